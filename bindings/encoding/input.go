@@ -235,6 +235,17 @@ var (
 		{Name: "TaikoData.Transition", Type: transitionComponentsType},
 		{Name: "TaikoData.TierProof", Type: tierProofComponentsType},
 	}
+	// SgxVerifier public inputs
+	stringType, _       = abi.NewType("string", "", nil)
+	sgxPublicInputsArgs = abi.Arguments{
+		{Name: "VERIFY_PROOF", Type: stringType},
+		{Name: "_chainId", Type: uint64Type},
+		{Name: "_verifierContract", Type: addressType},
+		{Name: "_tran", Type: transitionComponentsType},
+		{Name: "_newInstance", Type: addressType},
+		{Name: "_prover", Type: addressType},
+		{Name: "_metaHash", Type: bytes32Type},
+	}
 )
 
 // Contract ABIs.
@@ -388,6 +399,23 @@ func EncodeProveBlockInput(
 	return b, nil
 }
 
+// EncodeSgxPublicInputs performs the solidity `abi.encode` for the public inputs of a block transition,
+// which are signed by the SGX instance.
+func EncodeSgxPublicInputs(
+	chainID uint64,
+	verifierAddress common.Address,
+	transition *bindings.TaikoDataTransition,
+	newInstance common.Address,
+	prover common.Address,
+	metaHash common.Hash,
+) ([]byte, error) {
+	b, err := sgxPublicInputsArgs.Pack("VERIFY_PROOF", chainID, verifierAddress, transition, newInstance, prover, metaHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to abi.encode SGX public inputs, %w", err)
+	}
+	return b, nil
+}
+
 // UnpackTxListBytes unpacks the input data of a TaikoL1.proposeBlock transaction, and returns the txList bytes.
 func UnpackTxListBytes(txData []byte) ([]byte, error) {
 	method, err := TaikoL1ABI.MethodById(txData)
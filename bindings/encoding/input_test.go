@@ -53,6 +53,27 @@ func TestEncodeAssignmentHookInput(t *testing.T) {
 	require.NotNil(t, encoded)
 }
 
+func TestEncodeSgxPublicInputs(t *testing.T) {
+	encoded, err := EncodeSgxPublicInputs(
+		167001,
+		common.BytesToAddress(randomBytes(20)),
+		&bindings.TaikoDataTransition{
+			ParentHash: randomHash(),
+			BlockHash:  randomHash(),
+			StateRoot:  randomHash(),
+			Graffiti:   randomHash(),
+		},
+		common.BytesToAddress(randomBytes(20)),
+		common.BytesToAddress(randomBytes(20)),
+		randomHash(),
+	)
+
+	require.Nil(t, err)
+	// 10 head words, with the 4 words of the static transition tuple inline, and 2 words of the string tail.
+	require.Len(t, encoded, 12*32)
+	require.Equal(t, common.RightPadBytes([]byte("VERIFY_PROOF"), 32), encoded[11*32:])
+}
+
 func TestUnpackTxListBytes(t *testing.T) {
 	_, err := UnpackTxListBytes(randomBytes(1024))
 	require.NotNil(t, err)
@@ -0,0 +1,39 @@
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+// Optional flags used by the accounting export command.
+var (
+	AccountingFormat = &cli.StringFlag{
+		Name:     "accounting.format",
+		Usage:    "Export format, json or csv",
+		Value:    "json",
+		Category: accountCategory,
+		EnvVars:  []string{"ACCOUNTING_FORMAT"},
+	}
+	AccountingSummary = &cli.BoolFlag{
+		Name:     "accounting.summary",
+		Usage:    "Export the P&L of each period, instead of the records of each block",
+		Category: accountCategory,
+		EnvVars:  []string{"ACCOUNTING_SUMMARY"},
+	}
+	AccountingOutput = &cli.StringFlag{
+		Name:     "accounting.output",
+		Usage:    "File to write the export to, if not set, the export is written to the standard output",
+		Category: accountCategory,
+		EnvVars:  []string{"ACCOUNTING_OUTPUT"},
+	}
+)
+
+// AccountingExportFlags All accounting export flags.
+var AccountingExportFlags = []cli.Flag{
+	AccountingDir,
+	AccountingPeriod,
+	AccountingFormat,
+	AccountingSummary,
+	AccountingOutput,
+	Verbosity,
+	LogJSON,
+}
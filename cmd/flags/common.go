@@ -14,6 +14,12 @@ var (
 	driverCategory   = "DRIVER"
 	proposerCategory = "PROPOSER"
 	proverCategory   = "PROVER"
+	proveCategory    = "PROVE"
+	sgxCategory      = "SGX"
+	guardianCategory = "GUARDIAN_HEALTHCHECK"
+	watchCategory    = "WATCHTOWER"
+	accountCategory  = "ACCOUNTING"
+	verifierCategory = "VERIFIER"
 	txmgrCategory    = "TX_MANAGER"
 )
 
@@ -67,24 +73,22 @@ var (
 		Category: commonCategory,
 		EnvVars:  []string{"JWT_SECRET"},
 	}
+	// The contract addresses are required, unless they are resolved from the AddressManager.
 	TaikoL1Address = &cli.StringFlag{
 		Name:     "taikoL1",
 		Usage:    "TaikoL1 contract `address`",
-		Required: true,
 		Category: commonCategory,
 		EnvVars:  []string{"TAIKO_L1"},
 	}
 	TaikoL2Address = &cli.StringFlag{
 		Name:     "taikoL2",
 		Usage:    "TaikoL2 contract `address`",
-		Required: true,
 		Category: commonCategory,
 		EnvVars:  []string{"TAIKO_L2"},
 	}
 	TaikoTokenAddress = &cli.StringFlag{
 		Name:     "taikoToken",
 		Usage:    "TaikoToken contract `address`",
-		Required: true,
 		Category: commonCategory,
 		EnvVars:  []string{"TAIKO_TOKEN"},
 	}
@@ -152,6 +156,19 @@ var (
 		Category: commonCategory,
 		EnvVars:  []string{"ASSIGNMENT_HOOK_ADDRESS"},
 	}
+	AddressManagerAddress = &cli.StringFlag{
+		Name:     "addressManager",
+		Usage:    "AddressManager contract `address`, the contract addresses not given are resolved from it",
+		Category: commonCategory,
+		EnvVars:  []string{"ADDRESS_MANAGER"},
+	}
+	ResolveAddresses = &cli.BoolFlag{
+		Name:     "resolveAddresses",
+		Usage:    "Resolve the contract addresses not given from the AddressManager of TaikoL1",
+		Value:    false,
+		Category: commonCategory,
+		EnvVars:  []string{"RESOLVE_ADDRESSES"},
+	}
 )
 
 // CommonFlags All common flags.
@@ -161,6 +178,8 @@ var CommonFlags = []cli.Flag{
 	TaikoL1Address,
 	TaikoL2Address,
 	// Optional
+	AddressManagerAddress,
+	ResolveAddresses,
 	Verbosity,
 	LogJSON,
 	MetricsEnabled,
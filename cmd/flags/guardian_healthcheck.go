@@ -0,0 +1,51 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Optional flags used by the guardian health check server.
+var (
+	HealthCheckPort = &cli.Uint64Flag{
+		Name:     "healthcheck.port",
+		Usage:    "Port to expose for the guardian health check server",
+		Value:    3000,
+		Category: guardianCategory,
+		EnvVars:  []string{"HEALTHCHECK_PORT"},
+	}
+	HealthCheckLivenessTimeout = &cli.DurationFlag{
+		Name:     "healthcheck.livenessTimeout",
+		Usage:    "A guardian is considered down if no heartbeat is received within this duration",
+		Value:    1 * time.Minute,
+		Category: guardianCategory,
+		EnvVars:  []string{"HEALTHCHECK_LIVENESS_TIMEOUT"},
+	}
+	HealthCheckRetention = &cli.Uint64Flag{
+		Name:     "healthcheck.retention",
+		Usage:    "Number of the latest blocks whose guardian signatures are kept",
+		Value:    10_000,
+		Category: guardianCategory,
+		EnvVars:  []string{"HEALTHCHECK_RETENTION"},
+	}
+)
+
+// GuardianHealthCheckFlags All guardian health check server flags.
+var GuardianHealthCheckFlags = []cli.Flag{
+	// Required
+	L1WSEndpoint,
+	GuardianProverMajority,
+	// Optional
+	GuardianProverMinority,
+	AddressManagerAddress,
+	Verbosity,
+	LogJSON,
+	MetricsEnabled,
+	MetricsAddr,
+	MetricsPort,
+	RPCTimeout,
+	HealthCheckPort,
+	HealthCheckLivenessTimeout,
+	HealthCheckRetention,
+}
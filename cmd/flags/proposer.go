@@ -33,6 +33,12 @@ var (
 
 // Optional flags used by proposer.
 var (
+	ProverAPIKey = &cli.StringFlag{
+		Name:     "proverApiKey",
+		Usage:    "API key sent to the prover endpoints in the X-API-Key header when requesting assignments",
+		Category: proposerCategory,
+		EnvVars:  []string{"PROVER_API_KEY"},
+	}
 	// Tier fee related.
 	OptimisticTierFee = &cli.Float64Flag{
 		Name:     "tierFee.optimistic",
@@ -158,6 +164,7 @@ var ProposerFlags = MergeFlags(CommonFlags, []cli.Flag{
 	MinProposingInternal,
 	MaxProposedTxListsPerEpoch,
 	ProverEndpoints,
+	ProverAPIKey,
 	OptimisticTierFee,
 	SgxTierFee,
 	TierFeePriceBump,
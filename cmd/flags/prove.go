@@ -0,0 +1,42 @@
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+// Required flags used by the prove command.
+var (
+	ProveBlockID = &cli.Uint64Flag{
+		Name:     "blockID",
+		Usage:    "ID of the L2 block to prove",
+		Required: true,
+		Category: proveCategory,
+	}
+)
+
+// Optional flags used by the prove command.
+var (
+	ProveTier = &cli.UintFlag{
+		Name:     "tier",
+		Usage:    "Tier of the proof, the minimum tier of the block is used by default",
+		Category: proveCategory,
+	}
+	ProveContest = &cli.BoolFlag{
+		Name:     "contest",
+		Usage:    "Contest the invalid proof submitted for the block, instead of proving it",
+		Category: proveCategory,
+	}
+	ProveDryRun = &cli.BoolFlag{
+		Name:     "dry-run",
+		Usage:    "Generate the proof without sending any transaction",
+		Category: proveCategory,
+	}
+)
+
+// ProveFlags All prove command flags.
+var ProveFlags = MergeFlags(ProverFlags, []cli.Flag{
+	ProveBlockID,
+	ProveTier,
+	ProveContest,
+	ProveDryRun,
+})
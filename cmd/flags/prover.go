@@ -74,6 +74,35 @@ var (
 		Value:    false,
 		EnvVars:  []string{"PROVER_PROVE_UNASSIGNED_BLOCKS"},
 	}
+	MaxUnassignedProofs = &cli.Uint64Flag{
+		Name:     "unassigned.maxProofs",
+		Usage:    "Maximum number of expired blocks proving opportunistically at the same time, 0 means no limit",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"UNASSIGNED_MAX_PROOFS"},
+	}
+	UnassignedSgxProofCost = &cli.Float64Flag{
+		Name:     "unassigned.sgxProofCost",
+		Usage:    "Estimated cost in Ether of generating and submitting a SGX proof for an expired block",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"UNASSIGNED_SGX_PROOF_COST"},
+	}
+	UnassignedSgxAndZkVMProofCost = &cli.Float64Flag{
+		Name:     "unassigned.sgxAndZkvmProofCost",
+		Usage:    "Estimated cost in Ether of generating and submitting a SGX + zkVM proof for an expired block",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"UNASSIGNED_SGX_AND_ZKVM_PROOF_COST"},
+	}
+	UnassignedBondTokenPrice = &cli.Float64Flag{
+		Name: "unassigned.bondTokenPrice",
+		Usage: "Price of one Taiko token in Ether, used to rank the expired blocks by their liveness bonds " +
+			"minus the proof costs, 0 means the proof costs are ignored",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"UNASSIGNED_BOND_TOKEN_PRICE"},
+	}
 	MinEthBalance = &cli.Float64Flag{
 		Name:     "prover.minEthBalance",
 		Usage:    "Minimum ETH balance (in Ether) a prover wants to keep",
@@ -107,6 +136,175 @@ var (
 		Category: proverCategory,
 		EnvVars:  []string{"MIN_TIER_FEE_SGX_AND_ZKVM"},
 	}
+	// Dynamic pricing related.
+	DynamicPricing = &cli.BoolFlag{
+		Name:     "pricing.dynamic",
+		Usage:    "Whether to price each assignment with the dynamic fee formula, the minimum tier fees are used as floors",
+		Value:    false,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_DYNAMIC"},
+	}
+	PricingProveBlockGas = &cli.Uint64Flag{
+		Name:     "pricing.proveBlockGas",
+		Usage:    "Expected gas used by a TaikoL1.proveBlock transaction",
+		Value:    300_000,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_PROVE_BLOCK_GAS"},
+	}
+	PricingExpectedL2Gas = &cli.Uint64Flag{
+		Name:     "pricing.expectedL2Gas",
+		Usage:    "Expected gas used by a L2 block, 0 means using the protocol block max gas limit",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_EXPECTED_L2_GAS"},
+	}
+	PricingSgxCostPerGas = &cli.Uint64Flag{
+		Name:     "pricing.sgxCostPerGas",
+		Usage:    "Raiko cost in wei per unit of L2 gas for generating a SGX proof",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_SGX_COST_PER_GAS"},
+	}
+	PricingSgxAndZkVMCostPerGas = &cli.Uint64Flag{
+		Name:     "pricing.sgxAndZkvmCostPerGas",
+		Usage:    "Raiko cost in wei per unit of L2 gas for generating a SGX + zkVM proof",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_SGX_AND_ZKVM_COST_PER_GAS"},
+	}
+	PricingUtilizationPremium = &cli.Float64Flag{
+		Name:     "pricing.utilizationPremium",
+		Usage:    "Fee multiplier added when the prover is fully utilized, 1.0 doubles the fee at full capacity",
+		Value:    1.0,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_UTILIZATION_PREMIUM"},
+	}
+	PricingBondCostRate = &cli.Float64Flag{
+		Name:     "pricing.bondCostRate",
+		Usage:    "Annual opportunity cost rate of the locked liveness bond",
+		Value:    0.05,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_BOND_COST_RATE"},
+	}
+	PricingBondTokenPrice = &cli.Float64Flag{
+		Name:     "pricing.bondTokenPrice",
+		Usage:    "Price of one Taiko token in Ether, used to calculate the liveness bond opportunity cost",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_BOND_TOKEN_PRICE"},
+	}
+	PricingProfitMargin = &cli.Float64Flag{
+		Name:     "pricing.profitMargin",
+		Usage:    "Profit margin added on top of the total proving cost",
+		Value:    0.1,
+		Category: proverCategory,
+		EnvVars:  []string{"PRICING_PROFIT_MARGIN"},
+	}
+	// Proposer authentication related.
+	AllowedProposers = &cli.StringFlag{
+		Name:     "prover.allowedProposers",
+		Usage:    "Comma-delineated list of proposer addresses allowed to request assignments, empty means any proposer",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_ALLOWED_PROPOSERS"},
+	}
+	RequireProposerSignature = &cli.BoolFlag{
+		Name:     "prover.requireProposerSignature",
+		Usage:    "Whether assignment requests must be signed by the proposer, always required with an allowlist",
+		Value:    false,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_REQUIRE_PROPOSER_SIGNATURE"},
+	}
+	APIKeys = &cli.StringFlag{
+		Name:     "prover.apiKeys",
+		Usage:    "Comma-delineated list of API keys accepted in the X-API-Key header, empty means no API key needed",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_API_KEYS"},
+	}
+	ProposerRequestsPerMinute = &cli.Uint64Flag{
+		Name:     "prover.proposerRequestsPerMinute",
+		Usage:    "Maximum number of assignment requests per minute for each proposer, 0 means no limit",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_PROPOSER_REQUESTS_PER_MINUTE"},
+	}
+	MaxOutstandingAssignments = &cli.Uint64Flag{
+		Name:     "prover.maxOutstandingAssignments",
+		Usage:    "Maximum number of signed and not yet expired assignments for each proposer, 0 means no limit",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_MAX_OUTSTANDING_ASSIGNMENTS"},
+	}
+	MaxBondExposureRatio = &cli.Float64Flag{
+		Name: "prover.maxBondExposureRatio",
+		Usage: "Maximum ratio of the liveness bonds locked in unproven blocks and promised in outstanding assignments " +
+			"to the prover's total bond capital, new assignments will be refused beyond it, 0 means no limit",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_MAX_BOND_EXPOSURE_RATIO"},
+	}
+	SchedulerStateFile = &cli.StringFlag{
+		Name:     "prover.schedulerStateFile",
+		Usage:    "File to persist the scheduled proof requests and the proof generation time samples across restarts",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_SCHEDULER_STATE_FILE"},
+	}
+	AssignmentLedgerStateFile = &cli.StringFlag{
+		Name:     "prover.assignmentLedgerStateFile",
+		Usage:    "File to persist the signed assignments and their on-chain status across restarts",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_ASSIGNMENT_LEDGER_STATE_FILE"},
+	}
+	ProofCacheSize = &cli.Uint64Flag{
+		Name:     "prover.proofCacheSize",
+		Usage:    "Maximum number of the generated proofs cached until their blocks are verified",
+		Value:    1024,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_PROOF_CACHE_SIZE"},
+	}
+	DropLateProofs = &cli.BoolFlag{
+		Name: "prover.dropLateProofs",
+		Usage: "Whether to drop the proofs which are predicted to miss their proving windows, " +
+			"instead of generating them with the lowest priority",
+		Value:    false,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_DROP_LATE_PROOFS"},
+	}
+	SubmissionTargetBaseFee = &cli.Float64Flag{
+		Name: "prover.submissionTargetBaseFee",
+		Usage: "Hold the proofs of the blocks assigned to this prover while the L1 base fee (in GWei) is above this target, " +
+			"zero means submitting the proofs immediately",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_SUBMISSION_TARGET_BASE_FEE"},
+	}
+	SubmissionSafetyMargin = &cli.DurationFlag{
+		Name:     "prover.submissionSafetyMargin",
+		Usage:    "Submit a held proof at this long before its proving window closes, whatever the L1 base fee is",
+		Value:    15 * time.Minute,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_SUBMISSION_SAFETY_MARGIN"},
+	}
+	IdentitiesFile = &cli.StringFlag{
+		Name: "prover.identitiesFile",
+		Usage: "JSON file of the additional prover identities, each entry has a privateKey, and optionally its own " +
+			"capacity, minOptimisticTierFee, minSgxTierFee and minSgxAndZkVMTierFee (in GWei)",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_IDENTITIES_FILE"},
+	}
+	ChainsFile = &cli.StringFlag{
+		Name: "prover.chainsFile",
+		Usage: "JSON file of the additional L2 chains settling on the same L1, each entry has its l2WsEndpoint, " +
+			"l2HttpEndpoint, taikoL1Address, taikoL2Address, assignmentHookAddress, and optionally its own " +
+			"taikoTokenAddress and raikoL2Endpoint",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_CHAINS_FILE"},
+	}
+	SgxInstanceExpiryAlert = &cli.DurationFlag{
+		Name:     "prover.sgxInstanceExpiryAlert",
+		Usage:    "Warn when the SGX instance which signs the proofs expires within this duration",
+		Value:    24 * time.Hour,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_SGX_INSTANCE_EXPIRY_ALERT"},
+	}
 	// Running mode
 	ContesterMode = &cli.BoolFlag{
 		Name:     "mode.contester",
@@ -115,6 +313,49 @@ var (
 		Value:    false,
 		EnvVars:  []string{"MODE_CONTESTER"},
 	}
+	ContestConfidence = &cli.Float64Flag{
+		Name:     "contester.confidence",
+		Usage:    "Probability that the local L2 chain is correct when it differs from a proven transition",
+		Value:    0.99,
+		Category: proverCategory,
+		EnvVars:  []string{"CONTESTER_CONFIDENCE"},
+	}
+	ContestSgxProofCost = &cli.Float64Flag{
+		Name:     "contester.sgxProofCost",
+		Usage:    "Estimated cost in Ether of generating and submitting a SGX proof",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"CONTESTER_SGX_PROOF_COST"},
+	}
+	ContestSgxAndZkVMProofCost = &cli.Float64Flag{
+		Name:     "contester.sgxAndZkvmProofCost",
+		Usage:    "Estimated cost in Ether of generating and submitting a SGX + zkVM proof",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"CONTESTER_SGX_AND_ZKVM_PROOF_COST"},
+	}
+	ContestBondTokenPrice = &cli.Float64Flag{
+		Name: "contester.bondTokenPrice",
+		Usage: "Price of one Taiko token in Ether, used to compare the bonds with the proof costs, " +
+			"0 means the proof costs are ignored",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"CONTESTER_BOND_TOKEN_PRICE"},
+	}
+	ContestMaxBondsAtRisk = &cli.Float64Flag{
+		Name:     "contester.maxBondsAtRisk",
+		Usage:    "Maximum total Taiko token bonds at risk in the ongoing contests and escalations, 0 means no limit",
+		Value:    0,
+		Category: proverCategory,
+		EnvVars:  []string{"CONTESTER_MAX_BONDS_AT_RISK"},
+	}
+	ContestMinCooldownRemaining = &cli.DurationFlag{
+		Name:     "contester.minCooldownRemaining",
+		Usage:    "Minimum remaining cooldown window required to contest a transition",
+		Value:    1 * time.Minute,
+		Category: proverCategory,
+		EnvVars:  []string{"CONTESTER_MIN_COOLDOWN_REMAINING"},
+	}
 	// HTTP server related.
 	ProverHTTPServerPort = &cli.Uint64Flag{
 		Name:     "prover.port",
@@ -166,6 +407,20 @@ var (
 		Category: proverCategory,
 		EnvVars:  []string{"PROVER_GUARDIAN_PROVER_HEALTH_CHECK_SERVER_ENDPOINT"},
 	}
+	GuardianOutboxFile = &cli.StringFlag{
+		Name: "prover.guardianOutboxFile",
+		Usage: "File to persist the signed blocks and heartbeats not yet delivered to the guardian prover " +
+			"health check server across restarts",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_GUARDIAN_OUTBOX_FILE"},
+	}
+	GuardianOutboxSize = &cli.IntFlag{
+		Name:     "prover.guardianOutboxSize",
+		Usage:    "Maximum number of the undelivered signed blocks and heartbeats, the oldest one is dropped when full",
+		Value:    1000,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_GUARDIAN_OUTBOX_SIZE"},
+	}
 	// Guardian prover specific flag
 	GuardianProverMinority = &cli.StringFlag{
 		Name:     "guardianProverMinority",
@@ -187,6 +442,13 @@ var (
 		Category: proverCategory,
 		EnvVars:  []string{"GUARDIAN_SUBMISSION_DELAY"},
 	}
+	GuardianQuorumStuckThreshold = &cli.DurationFlag{
+		Name:     "guardian.quorumStuckThreshold",
+		Usage:    "Alert when a transition approved by some guardians stays below quorum for longer than this duration",
+		Value:    30 * time.Minute,
+		Category: proverCategory,
+		EnvVars:  []string{"GUARDIAN_QUORUM_STUCK_THRESHOLD"},
+	}
 	EnableLivenessBondProof = &cli.BoolFlag{
 		Name:     "prover.enableLivenessBondProof",
 		Usage:    "Toggles whether the proof is a dummy proof or returns keccak256(RETURN_LIVENESS_BOND) as proof",
@@ -206,6 +468,26 @@ var (
 		Category: proverCategory,
 		EnvVars:  []string{"PROVER_L2_NODE_VERSION"},
 	}
+	PipelineWorkers = &cli.Uint64Flag{
+		Name:     "prover.pipelineWorkers",
+		Usage:    "Number of workers of each prover pipeline stage, the proof generation stage uses the prover capacity",
+		Value:    4,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_PIPELINE_WORKERS"},
+	}
+	AccountingDir = &cli.StringFlag{
+		Name:     "prover.accountingDir",
+		Usage:    "Directory to persist the prover accounting records, if not set, the records are only kept in memory",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_ACCOUNTING_DIR"},
+	}
+	AccountingPeriod = &cli.DurationFlag{
+		Name:     "prover.accountingPeriod",
+		Usage:    "Period of the prover P&L, the P&L metrics are reset at the start of each period",
+		Value:    24 * time.Hour,
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_ACCOUNTING_PERIOD"},
+	}
 	// Confirmations specific flag
 	BlockConfirmations = &cli.Uint64Flag{
 		Name:     "prover.blockConfirmations",
@@ -237,10 +519,23 @@ var ProverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	GuardianProverMinority,
 	GuardianProverMajority,
 	GuardianProofSubmissionDelay,
+	GuardianQuorumStuckThreshold,
 	GuardianProverHealthCheckServerEndpoint,
+	GuardianOutboxFile,
+	GuardianOutboxSize,
 	Graffiti,
 	ProveUnassignedBlocks,
+	MaxUnassignedProofs,
+	UnassignedSgxProofCost,
+	UnassignedSgxAndZkVMProofCost,
+	UnassignedBondTokenPrice,
 	ContesterMode,
+	ContestConfidence,
+	ContestSgxProofCost,
+	ContestSgxAndZkVMProofCost,
+	ContestBondTokenPrice,
+	ContestMaxBondsAtRisk,
+	ContestMinCooldownRemaining,
 	ProverHTTPServerPort,
 	ProverCapacity,
 	MaxExpiry,
@@ -252,4 +547,31 @@ var ProverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	L1NodeVersion,
 	L2NodeVersion,
 	BlockConfirmations,
+	PipelineWorkers,
+	AccountingDir,
+	AccountingPeriod,
+	DynamicPricing,
+	PricingProveBlockGas,
+	PricingExpectedL2Gas,
+	PricingSgxCostPerGas,
+	PricingSgxAndZkVMCostPerGas,
+	PricingUtilizationPremium,
+	PricingBondCostRate,
+	PricingBondTokenPrice,
+	PricingProfitMargin,
+	AllowedProposers,
+	RequireProposerSignature,
+	APIKeys,
+	ProposerRequestsPerMinute,
+	MaxOutstandingAssignments,
+	MaxBondExposureRatio,
+	SchedulerStateFile,
+	AssignmentLedgerStateFile,
+	ProofCacheSize,
+	DropLateProofs,
+	SubmissionTargetBaseFee,
+	SubmissionSafetyMargin,
+	IdentitiesFile,
+	ChainsFile,
+	SgxInstanceExpiryAlert,
 }, TxmgrFlags)
@@ -0,0 +1,65 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Optional flags used by the sgx commands.
+var (
+	SgxVerifierAddress = &cli.StringFlag{
+		Name:     "sgx.verifier",
+		Usage:    "SgxVerifier contract `address`, resolved by the TaikoL1 contract by default",
+		Category: sgxCategory,
+		EnvVars:  []string{"SGX_VERIFIER"},
+	}
+	SgxAttestation = &cli.StringFlag{
+		Name: "sgx.attestation",
+		Usage: "Path to the raiko bootstrap output, which contains the SGX quote and the new instance address, " +
+			"or to a file containing the hex encoded SGX quote",
+		Category: sgxCategory,
+		EnvVars:  []string{"SGX_ATTESTATION"},
+	}
+	SgxInstance = &cli.StringFlag{
+		Name:     "sgx.instance",
+		Usage:    "SGX instance `address` to check, the instance attested by --sgx.attestation is used by default",
+		Category: sgxCategory,
+		EnvVars:  []string{"SGX_INSTANCE"},
+	}
+	SgxValidFor = &cli.DurationFlag{
+		Name:     "sgx.validFor",
+		Usage:    "Minimum duration for which the checked SGX instance should stay valid",
+		Value:    24 * time.Hour,
+		Category: sgxCategory,
+		EnvVars:  []string{"SGX_VALID_FOR"},
+	}
+)
+
+// SgxFlags All sgx list command flags.
+var SgxFlags = []cli.Flag{
+	// Required
+	L1WSEndpoint,
+	TaikoL1Address,
+	// Optional
+	AddressManagerAddress,
+	ResolveAddresses,
+	Verbosity,
+	LogJSON,
+	RPCTimeout,
+	SgxVerifierAddress,
+}
+
+// SgxRegisterFlags All sgx register command flags.
+var SgxRegisterFlags = MergeFlags(SgxFlags, []cli.Flag{
+	L1HTTPEndpoint,
+	L1ProverPrivKey,
+	SgxAttestation,
+}, TxmgrFlags)
+
+// SgxCheckFlags All sgx check command flags.
+var SgxCheckFlags = MergeFlags(SgxFlags, []cli.Flag{
+	SgxInstance,
+	SgxAttestation,
+	SgxValidFor,
+})
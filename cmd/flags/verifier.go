@@ -0,0 +1,70 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Required flags used by the block verifier.
+var (
+	L1VerifierPrivKey = &cli.StringFlag{
+		Name:     "l1.verifierPrivKey",
+		Usage:    "Private key of the L1 block verifier, who will send TaikoL1.verifyBlocks transactions",
+		Required: true,
+		Category: verifierCategory,
+		EnvVars:  []string{"L1_VERIFIER_PRIV_KEY"},
+	}
+)
+
+// Optional flags used by the block verifier.
+var (
+	VerifierCheckInterval = &cli.DurationFlag{
+		Name:     "verifier.checkInterval",
+		Usage:    "Interval of checking how many blocks can be verified",
+		Value:    1 * time.Minute,
+		Category: verifierCategory,
+		EnvVars:  []string{"VERIFIER_CHECK_INTERVAL"},
+	}
+	VerifierMinBlocks = &cli.Uint64Flag{
+		Name:     "verifier.minBlocks",
+		Usage:    "Minimum number of verifiable blocks to send a TaikoL1.verifyBlocks transaction",
+		Value:    4,
+		Category: verifierCategory,
+		EnvVars:  []string{"VERIFIER_MIN_BLOCKS"},
+	}
+	VerifierMaxBlocks = &cli.Uint64Flag{
+		Name:     "verifier.maxBlocks",
+		Usage:    "Maximum number of blocks to verify in a TaikoL1.verifyBlocks transaction",
+		Value:    16,
+		Category: verifierCategory,
+		EnvVars:  []string{"VERIFIER_MAX_BLOCKS"},
+	}
+	VerifierMaxDelay = &cli.DurationFlag{
+		Name: "verifier.maxDelay",
+		Usage: "Verify the blocks even if fewer than the minimum number are verifiable, " +
+			"once the oldest one has been verifiable for this duration",
+		Value:    10 * time.Minute,
+		Category: verifierCategory,
+		EnvVars:  []string{"VERIFIER_MAX_DELAY"},
+	}
+	VerifierGasBudget = &cli.Uint64Flag{
+		Name:     "verifier.gasBudget",
+		Usage:    "Maximum gas of a TaikoL1.verifyBlocks transaction, fewer blocks are verified to stay within it",
+		Value:    3_000_000,
+		Category: verifierCategory,
+		EnvVars:  []string{"VERIFIER_GAS_BUDGET"},
+	}
+)
+
+// VerifierFlags All block verifier flags.
+var VerifierFlags = MergeFlags(CommonFlags, []cli.Flag{
+	L2WSEndpoint,
+	L1HTTPEndpoint,
+	L1VerifierPrivKey,
+	VerifierCheckInterval,
+	VerifierMinBlocks,
+	VerifierMaxBlocks,
+	VerifierMaxDelay,
+	VerifierGasBudget,
+}, TxmgrFlags)
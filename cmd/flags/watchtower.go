@@ -0,0 +1,31 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Optional flags used by the watchtower.
+var (
+	WatchtowerWebhook = &cli.StringFlag{
+		Name:     "watchtower.webhook",
+		Usage:    "Webhook URL which the alerts are posted to as JSON, if not set, the alerts are only logged",
+		Category: watchCategory,
+		EnvVars:  []string{"WATCHTOWER_WEBHOOK"},
+	}
+	WatchtowerVerificationAlert = &cli.DurationFlag{
+		Name:     "watchtower.verificationAlert",
+		Usage:    "Alert when an invalid transition can be verified within this duration",
+		Value:    1 * time.Hour,
+		Category: watchCategory,
+		EnvVars:  []string{"WATCHTOWER_VERIFICATION_ALERT"},
+	}
+)
+
+// WatchtowerFlags All watchtower flags.
+var WatchtowerFlags = MergeFlags(CommonFlags, []cli.Flag{
+	L2WSEndpoint,
+	WatchtowerWebhook,
+	WatchtowerVerificationAlert,
+})
@@ -12,6 +12,11 @@ import (
 	"github.com/taikoxyz/taiko-client/internal/version"
 	"github.com/taikoxyz/taiko-client/proposer"
 	"github.com/taikoxyz/taiko-client/prover"
+	"github.com/taikoxyz/taiko-client/prover/accounting"
+	blockverifier "github.com/taikoxyz/taiko-client/prover/block_verifier"
+	healthcheck "github.com/taikoxyz/taiko-client/prover/guardian_healthcheck"
+	manager "github.com/taikoxyz/taiko-client/prover/sgx_manager"
+	"github.com/taikoxyz/taiko-client/prover/watchtower"
 )
 
 func main() {
@@ -32,6 +37,7 @@ func main() {
 			Flags:       flags.DriverFlags,
 			Usage:       "Starts the driver software",
 			Description: "Taiko driver software",
+			Before:      utils.ResolveAddresses(),
 			Action:      utils.SubcommandAction(new(driver.Driver)),
 		},
 		{
@@ -39,6 +45,7 @@ func main() {
 			Flags:       flags.ProposerFlags,
 			Usage:       "Starts the proposer software",
 			Description: "Taiko proposer software",
+			Before:      utils.ResolveAddresses(),
 			Action:      utils.SubcommandAction(new(proposer.Proposer)),
 		},
 		{
@@ -46,8 +53,86 @@ func main() {
 			Flags:       flags.ProverFlags,
 			Usage:       "Starts the prover software",
 			Description: "Taiko prover software",
+			Before:      utils.ResolveAddresses(),
 			Action:      utils.SubcommandAction(new(prover.Prover)),
 		},
+		{
+			Name:        "prove",
+			Flags:       flags.ProveFlags,
+			Usage:       "Proves or contests a single L2 block",
+			Description: "Proves, or contests, the given L2 block once, and prints the result",
+			Before:      utils.ResolveAddresses(),
+			Action:      utils.OneShotAction(new(prover.BlockProver)),
+		},
+		{
+			Name:        "guardian-healthcheck",
+			Flags:       flags.GuardianHealthCheckFlags,
+			Usage:       "Starts the guardian health check server",
+			Description: "Guardian prover health check server, which tracks the heartbeats and signed blocks",
+			Before:      utils.ResolveAddresses(flags.GuardianProverMajority, flags.GuardianProverMinority),
+			Action:      utils.SubcommandAction(new(healthcheck.Server)),
+		},
+		{
+			Name:        "watchtower",
+			Flags:       flags.WatchtowerFlags,
+			Usage:       "Starts the watchtower",
+			Description: "Watchtower which alerts on the invalid transitions and contests, without submitting anything",
+			Before:      utils.ResolveAddresses(),
+			Action:      utils.SubcommandAction(new(watchtower.Watchtower)),
+		},
+		{
+			Name:        "verifier",
+			Flags:       flags.VerifierFlags,
+			Usage:       "Starts the block verifier",
+			Description: "Block verifier which sends TaikoL1.verifyBlocks transactions once enough blocks are verifiable",
+			Before:      utils.ResolveAddresses(),
+			Action:      utils.SubcommandAction(new(blockverifier.BlockVerifier)),
+		},
+		{
+			Name:        "accounting",
+			Usage:       "Manages the prover accounting records",
+			Description: "Manages the fees, bonds and gas costs records persisted by a prover",
+			Subcommands: []*cli.Command{
+				{
+					Name:        "export",
+					Flags:       flags.AccountingExportFlags,
+					Usage:       "Exports the prover accounting records",
+					Description: "Exports the records of the verified blocks, or their P&L of each period, as JSON or CSV",
+					Action:      utils.OneShotAction(new(accounting.Exporter)),
+				},
+			},
+		},
+		{
+			Name:        "sgx",
+			Usage:       "Manages the SGX instances",
+			Description: "Manages the SGX instances registered in the SgxVerifier contract",
+			Subcommands: []*cli.Command{
+				{
+					Name:        "list",
+					Flags:       flags.SgxFlags,
+					Usage:       "Lists the registered SGX instances",
+					Description: "Lists the registered SGX instances, with their validity and expiry",
+					Before:      utils.ResolveAddresses(),
+					Action:      utils.OneShotAction(new(manager.InstanceLister)),
+				},
+				{
+					Name:        "register",
+					Flags:       flags.SgxRegisterFlags,
+					Usage:       "Registers a new SGX instance",
+					Description: "Registers a new SGX instance from the raiko bootstrap output",
+					Before:      utils.ResolveAddresses(),
+					Action:      utils.OneShotAction(new(manager.InstanceRegisterer)),
+				},
+				{
+					Name:        "check",
+					Flags:       flags.SgxCheckFlags,
+					Usage:       "Checks an SGX instance",
+					Description: "Checks whether an SGX instance is registered, and stays valid for the given duration",
+					Before:      utils.ResolveAddresses(),
+					Action:      utils.OneShotAction(new(manager.InstanceChecker)),
+				},
+			},
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/cmd/logger"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// contractAddress is a contract address flag, whose contract is registered in the AddressManager.
+type contractAddress struct {
+	flag *cli.StringFlag
+	name string
+	// Whether the contract is deployed on L2, then it's resolved with the L2 chain ID.
+	l2 bool
+	// Whether the flag is required if the address is not resolved.
+	required bool
+}
+
+// contractAddresses are all the contract address flags which can be resolved from the AddressManager.
+var contractAddresses = []*contractAddress{
+	{flag: flags.TaikoL1Address, name: rpc.ContractNameTaiko, required: true},
+	{flag: flags.TaikoL2Address, name: rpc.ContractNameTaiko, l2: true, required: true},
+	{flag: flags.TaikoTokenAddress, name: rpc.ContractNameTaikoToken, required: true},
+	{flag: flags.AssignmentHookAddress, name: rpc.ContractNameAssignmentHook},
+	{flag: flags.GuardianProverMajority, name: rpc.ContractNameGuardianProver},
+	{flag: flags.GuardianProverMinority, name: rpc.ContractNameGuardianProverMinority},
+}
+
+// ResolveAddresses returns a command's before function, which resolves the contract address flags not given
+// from the AddressManager, if `--addressManager` or `--resolveAddresses` is set. The guardian prover addresses
+// make a prover run as a guardian prover, so they are only filled in if they are in the given flags, otherwise
+// they are only compared with the given ones. The resolved addresses are printed, and the given ones which
+// disagree with the AddressManager are warned about.
+func ResolveAddresses(fill ...cli.Flag) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		logger.InitLogger(c)
+
+		if c.String(flags.AddressManagerAddress.Name) != "" || c.Bool(flags.ResolveAddresses.Name) {
+			if err := resolveAddresses(c.Context, c, fill); err != nil {
+				return fmt.Errorf("failed to resolve contract addresses: %w", err)
+			}
+		}
+
+		for _, contract := range contractAddresses {
+			if contract.required && hasFlag(c, contract.flag) && !c.IsSet(contract.flag.Name) {
+				return fmt.Errorf("Required flag %q not set", contract.flag.Name)
+			}
+		}
+
+		return nil
+	}
+}
+
+// resolveAddresses resolves the contract address flags of the command from the AddressManager.
+func resolveAddresses(ctx context.Context, c *cli.Context, fill []cli.Flag) error {
+	l1, err := rpc.NewEthClient(ctx, c.String(flags.L1WSEndpoint.Name), c.Duration(flags.RPCTimeout.Name))
+	if err != nil {
+		return err
+	}
+	l1ChainID := l1.ChainID
+
+	resolver, err := rpc.NewAddressResolver(
+		ctx,
+		l1,
+		common.HexToAddress(c.String(flags.AddressManagerAddress.Name)),
+		common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+	)
+	if err != nil {
+		return err
+	}
+	log.Info("Resolving contract addresses", "addressManager", resolver.Address(), "l1ChainID", l1ChainID)
+
+	resolved := make(map[*cli.StringFlag]common.Address)
+	for _, contract := range contractAddresses {
+		if !hasFlag(c, contract.flag) || contract.l2 {
+			continue
+		}
+		if resolved[contract.flag], err = resolver.Resolve(ctx, l1ChainID.Uint64(), contract.name); err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", contract.name, err)
+		}
+	}
+
+	// The L2 contracts are resolved with the L2 chain ID of TaikoL1, which should be the chain ID of
+	// the L2 node if given.
+	if hasFlag(c, flags.TaikoL2Address) {
+		l2ChainID, err := resolveL2ChainID(ctx, c, l1, resolved[flags.TaikoL1Address])
+		if err != nil {
+			return err
+		}
+		if resolved[flags.TaikoL2Address], err = resolver.Resolve(ctx, l2ChainID, rpc.ContractNameTaiko); err != nil {
+			return fmt.Errorf("failed to resolve L2 %s: %w", rpc.ContractNameTaiko, err)
+		}
+	}
+
+	for _, contract := range contractAddresses {
+		address, ok := resolved[contract.flag]
+		if !ok {
+			continue
+		}
+		if err := applyAddress(c, contract, address, fill); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveL2ChainID returns the L2 chain ID in the configurations of the given TaikoL1, and checks it against
+// the chain ID of the L2 node if its endpoint is given.
+func resolveL2ChainID(
+	ctx context.Context,
+	c *cli.Context,
+	l1 *rpc.EthClient,
+	resolvedTaikoL1 common.Address,
+) (uint64, error) {
+	taikoL1Address := resolvedTaikoL1
+	if c.IsSet(flags.TaikoL1Address.Name) {
+		taikoL1Address = common.HexToAddress(c.String(flags.TaikoL1Address.Name))
+	}
+
+	taikoL1, err := bindings.NewTaikoL1Client(taikoL1Address, l1)
+	if err != nil {
+		return 0, err
+	}
+	config, err := taikoL1.GetConfig(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get protocol configs of TaikoL1 %s: %w", taikoL1Address, err)
+	}
+
+	if hasFlag(c, flags.L2WSEndpoint) && c.IsSet(flags.L2WSEndpoint.Name) {
+		l2, err := rpc.NewEthClient(ctx, c.String(flags.L2WSEndpoint.Name), c.Duration(flags.RPCTimeout.Name))
+		if err != nil {
+			return 0, err
+		}
+		if l2.ChainID.Uint64() != config.ChainId {
+			return 0, fmt.Errorf("L2 node chain ID %d mismatches the TaikoL1 chain ID %d", l2.ChainID, config.ChainId)
+		}
+	}
+
+	return config.ChainId, nil
+}
+
+// applyAddress fills in the given resolved address if the flag is not given, or warns if the given address
+// disagrees with it.
+func applyAddress(c *cli.Context, contract *contractAddress, resolved common.Address, fill []cli.Flag) error {
+	name := contract.flag.Name
+	if resolved == rpc.ZeroAddress {
+		log.Warn("Contract not registered in the AddressManager", "flag", name, "contract", contract.name)
+		return nil
+	}
+
+	if c.IsSet(name) {
+		given := common.HexToAddress(c.String(name))
+		if given != resolved {
+			log.Warn(
+				"Contract address flag disagrees with the AddressManager",
+				"flag", name,
+				"given", given,
+				"resolved", resolved,
+			)
+			return nil
+		}
+		log.Info("Contract address", "flag", name, "address", given, "source", "flag")
+		return nil
+	}
+
+	if contract.flag == flags.GuardianProverMajority || contract.flag == flags.GuardianProverMinority {
+		if !slices.Contains(fill, cli.Flag(contract.flag)) {
+			log.Info("Contract address not filled in", "flag", name, "resolved", resolved)
+			return nil
+		}
+	}
+
+	log.Info("Contract address", "flag", name, "address", resolved, "source", "addressManager")
+	return c.Set(name, resolved.Hex())
+}
+
+// hasFlag checks whether the given flag is one of the command's flags.
+func hasFlag(c *cli.Context, flag cli.Flag) bool {
+	return c.Command != nil && slices.Contains(c.Command.Flags, flag)
+}
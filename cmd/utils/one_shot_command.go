@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/cmd/logger"
+)
+
+// OneShotApplication is an application which runs once and exits, instead of running until it is stopped.
+type OneShotApplication interface {
+	InitFromCli(context.Context, *cli.Context) error
+	Name() string
+	Run(context.Context) (interface{}, error)
+}
+
+// OneShotAction runs the given application once, and prints its result as JSON to the standard output.
+func OneShotAction(app OneShotApplication) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		logger.InitLogger(c)
+
+		ctx, ctxClose := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+		defer ctxClose()
+
+		if err := app.InitFromCli(ctx, c); err != nil {
+			return err
+		}
+
+		log.Info("Running Taiko client application", "name", app.Name())
+
+		result, err := app.Run(ctx)
+		if result != nil {
+			output, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(c.App.Writer, string(output))
+		}
+		if err != nil {
+			log.Error("Running application error", "name", app.Name(), "error", err)
+			return err
+		}
+
+		return nil
+	}
+}
@@ -51,8 +51,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.ProposeBlockResponse"
                         }
                     },
+                    "401": {
+                        "description": "invalid proposer signature",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "proposer not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "422": {
-                        "description": "prover does not have capacity",
+                        "description": "cannot finish proof in time",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "too many outstanding assignments for proposer",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "protocol paused",
                         "schema": {
                             "type": "string"
                         }
@@ -60,6 +84,174 @@ const docTemplate = `{
                 }
             }
         },
+        "/assignments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get signed assignments",
+                "operationId": "get-assignments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "filter by status: pending, used, expired_unused or proven",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "return the assignments after the given cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of the returned assignments, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.AssignmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid cursor or limit",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid API key",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "assignment ledger not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/assignments/stats": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get assignment statistics of each proposer",
+                "operationId": "get-assignment-stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "return the proposers after the given proposer address",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of the returned proposers, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.AssignmentStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid cursor or limit",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid API key",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "assignment ledger not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/chains/{chainID}/assignment": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Request a proof assignment for a block of the given L2 chain",
+                "operationId": "route-chain-assignment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "L2 chain ID",
+                        "name": "chainID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "assignment request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.CreateAssignmentRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.ProposeBlockResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "unknown chain",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quote": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get current proof fees quote",
+                "operationId": "get-quote",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.Quote"
+                        }
+                    }
+                }
+            }
+        },
         "/status": {
             "get": {
                 "consumes": [
@@ -82,6 +274,104 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "ledger.Assignment": {
+            "type": "object",
+            "properties": {
+                "blobHash": {
+                    "type": "string"
+                },
+                "blockID": {
+                    "type": "integer"
+                },
+                "expiry": {
+                    "type": "integer"
+                },
+                "maxBlockID": {
+                    "type": "integer"
+                },
+                "maxProposedIn": {
+                    "type": "integer"
+                },
+                "proposedIn": {
+                    "type": "integer"
+                },
+                "proposer": {
+                    "type": "string"
+                },
+                "prover": {
+                    "type": "string"
+                },
+                "requestID": {
+                    "type": "string"
+                },
+                "seq": {
+                    "description": "Sequence number of the assignment in the ledger, used as the pagination cursor.",
+                    "type": "integer"
+                },
+                "signedAt": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tierFees": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "updatedAt": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ledger.ProposerStats": {
+            "type": "object",
+            "properties": {
+                "expiredUnused": {
+                    "type": "integer"
+                },
+                "proven": {
+                    "type": "integer"
+                },
+                "signed": {
+                    "type": "integer"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.AssignmentStatsResponse": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "description": "Cursor to query the next page with, omitted if there are no more proposers.",
+                    "type": "string"
+                },
+                "stats": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/ledger.ProposerStats"
+                    }
+                }
+            }
+        },
+        "server.AssignmentsResponse": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ledger.Assignment"
+                    }
+                },
+                "nextCursor": {
+                    "description": "Cursor to query the next page with, omitted if there are no more assignments.",
+                    "type": "integer"
+                }
+            }
+        },
         "server.CreateAssignmentRequestBody": {
             "type": "object",
             "properties": {
@@ -97,9 +387,18 @@ const docTemplate = `{
                 "feeToken": {
                     "type": "string"
                 },
+                "nonce": {
+                    "type": "integer"
+                },
                 "proposer": {
                     "type": "string"
                 },
+                "signature": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "tierFees": {
                     "type": "array",
                     "items": {
@@ -128,6 +427,26 @@ const docTemplate = `{
                 }
             }
         },
+        "server.Quote": {
+            "type": "object",
+            "properties": {
+                "l1GasPrice": {
+                    "type": "integer"
+                },
+                "prover": {
+                    "type": "string"
+                },
+                "tierFees": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "utilization": {
+                    "type": "number"
+                }
+            }
+        },
         "server.Status": {
             "type": "object",
             "properties": {
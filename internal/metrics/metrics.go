@@ -30,6 +30,10 @@ var (
 	ProposerProposedTxListsCounter = factory.NewCounter(prometheus.CounterOpts{Name: "proposer_proposed_txLists"})
 	ProposerProposedTxsCounter     = factory.NewCounter(prometheus.CounterOpts{Name: "proposer_proposed_txs"})
 
+	// Protocol pause
+	ProposerProtocolPausedGauge  = factory.NewGauge(prometheus.GaugeOpts{Name: "proposer_protocol_paused"})
+	ProposerPausedSkippedCounter = factory.NewCounter(prometheus.CounterOpts{Name: "proposer_paused_skipped"})
+
 	// Prover
 	ProverLatestVerifiedIDGauge      = factory.NewGauge(prometheus.GaugeOpts{Name: "prover_latestVerified_id"})
 	ProverLatestProvenBlockIDGauge   = factory.NewGauge(prometheus.GaugeOpts{Name: "prover_latestProven_id"})
@@ -49,9 +53,160 @@ var (
 	ProverSgxProofGeneratedCounter = factory.NewCounter(prometheus.CounterOpts{
 		Name: "prover_proof_sgx_generated",
 	})
+	ProverSgxProofInvalidCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_proof_sgx_invalid",
+	})
+	ProverSgxInstanceExpiresAtGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_sgx_instance_expires_at",
+	}, []string{"chain"})
 	ProverSubmissionRevertedCounter = factory.NewCounter(prometheus.CounterOpts{
 		Name: "prover_proof_submission_reverted",
 	})
+	ProverAssignmentSignedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_assignment_signed",
+	})
+	ProverAssignmentUsedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_assignment_used",
+	})
+	ProverAssignmentExpiredUnusedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_assignment_expired_unused",
+	})
+	ProverAssignmentProvenCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_assignment_proven",
+	})
+	ProverAssignmentPendingGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_assignment_pending",
+	}, []string{"chain"})
+	ProverBondLockedGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_bond_locked",
+	}, []string{"chain"})
+	ProverBondPromisedGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_bond_promised",
+	}, []string{"chain"})
+	ProverBondExposureGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_bond_exposure",
+	}, []string{"chain"})
+	ProverScheduledJobsGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_scheduler_jobs",
+	}, []string{"chain"})
+	ProverProofGenerationTimeGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_proof_generation_time",
+	}, []string{"chain"})
+	ProverProofDroppedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_proof_dropped",
+	})
+	ProverProofDowngradedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_proof_downgraded",
+	})
+	ProverProofsHeldGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_proofs_held",
+	}, []string{"chain"})
+	ProverL1BaseFeeGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_l1_base_fee",
+	}, []string{"chain"})
+
+	// Opportunistic proving
+	ProverOpportunisticProofsGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_opportunistic_proofs",
+	}, []string{"chain"})
+	ProverOpportunisticSkippedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_opportunistic_skipped",
+	})
+
+	// Guardian approvals
+	ProverGuardianApprovalCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_guardian_approval",
+	})
+	ProverGuardianBelowQuorumGauge = factory.NewGauge(prometheus.GaugeOpts{Name: "prover_guardian_below_quorum"})
+	ProverGuardianStuckGauge       = factory.NewGauge(prometheus.GaugeOpts{Name: "prover_guardian_stuck"})
+	ProverGuardianOutboxGauge      = factory.NewGauge(prometheus.GaugeOpts{Name: "prover_guardian_outbox"})
+	ProverGuardianDeliveryLagGauge = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "prover_guardian_delivery_lag",
+	})
+	ProverGuardianDeliveryFailedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_guardian_delivery_failed",
+	})
+	ProverGuardianOutboxDroppedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_guardian_outbox_dropped",
+	})
+
+	// Prover pipeline
+	ProverPipelineQueueGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_pipeline_queue",
+	}, []string{"chain", "stage"})
+	ProverPnLGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_pnl",
+	}, []string{"chain", "item"})
+
+	// Prover chains
+	ProverChainLatestVerifiedIDGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_chain_latest_verified_id",
+	}, []string{"chain"})
+	ProverChainLatestProvenIDGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_chain_latest_proven_id",
+	}, []string{"chain"})
+	ProverChainReceivedProposedIDGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_chain_received_proposed_id",
+	}, []string{"chain"})
+	ProverChainReceivedProvenIDGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_chain_received_proven_id",
+	}, []string{"chain"})
+	ProverChainInflightProofsGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_chain_inflight_proofs",
+	}, []string{"chain"})
+	ProverChainProofsSubmittedCounterVec = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_chain_proofs_submitted",
+	}, []string{"chain"})
+	ProverChainAssignmentsCounterVec = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_chain_assignments",
+	}, []string{"chain"})
+
+	// Protocol pause
+	ProverProtocolPausedGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_protocol_paused",
+	}, []string{"chain"})
+	ProverProvingPausedGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_proving_paused",
+	}, []string{"chain"})
+	ProverPausedHeldProofsGaugeVec = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_paused_held_proofs",
+	}, []string{"chain"})
+	ProverPausedRejectedAssignmentsCounterVec = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_paused_rejected_assignments",
+	}, []string{"chain"})
+
+	// Prover proof cache
+	ProverProofCacheHitCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_proof_cache_hit",
+	})
+	ProverProofRequestMergedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_proof_request_merged",
+	})
+
+	// Watchtower
+	WatchtowerAlertCounterVec = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "watchtower_alert",
+	}, []string{"kind"})
+	WatchtowerInvalidTransitionsGauge = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "watchtower_invalid_transitions",
+	})
+
+	// Block verifier
+	VerifierVerifiableBlocksGauge = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "verifier_verifiable_blocks",
+	})
+	VerifierVerifiedBlocksCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "verifier_verified_blocks",
+	})
+	VerifierTxCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "verifier_tx",
+	}, []string{"status"})
+	VerifierGasUsedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "verifier_gas_used",
+	})
+	VerifierBlocksPerHourGauge = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "verifier_blocks_per_hour",
+	})
 
 	// TxManager
 	TxMgrMetrics = txmgrMetrics.MakeTxMetrics("client", factory)
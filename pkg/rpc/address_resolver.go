@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// Names of the protocol contracts registered in the AddressManager.
+const (
+	ContractNameTaiko                  = "taiko"
+	ContractNameTaikoToken             = "taiko_token"
+	ContractNameAssignmentHook         = "assignment_hook"
+	ContractNameGuardianProver         = "tier_guardian"
+	ContractNameGuardianProverMinority = "tier_guardian_minority"
+)
+
+// AddressResolver resolves the protocol contract addresses by their names from the AddressManager.
+type AddressResolver struct {
+	address        common.Address
+	addressManager *bindings.AddressManager
+}
+
+// NewAddressResolver creates a new AddressResolver of the given AddressManager, or of the AddressManager used
+// by the given TaikoL1 if the former is empty.
+func NewAddressResolver(
+	ctx context.Context,
+	l1 *EthClient,
+	addressManagerAddress common.Address,
+	taikoL1Address common.Address,
+) (*AddressResolver, error) {
+	if addressManagerAddress == ZeroAddress {
+		if taikoL1Address == ZeroAddress {
+			return nil, errors.New("empty AddressManager and TaikoL1 addresses")
+		}
+
+		taikoL1, err := bindings.NewTaikoL1Client(taikoL1Address, l1)
+		if err != nil {
+			return nil, err
+		}
+
+		ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultTimeout)
+		defer cancel()
+
+		if addressManagerAddress, err = taikoL1.AddressManager(&bind.CallOpts{Context: ctxWithTimeout}); err != nil {
+			return nil, err
+		}
+	}
+
+	addressManager, err := bindings.NewAddressManager(addressManagerAddress, l1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddressResolver{address: addressManagerAddress, addressManager: addressManager}, nil
+}
+
+// Address returns the address of the AddressManager.
+func (r *AddressResolver) Address() common.Address {
+	return r.address
+}
+
+// Resolve returns the address of the contract registered with the given name for the given chain, the zero
+// address is returned if no contract is registered.
+func (r *AddressResolver) Resolve(ctx context.Context, chainID uint64, name string) (common.Address, error) {
+	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultTimeout)
+	defer cancel()
+
+	return r.addressManager.GetAddress(&bind.CallOpts{Context: ctxWithTimeout}, chainID, StringToBytes32(name))
+}
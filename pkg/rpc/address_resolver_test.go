@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressResolver(t *testing.T) {
+	client := newTestClient(t)
+	taikoL1Address := common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS"))
+
+	_, err := NewAddressResolver(context.Background(), client.L1, ZeroAddress, ZeroAddress)
+	require.NotNil(t, err)
+
+	resolver, err := NewAddressResolver(context.Background(), client.L1, ZeroAddress, taikoL1Address)
+	require.Nil(t, err)
+	require.NotEqual(t, ZeroAddress, resolver.Address())
+
+	address, err := resolver.Resolve(context.Background(), client.L1.ChainID.Uint64(), ContractNameTaiko)
+	require.Nil(t, err)
+	require.Equal(t, taikoL1Address, address)
+
+	// The unregistered contracts are resolved to the zero address.
+	address, err = resolver.Resolve(context.Background(), client.L1.ChainID.Uint64(), "unregistered")
+	require.Nil(t, err)
+	require.Equal(t, ZeroAddress, address)
+}
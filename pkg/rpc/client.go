@@ -50,6 +50,10 @@ type ClientConfig struct {
 	L2EngineEndpoint              string
 	JwtSecret                     string
 	Timeout                       time.Duration
+	// Shared L1 clients, if set, the L1 endpoints will not be connected again, so that several
+	// L2 chains settling on the same L1 can share one L1 connection.
+	L1Client       *EthClient
+	L1BeaconClient *BeaconClient
 }
 
 // NewClient initializes all RPC clients used by Taiko client software.
@@ -67,7 +71,9 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 		ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultTimeout)
 		defer cancel()
 
-		if l1Client, err = NewEthClient(ctxWithTimeout, cfg.L1Endpoint, cfg.Timeout); err != nil {
+		if cfg.L1Client != nil {
+			l1Client, l1BeaconClient = cfg.L1Client, cfg.L1BeaconClient
+		} else if l1Client, err = NewEthClient(ctxWithTimeout, cfg.L1Endpoint, cfg.Timeout); err != nil {
 			log.Error("Failed to connect to L1 endpoint, retrying", "endpoint", cfg.L1Endpoint, "err", err)
 			return err
 		}
@@ -78,7 +84,7 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 		}
 
 		// NOTE: when running tests, we do not have a L1 beacon endpoint.
-		if cfg.L1BeaconEndpoint != "" && os.Getenv("RUN_TESTS") == "" {
+		if cfg.L1Client == nil && cfg.L1BeaconEndpoint != "" && os.Getenv("RUN_TESTS") == "" {
 			if l1BeaconClient, err = NewBeaconClient(cfg.L1BeaconEndpoint, defaultTimeout); err != nil {
 				log.Error("Failed to connect to L1 beacon endpoint, retrying", "endpoint", cfg.L1BeaconEndpoint, "err", err)
 				return err
@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+var (
+	// configResyncInterval is the interval to read the protocol parameters again, since the tier provider
+	// can be changed without any TaikoL1 event.
+	configResyncInterval = 5 * time.Minute
+)
+
+// ProtocolParams represents the protocol configurations and proof tiers, which can be changed by a protocol
+// upgrade or a tier provider change.
+type ProtocolParams struct {
+	Config *bindings.TaikoDataConfig
+	Tiers  []*TierProviderTierWithID
+}
+
+// equal checks whether the given protocol parameters are the same as these.
+func (p *ProtocolParams) equal(other *ProtocolParams) bool {
+	a, errA := json.Marshal(p)
+	b, errB := json.Marshal(other)
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// ConfigWatcher keeps track of the protocol parameters, it reads them again once TaikoL1 is upgraded, and
+// periodically in case the tier provider is changed.
+type ConfigWatcher struct {
+	rpc    *Client
+	params atomic.Pointer[ProtocolParams]
+	// onChange is called with the new parameters once they are changed, but not with the initial ones.
+	// The new parameters are only stored once onChange succeeds, so that they are notified again in the
+	// next resync if it fails.
+	onChange func(params *ProtocolParams) error
+	wg       sync.WaitGroup
+}
+
+// NewConfigWatcher creates a new ConfigWatcher instance.
+func NewConfigWatcher(rpc *Client, onChange func(params *ProtocolParams) error) *ConfigWatcher {
+	return &ConfigWatcher{rpc: rpc, onChange: onChange}
+}
+
+// Init reads the current protocol parameters.
+func (w *ConfigWatcher) Init(ctx context.Context) error {
+	params, err := w.read(ctx)
+	if err != nil {
+		return err
+	}
+	w.params.Store(params)
+
+	return nil
+}
+
+// Start starts the watching loop, which will be stopped when the given context is done.
+func (w *ConfigWatcher) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go w.loop(ctx)
+}
+
+// Wait waits until the watching loop exits.
+func (w *ConfigWatcher) Wait() {
+	w.wg.Wait()
+}
+
+// Params returns the current protocol parameters.
+func (w *ConfigWatcher) Params() *ProtocolParams {
+	return w.params.Load()
+}
+
+// loop is the main loop of the config watcher.
+func (w *ConfigWatcher) loop(ctx context.Context) {
+	defer w.wg.Done()
+
+	upgradedCh := make(chan *bindings.TaikoL1ClientUpgraded, 16)
+	sub := SubscribeUpgraded(w.rpc.TaikoL1, upgradedCh)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(configResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-upgradedCh:
+			log.Info("TaikoL1 upgraded", "implementation", e.Implementation, "removed", e.Raw.Removed)
+			w.resync(ctx)
+		case <-ticker.C:
+			w.resync(ctx)
+		}
+	}
+}
+
+// resync reads the protocol parameters again, and notifies the change.
+func (w *ConfigWatcher) resync(ctx context.Context) {
+	params, err := w.read(ctx)
+	if err != nil {
+		log.Warn("Failed to read protocol parameters", "error", err)
+		return
+	}
+	if err := w.update(params); err != nil {
+		log.Warn("Failed to apply the changed protocol parameters, will retry in the next resync", "error", err)
+	}
+}
+
+// read reads the protocol configurations from TaikoL1, and the proof tiers from the current tier provider.
+func (w *ConfigWatcher) read(ctx context.Context) (*ProtocolParams, error) {
+	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultTimeout)
+	defer cancel()
+
+	config, err := w.rpc.TaikoL1.GetConfig(&bind.CallOpts{Context: ctxWithTimeout})
+	if err != nil {
+		return nil, err
+	}
+	tiers, err := w.rpc.GetTiers(ctxWithTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProtocolParams{Config: &config, Tiers: tiers}, nil
+}
+
+// update notifies the change of the given protocol parameters, and swaps them in once the change is
+// applied successfully.
+func (w *ConfigWatcher) update(params *ProtocolParams) error {
+	if old := w.params.Load(); old != nil && old.equal(params) {
+		return nil
+	}
+
+	tierIDs := make([]uint16, 0, len(params.Tiers))
+	for _, tier := range params.Tiers {
+		tierIDs = append(tierIDs, tier.ID)
+	}
+	log.Info("Protocol parameters changed", "configs", params.Config, "tiers", tierIDs)
+
+	if w.onChange != nil {
+		if err := w.onChange(params); err != nil {
+			return err
+		}
+	}
+	w.params.Store(params)
+
+	return nil
+}
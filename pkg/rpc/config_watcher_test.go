@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func testProtocolParams(livenessBond int64, tierIDs ...uint16) *ProtocolParams {
+	params := &ProtocolParams{Config: &bindings.TaikoDataConfig{LivenessBond: big.NewInt(livenessBond)}}
+	for _, id := range tierIDs {
+		params.Tiers = append(params.Tiers, &TierProviderTierWithID{
+			ID:                id,
+			ITierProviderTier: bindings.ITierProviderTier{ValidityBond: big.NewInt(1), ContestBond: big.NewInt(2)},
+		})
+	}
+	return params
+}
+
+func TestConfigWatcherUpdate(t *testing.T) {
+	var (
+		changes []*ProtocolParams
+		failing bool
+	)
+	w := NewConfigWatcher(nil, func(params *ProtocolParams) error {
+		changes = append(changes, params)
+		if failing {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	w.params.Store(testProtocolParams(1, 100, 200))
+
+	// The same parameters read again are not notified.
+	require.Nil(t, w.update(testProtocolParams(1, 100, 200)))
+	require.Empty(t, changes)
+
+	require.Nil(t, w.update(testProtocolParams(1, 100, 200, 300)))
+	require.Len(t, changes, 1)
+	require.Len(t, w.Params().Tiers, 3)
+
+	require.Nil(t, w.update(testProtocolParams(2, 100, 200, 300)))
+	require.Len(t, changes, 2)
+	require.Equal(t, int64(2), w.Params().Config.LivenessBond.Int64())
+
+	// The parameters are not swapped in if the change failed to be applied, so that they are notified
+	// again in the next resync.
+	failing = true
+	require.NotNil(t, w.update(testProtocolParams(3, 100, 200, 300)))
+	require.Equal(t, int64(2), w.Params().Config.LivenessBond.Int64())
+	failing = false
+	require.Nil(t, w.update(testProtocolParams(3, 100, 200, 300)))
+	require.Len(t, changes, 4)
+	require.Equal(t, int64(3), w.Params().Config.LivenessBond.Int64())
+}
@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+var (
+	// pauseResyncInterval is the interval to read the pause status again, in case any event is missed.
+	pauseResyncInterval = 1 * time.Minute
+)
+
+// PauseStatus represents the pause status of the protocol.
+type PauseStatus struct {
+	// Whether TaikoL1 is paused, no block can be proposed, proven or verified.
+	Paused bool
+	// Whether the proving is paused, no block can be proven.
+	ProvingPaused bool
+}
+
+// CanPropose returns whether the blocks can be proposed.
+func (s PauseStatus) CanPropose() bool {
+	return !s.Paused
+}
+
+// CanProve returns whether the blocks can be proven and contested.
+func (s PauseStatus) CanProve() bool {
+	return !s.Paused && !s.ProvingPaused
+}
+
+// PauseWatcher keeps track of the pause status of the protocol, from TaikoL1's Paused, Unpaused and
+// ProvingPaused events, and reads it periodically in case any event is missed.
+type PauseWatcher struct {
+	rpc    *Client
+	status atomic.Pointer[PauseStatus]
+	// onChange is called with the new status once the status changes, and with the initial status.
+	onChange func(status PauseStatus)
+	wg       sync.WaitGroup
+}
+
+// NewPauseWatcher creates a new PauseWatcher instance.
+func NewPauseWatcher(rpc *Client, onChange func(status PauseStatus)) *PauseWatcher {
+	return &PauseWatcher{rpc: rpc, onChange: onChange}
+}
+
+// Init reads the current pause status of the protocol.
+func (w *PauseWatcher) Init(ctx context.Context) error {
+	status, err := w.read(ctx)
+	if err != nil {
+		return err
+	}
+	w.update(status)
+
+	return nil
+}
+
+// Start starts the watching loop, which will be stopped when the given context is done.
+func (w *PauseWatcher) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go w.loop(ctx)
+}
+
+// Wait waits until the watching loop exits.
+func (w *PauseWatcher) Wait() {
+	w.wg.Wait()
+}
+
+// Status returns the current pause status of the protocol.
+func (w *PauseWatcher) Status() PauseStatus {
+	if status := w.status.Load(); status != nil {
+		return *status
+	}
+	return PauseStatus{}
+}
+
+// CanPropose returns whether the blocks can be proposed now.
+func (w *PauseWatcher) CanPropose() bool {
+	return w.Status().CanPropose()
+}
+
+// CanProve returns whether the blocks can be proven and contested now.
+func (w *PauseWatcher) CanProve() bool {
+	return w.Status().CanProve()
+}
+
+// loop is the main loop of the pause watcher.
+func (w *PauseWatcher) loop(ctx context.Context) {
+	defer w.wg.Done()
+
+	pausedCh := make(chan *bindings.TaikoL1ClientPaused, 16)
+	unpausedCh := make(chan *bindings.TaikoL1ClientUnpaused, 16)
+	provingPausedCh := make(chan *bindings.TaikoL1ClientProvingPaused, 16)
+	pausedSub := SubscribePaused(w.rpc.TaikoL1, pausedCh)
+	unpausedSub := SubscribeUnpaused(w.rpc.TaikoL1, unpausedCh)
+	provingPausedSub := SubscribeProvingPaused(w.rpc.TaikoL1, provingPausedCh)
+	defer func() {
+		pausedSub.Unsubscribe()
+		unpausedSub.Unsubscribe()
+		provingPausedSub.Unsubscribe()
+	}()
+
+	ticker := time.NewTicker(pauseResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-pausedCh:
+			// The removed events are reorged out, read the status again instead.
+			if e.Raw.Removed {
+				w.resync(ctx)
+				continue
+			}
+			w.update(PauseStatus{Paused: true, ProvingPaused: w.Status().ProvingPaused})
+		case e := <-unpausedCh:
+			if e.Raw.Removed {
+				w.resync(ctx)
+				continue
+			}
+			w.update(PauseStatus{Paused: false, ProvingPaused: w.Status().ProvingPaused})
+		case e := <-provingPausedCh:
+			if e.Raw.Removed {
+				w.resync(ctx)
+				continue
+			}
+			w.update(PauseStatus{Paused: w.Status().Paused, ProvingPaused: e.Paused})
+		case <-ticker.C:
+			w.resync(ctx)
+		}
+	}
+}
+
+// resync reads the pause status of the protocol again.
+func (w *PauseWatcher) resync(ctx context.Context) {
+	status, err := w.read(ctx)
+	if err != nil {
+		log.Warn("Failed to read protocol pause status", "error", err)
+		return
+	}
+	w.update(status)
+}
+
+// read reads the pause status of the protocol from TaikoL1.
+func (w *PauseWatcher) read(ctx context.Context) (PauseStatus, error) {
+	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultTimeout)
+	defer cancel()
+
+	paused, err := w.rpc.TaikoL1.Paused(&bind.CallOpts{Context: ctxWithTimeout})
+	if err != nil {
+		return PauseStatus{}, err
+	}
+	stateVars, err := w.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: ctxWithTimeout})
+	if err != nil {
+		return PauseStatus{}, err
+	}
+
+	return PauseStatus{Paused: paused, ProvingPaused: stateVars.B.ProvingPaused}, nil
+}
+
+// update updates the pause status, and notifies the change.
+func (w *PauseWatcher) update(status PauseStatus) {
+	old := w.status.Swap(&status)
+	if old != nil && *old == status {
+		return
+	}
+
+	if old != nil || !status.CanProve() {
+		if status.CanProve() {
+			log.Info("Protocol resumed", "paused", status.Paused, "provingPaused", status.ProvingPaused)
+		} else {
+			log.Warn("Protocol paused", "paused", status.Paused, "provingPaused", status.ProvingPaused)
+		}
+	}
+
+	if w.onChange != nil {
+		w.onChange(status)
+	}
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseWatcherUpdate(t *testing.T) {
+	var changes []PauseStatus
+	w := NewPauseWatcher(nil, func(status PauseStatus) { changes = append(changes, status) })
+
+	// The initial status is always notified.
+	w.update(PauseStatus{})
+	require.True(t, w.CanPropose())
+	require.True(t, w.CanProve())
+	require.Len(t, changes, 1)
+
+	// Only the changes are notified.
+	w.update(PauseStatus{})
+	require.Len(t, changes, 1)
+
+	w.update(PauseStatus{ProvingPaused: true})
+	require.True(t, w.CanPropose())
+	require.False(t, w.CanProve())
+
+	w.update(PauseStatus{Paused: true, ProvingPaused: true})
+	require.False(t, w.CanPropose())
+	require.False(t, w.CanProve())
+
+	w.update(PauseStatus{})
+	require.True(t, w.CanProve())
+	require.Len(t, changes, 4)
+}
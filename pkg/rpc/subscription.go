@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
@@ -100,6 +101,133 @@ func SubscribeTransitionContested(
 	})
 }
 
+// SubscribePaused subscribes the protocol's Paused events.
+func SubscribePaused(
+	taikoL1 *bindings.TaikoL1Client,
+	ch chan *bindings.TaikoL1ClientPaused,
+) event.Subscription {
+	return SubscribeEvent("Paused", func(ctx context.Context) (event.Subscription, error) {
+		sub, err := taikoL1.WatchPaused(nil, ch)
+		if err != nil {
+			log.Error("Create TaikoL1.Paused subscription error", "error", err)
+			return nil, err
+		}
+
+		defer sub.Unsubscribe()
+
+		return waitSubErr(ctx, sub)
+	})
+}
+
+// SubscribeUnpaused subscribes the protocol's Unpaused events.
+func SubscribeUnpaused(
+	taikoL1 *bindings.TaikoL1Client,
+	ch chan *bindings.TaikoL1ClientUnpaused,
+) event.Subscription {
+	return SubscribeEvent("Unpaused", func(ctx context.Context) (event.Subscription, error) {
+		sub, err := taikoL1.WatchUnpaused(nil, ch)
+		if err != nil {
+			log.Error("Create TaikoL1.Unpaused subscription error", "error", err)
+			return nil, err
+		}
+
+		defer sub.Unsubscribe()
+
+		return waitSubErr(ctx, sub)
+	})
+}
+
+// SubscribeProvingPaused subscribes the protocol's ProvingPaused events.
+func SubscribeProvingPaused(
+	taikoL1 *bindings.TaikoL1Client,
+	ch chan *bindings.TaikoL1ClientProvingPaused,
+) event.Subscription {
+	return SubscribeEvent("ProvingPaused", func(ctx context.Context) (event.Subscription, error) {
+		sub, err := taikoL1.WatchProvingPaused(nil, ch)
+		if err != nil {
+			log.Error("Create TaikoL1.ProvingPaused subscription error", "error", err)
+			return nil, err
+		}
+
+		defer sub.Unsubscribe()
+
+		return waitSubErr(ctx, sub)
+	})
+}
+
+// SubscribeUpgraded subscribes the protocol's Upgraded events.
+func SubscribeUpgraded(
+	taikoL1 *bindings.TaikoL1Client,
+	ch chan *bindings.TaikoL1ClientUpgraded,
+) event.Subscription {
+	return SubscribeEvent("Upgraded", func(ctx context.Context) (event.Subscription, error) {
+		sub, err := taikoL1.WatchUpgraded(nil, ch, nil)
+		if err != nil {
+			log.Error("Create TaikoL1.Upgraded subscription error", "error", err)
+			return nil, err
+		}
+
+		defer sub.Unsubscribe()
+
+		return waitSubErr(ctx, sub)
+	})
+}
+
+// SubscribeBlockAssigned subscribes the assignment hook's BlockAssigned events of the given assigned provers.
+func SubscribeBlockAssigned(
+	assignmentHook *bindings.AssignmentHook,
+	ch chan *bindings.AssignmentHookBlockAssigned,
+	assignedProvers []common.Address,
+) event.Subscription {
+	return SubscribeEvent("BlockAssigned", func(ctx context.Context) (event.Subscription, error) {
+		sub, err := assignmentHook.WatchBlockAssigned(nil, ch, assignedProvers)
+		if err != nil {
+			log.Error("Create AssignmentHook.BlockAssigned subscription error", "error", err)
+			return nil, err
+		}
+
+		defer sub.Unsubscribe()
+
+		return waitSubErr(ctx, sub)
+	})
+}
+
+// SubscribeGuardianApproval subscribes the guardian prover's GuardianApproval events.
+func SubscribeGuardianApproval(
+	guardianProver *bindings.GuardianProver,
+	ch chan *bindings.GuardianProverGuardianApproval,
+) event.Subscription {
+	return SubscribeEvent("GuardianApproval", func(ctx context.Context) (event.Subscription, error) {
+		sub, err := guardianProver.WatchGuardianApproval(nil, ch, nil, nil, nil)
+		if err != nil {
+			log.Error("Create GuardianProver.GuardianApproval subscription error", "error", err)
+			return nil, err
+		}
+
+		defer sub.Unsubscribe()
+
+		return waitSubErr(ctx, sub)
+	})
+}
+
+// SubscribeGuardiansUpdated subscribes the guardian prover's GuardiansUpdated events.
+func SubscribeGuardiansUpdated(
+	guardianProver *bindings.GuardianProver,
+	ch chan *bindings.GuardianProverGuardiansUpdated,
+) event.Subscription {
+	return SubscribeEvent("GuardiansUpdated", func(ctx context.Context) (event.Subscription, error) {
+		sub, err := guardianProver.WatchGuardiansUpdated(nil, ch)
+		if err != nil {
+			log.Error("Create GuardianProver.GuardiansUpdated subscription error", "error", err)
+			return nil, err
+		}
+
+		defer sub.Unsubscribe()
+
+		return waitSubErr(ctx, sub)
+	})
+}
+
 // SubscribeChainHead subscribes the new chain heads.
 func SubscribeChainHead(
 	client *EthClient,
@@ -31,6 +31,34 @@ func TestSubscribeBlockProposed(t *testing.T) {
 	)
 }
 
+func TestSubscribePaused(t *testing.T) {
+	require.NotNil(t, SubscribePaused(
+		newTestClient(t).TaikoL1,
+		make(chan *bindings.TaikoL1ClientPaused, 1024)),
+	)
+}
+
+func TestSubscribeUnpaused(t *testing.T) {
+	require.NotNil(t, SubscribeUnpaused(
+		newTestClient(t).TaikoL1,
+		make(chan *bindings.TaikoL1ClientUnpaused, 1024)),
+	)
+}
+
+func TestSubscribeProvingPaused(t *testing.T) {
+	require.NotNil(t, SubscribeProvingPaused(
+		newTestClient(t).TaikoL1,
+		make(chan *bindings.TaikoL1ClientProvingPaused, 1024)),
+	)
+}
+
+func TestSubscribeUpgraded(t *testing.T) {
+	require.NotNil(t, SubscribeUpgraded(
+		newTestClient(t).TaikoL1,
+		make(chan *bindings.TaikoL1ClientUpgraded, 1024)),
+	)
+}
+
 func TestSubscribeTransitionProved(t *testing.T) {
 	require.NotNil(t, SubscribeTransitionProved(
 		newTestClient(t).TaikoL1,
@@ -37,6 +37,7 @@ type Config struct {
 	MaxProposedTxListsPerEpoch uint64
 	ProposeBlockTxGasLimit     uint64
 	ProverEndpoints            []*url.URL
+	ProverAPIKey               string
 	OptimisticTierFee          *big.Int
 	SgxTierFee                 *big.Int
 	TierFeePriceBump           *big.Int
@@ -120,6 +121,7 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		MaxProposedTxListsPerEpoch: c.Uint64(flags.MaxProposedTxListsPerEpoch.Name),
 		ProposeBlockTxGasLimit:     c.Uint64(flags.TxGasLimit.Name),
 		ProverEndpoints:            proverEndpoints,
+		ProverAPIKey:               c.String(flags.ProverAPIKey.Name),
 		OptimisticTierFee:          optimisticTierFee,
 		SgxTierFee:                 sgxTierFee,
 		TierFeePriceBump:           new(big.Int).SetUint64(c.Uint64(flags.TierFeePriceBump.Name)),
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -51,6 +50,13 @@ type Proposer struct {
 	// Prover selector
 	proverSelector selector.ProverSelector
 
+	// Pause status of the protocol, no block is proposed while TaikoL1 is paused
+	pauseWatcher *rpc.PauseWatcher
+
+	// Protocol parameters watcher, the main loop is notified through the channel once they are changed
+	configWatcher    *rpc.ConfigWatcher
+	protocolParamsCh chan *rpc.ProtocolParams
+
 	// Transaction builder
 	txBuilder builder.ProposeBlockTransactionBuilder
 
@@ -81,26 +87,36 @@ func (p *Proposer) InitFromConfig(ctx context.Context, cfg *Config) (err error)
 	p.ctx = ctx
 	p.Config = cfg
 	p.lastProposedAt = time.Now()
+	p.protocolParamsCh = make(chan *rpc.ProtocolParams, 1)
 
 	// RPC clients
 	if p.rpc, err = rpc.NewClient(p.ctx, cfg.ClientConfig); err != nil {
 		return fmt.Errorf("initialize rpc clients error: %w", err)
 	}
 
-	// Protocol configs
-	protocolConfigs, err := p.rpc.TaikoL1.GetConfig(&bind.CallOpts{Context: ctx})
-	if err != nil {
+	// Protocol configurations and proof tiers, which are swapped in once changed.
+	p.configWatcher = rpc.NewConfigWatcher(p.rpc, func(params *rpc.ProtocolParams) error {
+		// Only the latest parameters are applied, the watcher's parameters are not swapped in until this returns.
+		select {
+		case <-p.protocolParamsCh:
+		default:
+		}
+		p.protocolParamsCh <- params
+		return nil
+	})
+	if err := p.configWatcher.Init(ctx); err != nil {
 		return fmt.Errorf("failed to get protocol configs: %w", err)
 	}
-	p.protocolConfigs = &protocolConfigs
 
-	log.Info("Protocol configs", "configs", p.protocolConfigs)
-
-	if p.tiers, err = p.rpc.GetTiers(ctx); err != nil {
-		return err
-	}
-	if err := p.initTierFees(); err != nil {
-		return err
+	p.pauseWatcher = rpc.NewPauseWatcher(p.rpc, func(status rpc.PauseStatus) {
+		var paused float64
+		if !status.CanPropose() {
+			paused = 1
+		}
+		metrics.ProposerProtocolPausedGauge.Set(paused)
+	})
+	if err := p.pauseWatcher.Init(ctx); err != nil {
+		return fmt.Errorf("failed to read protocol pause status: %w", err)
 	}
 
 	if p.txmgr, err = txmgr.NewSimpleTxManager(
@@ -112,33 +128,49 @@ func (p *Proposer) InitFromConfig(ctx context.Context, cfg *Config) (err error)
 		return err
 	}
 
-	if p.proverSelector, err = selector.NewETHFeeEOASelector(
-		&protocolConfigs,
+	return p.applyProtocolParams(p.configWatcher.Params())
+}
+
+// applyProtocolParams swaps in the given protocol configurations and proof tiers, with the tier fees, the prover
+// selector and the transaction builder depending on them. It's only called before the proposer starts, or in
+// the main loop between two proposing operations, so that no proposal is built with half of them.
+func (p *Proposer) applyProtocolParams(params *rpc.ProtocolParams) error {
+	log.Info("Protocol configs", "configs", params.Config)
+
+	tierFees := p.newTierFees(params.Tiers)
+	proverSelector, err := selector.NewETHFeeEOASelector(
+		params.Config,
 		p.rpc,
-		p.proposerAddress,
-		cfg.TaikoL1Address,
-		cfg.AssignmentHookAddress,
-		p.tierFees,
-		cfg.TierFeePriceBump,
-		cfg.ProverEndpoints,
-		cfg.MaxTierFeePriceBumps,
+		p.L1ProposerPrivKey,
+		p.TaikoL1Address,
+		p.AssignmentHookAddress,
+		tierFees,
+		p.TierFeePriceBump,
+		p.ProverEndpoints,
+		p.ProverAPIKey,
+		p.MaxTierFeePriceBumps,
 		proverAssignmentTimeout,
 		requestProverServerTimeout,
-	); err != nil {
+	)
+	if err != nil {
 		return err
 	}
 
-	if cfg.BlobAllowed {
+	p.protocolConfigs = params.Config
+	p.tiers = params.Tiers
+	p.tierFees = tierFees
+	p.proverSelector = proverSelector
+	if p.BlobAllowed {
 		p.txBuilder = builder.NewBlobTransactionBuilder(
 			p.rpc,
 			p.L1ProposerPrivKey,
 			p.proverSelector,
 			p.Config.L1BlockBuilderTip,
-			cfg.TaikoL1Address,
-			cfg.L2SuggestedFeeRecipient,
-			cfg.AssignmentHookAddress,
-			cfg.ProposeBlockTxGasLimit,
-			cfg.ExtraData,
+			p.TaikoL1Address,
+			p.L2SuggestedFeeRecipient,
+			p.AssignmentHookAddress,
+			p.ProposeBlockTxGasLimit,
+			p.ExtraData,
 		)
 	} else {
 		p.txBuilder = builder.NewCalldataTransactionBuilder(
@@ -146,11 +178,11 @@ func (p *Proposer) InitFromConfig(ctx context.Context, cfg *Config) (err error)
 			p.L1ProposerPrivKey,
 			p.proverSelector,
 			p.Config.L1BlockBuilderTip,
-			cfg.L2SuggestedFeeRecipient,
-			cfg.TaikoL1Address,
-			cfg.AssignmentHookAddress,
-			cfg.ProposeBlockTxGasLimit,
-			cfg.ExtraData,
+			p.L2SuggestedFeeRecipient,
+			p.TaikoL1Address,
+			p.AssignmentHookAddress,
+			p.ProposeBlockTxGasLimit,
+			p.ExtraData,
 		)
 	}
 
@@ -159,6 +191,8 @@ func (p *Proposer) InitFromConfig(ctx context.Context, cfg *Config) (err error)
 
 // Start starts the proposer's main loop.
 func (p *Proposer) Start() error {
+	p.pauseWatcher.Start(p.ctx)
+	p.configWatcher.Start(p.ctx)
 	p.wg.Add(1)
 	go p.eventLoop()
 	return nil
@@ -186,12 +220,18 @@ func (p *Proposer) eventLoop() {
 				log.Error("Proposing operation error", "error", err)
 				continue
 			}
+		case params := <-p.protocolParamsCh:
+			if err := p.applyProtocolParams(params); err != nil {
+				log.Error("Failed to apply the changed protocol parameters", "error", err)
+			}
 		}
 	}
 }
 
 // Close closes the proposer instance.
 func (p *Proposer) Close(_ context.Context) {
+	p.pauseWatcher.Wait()
+	p.configWatcher.Wait()
 	p.wg.Wait()
 }
 
@@ -273,6 +313,13 @@ func (p *Proposer) fetchPoolContent(filterPoolContent bool) ([]types.Transaction
 // from L2 execution engine's tx pool, splitting them by proposing constraints,
 // and then proposing them to TaikoL1 contract.
 func (p *Proposer) ProposeOp(ctx context.Context) error {
+	// Skip proposing while TaikoL1 is paused, since the transactions would revert.
+	if !p.pauseWatcher.CanPropose() {
+		log.Info("Protocol paused, skip proposing")
+		metrics.ProposerPausedSkippedCounter.Add(1)
+		return nil
+	}
+
 	// Check if it's time to propose unfiltered pool content.
 	filterPoolContent := time.Now().Before(p.lastProposedAt.Add(p.MinProposingInternal))
 
@@ -394,9 +441,11 @@ func (p *Proposer) Name() string {
 	return "proposer"
 }
 
-// initTierFees initializes the proving fees for every proof tier configured in the protocol for the proposer.
-func (p *Proposer) initTierFees() error {
-	for _, tier := range p.tiers {
+// newTierFees returns the proving fees for every proof tier configured in the protocol for the proposer,
+// the unknown tiers are skipped.
+func (p *Proposer) newTierFees(tiers []*rpc.TierProviderTierWithID) []encoding.TierFee {
+	var tierFees []encoding.TierFee
+	for _, tier := range tiers {
 		log.Info(
 			"Protocol tier",
 			"id", tier.ID,
@@ -409,18 +458,18 @@ func (p *Proposer) initTierFees() error {
 
 		switch tier.ID {
 		case encoding.TierOptimisticID:
-			p.tierFees = append(p.tierFees, encoding.TierFee{Tier: tier.ID, Fee: p.OptimisticTierFee})
+			tierFees = append(tierFees, encoding.TierFee{Tier: tier.ID, Fee: p.OptimisticTierFee})
 		case encoding.TierSgxID:
-			p.tierFees = append(p.tierFees, encoding.TierFee{Tier: tier.ID, Fee: p.SgxTierFee})
+			tierFees = append(tierFees, encoding.TierFee{Tier: tier.ID, Fee: p.SgxTierFee})
 		case encoding.TierGuardianMinorityID:
-			p.tierFees = append(p.tierFees, encoding.TierFee{Tier: tier.ID, Fee: common.Big0})
+			tierFees = append(tierFees, encoding.TierFee{Tier: tier.ID, Fee: common.Big0})
 		case encoding.TierGuardianMajorityID:
 			// Guardian prover should not charge any fee.
-			p.tierFees = append(p.tierFees, encoding.TierFee{Tier: tier.ID, Fee: common.Big0})
+			tierFees = append(tierFees, encoding.TierFee{Tier: tier.ID, Fee: common.Big0})
 		default:
-			return fmt.Errorf("unknown tier: %d", tier.ID)
+			log.Warn("Unknown protocol tier, no fee is offered for it", "id", tier.ID)
 		}
 	}
 
-	return nil
+	return tierFees
 }
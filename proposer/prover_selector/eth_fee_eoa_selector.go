@@ -2,6 +2,7 @@ package selector
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -32,12 +33,13 @@ var (
 type ETHFeeEOASelector struct {
 	protocolConfigs               *bindings.TaikoDataConfig
 	rpc                           *rpc.Client
-	proposerAddress               common.Address
+	proposerPrivKey               *ecdsa.PrivateKey
 	taikoL1Address                common.Address
 	assignmentHookAddress         common.Address
 	tiersFee                      []encoding.TierFee
 	tierFeePriceBump              *big.Int
 	proverEndpoints               []*url.URL
+	proverAPIKey                  string
 	maxTierFeePriceBumpIterations uint64
 	proposalExpiry                time.Duration
 	requestTimeout                time.Duration
@@ -47,12 +49,13 @@ type ETHFeeEOASelector struct {
 func NewETHFeeEOASelector(
 	protocolConfigs *bindings.TaikoDataConfig,
 	rpc *rpc.Client,
-	proposerAddress common.Address,
+	proposerPrivKey *ecdsa.PrivateKey,
 	taikoL1Address common.Address,
 	assignmentHookAddress common.Address,
 	tiersFee []encoding.TierFee,
 	tierFeePriceBump *big.Int,
 	proverEndpoints []*url.URL,
+	proverAPIKey string,
 	maxTierFeePriceBumpIterations uint64,
 	proposalExpiry time.Duration,
 	requestTimeout time.Duration,
@@ -70,12 +73,13 @@ func NewETHFeeEOASelector(
 	return &ETHFeeEOASelector{
 		protocolConfigs,
 		rpc,
-		proposerAddress,
+		proposerPrivKey,
 		taikoL1Address,
 		assignmentHookAddress,
 		tiersFee,
 		tierFeePriceBump,
 		proverEndpoints,
+		proverAPIKey,
 		maxTierFeePriceBumpIterations,
 		proposalExpiry,
 		requestTimeout,
@@ -126,7 +130,8 @@ func (s *ETHFeeEOASelector) AssignProver(
 				s.protocolConfigs.ChainId,
 				endpoint,
 				expiry,
-				s.proposerAddress,
+				s.proposerPrivKey,
+				s.proverAPIKey,
 				fees,
 				s.taikoL1Address,
 				s.assignmentHookAddress,
@@ -178,7 +183,8 @@ func assignProver(
 	chainID uint64,
 	endpoint *url.URL,
 	expiry uint64,
-	proposerAddress common.Address,
+	proposerPrivKey *ecdsa.PrivateKey,
+	proverAPIKey string,
 	tierFees []encoding.TierFee,
 	taikoL1Address common.Address,
 	assignmentHookAddress common.Address,
@@ -193,30 +199,50 @@ func assignProver(
 		"tierFees", tierFees,
 	)
 
+	ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Send the HTTP request
 	var (
-		client  = resty.New()
-		reqBody = &server.CreateAssignmentRequestBody{
+		client          = resty.New()
+		proposerAddress = crypto.PubkeyToAddress(proposerPrivKey.PublicKey)
+		reqBody         = &server.CreateAssignmentRequestBody{
 			Proposer: proposerAddress,
 			FeeToken: rpc.ZeroAddress,
 			TierFees: tierFees,
 			Expiry:   expiry,
 			BlobHash: txListHash,
+			Nonce:    rand.Uint64(),
 		}
 		result = server.ProposeBlockResponse{}
 	)
+	// Sign the request for the prover, so that the prover can authenticate the proposer, and the
+	// request can't be replayed to other provers.
+	proverAddress, err := getProverAddress(ctxTimeout, client, endpoint, proverAPIKey)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if err := reqBody.Sign(proposerPrivKey, &server.AssignmentDomain{
+		ChainID: chainID,
+		TaikoL1: taikoL1Address,
+		Prover:  proverAddress,
+	}); err != nil {
+		return nil, common.Address{}, err
+	}
 	requestURL, err := url.JoinPath(endpoint.String(), "/assignment")
 	if err != nil {
 		return nil, common.Address{}, err
 	}
 
-	ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	resp, err := client.R().
+	req := client.R().
 		SetContext(ctxTimeout).
 		SetHeader("Content-Type", "application/json").
-		SetHeader("Accept", "application/json").
+		SetHeader("Accept", "application/json")
+	if proverAPIKey != "" {
+		req.SetHeader(server.APIKeyHeader, proverAPIKey)
+	}
+
+	resp, err := req.
 		SetBody(reqBody).
 		SetResult(&result).
 		Post(requestURL)
@@ -281,3 +307,36 @@ func assignProver(
 		Signature:     result.SignedPayload,
 	}, result.Prover, nil
 }
+
+// getProverAddress fetches the address of the prover behind the given prover server endpoint.
+func getProverAddress(
+	ctx context.Context,
+	client *resty.Client,
+	endpoint *url.URL,
+	proverAPIKey string,
+) (common.Address, error) {
+	requestURL, err := url.JoinPath(endpoint.String(), "/status")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var (
+		status = server.Status{}
+		req    = client.R().SetContext(ctx).SetHeader("Accept", "application/json")
+	)
+	if proverAPIKey != "" {
+		req.SetHeader(server.APIKeyHeader, proverAPIKey)
+	}
+	resp, err := req.SetResult(&status).Get(requestURL)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !resp.IsSuccess() {
+		return common.Address{}, fmt.Errorf("unsuccessful status response %d", resp.StatusCode())
+	}
+	if !common.IsHexAddress(status.Prover) {
+		return common.Address{}, fmt.Errorf("invalid prover address %q", status.Prover)
+	}
+
+	return common.HexToAddress(status.Prover), nil
+}
@@ -34,12 +34,13 @@ func (s *TransactionBuilderTestSuite) SetupTest() {
 	proverSelector, err := selector.NewETHFeeEOASelector(
 		&protocolConfigs,
 		s.RPCClient,
-		crypto.PubkeyToAddress(l1ProposerPrivKey.PublicKey),
+		l1ProposerPrivKey,
 		common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
 		common.HexToAddress(os.Getenv("ASSIGNMENT_HOOK_ADDRESS")),
 		[]encoding.TierFee{},
 		common.Big2,
 		[]*url.URL{s.ProverEndpoints[0]},
+		"",
 		32,
 		1*time.Minute,
 		1*time.Minute,
@@ -0,0 +1,437 @@
+package accounting
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+// BondStatus represents the outcome of a bond.
+type BondStatus string
+
+// Bond statuses.
+const (
+	// BondLocked means the bond is still locked in the protocol.
+	BondLocked BondStatus = "locked"
+	// BondReturned means the bond has been returned.
+	BondReturned BondStatus = "returned"
+	// BondForfeited means the liveness bond has been forfeited, since the block was not proven by
+	// the assigned prover in its proving window.
+	BondForfeited BondStatus = "forfeited"
+	// BondWon means the contest has been won.
+	BondWon BondStatus = "won"
+	// BondLost means the transition or the contest has been overturned.
+	BondLost BondStatus = "lost"
+)
+
+const (
+	// openFileName is the name of the file keeping the records of the unverified blocks.
+	openFileName = "open.json"
+	// settledFileName is the name of the file which the records of the verified blocks are appended to.
+	settledFileName = "settled.jsonl"
+)
+
+// Record is the accounting record of a block which the prover identities were assigned, proved or contested.
+type Record struct {
+	BlockID            uint64         `json:"blockID"`
+	AssignedProver     common.Address `json:"assignedProver"`
+	ProposedAt         uint64         `json:"proposedAt"`
+	MinTier            uint16         `json:"minTier"`
+	FeeToken           common.Address `json:"feeToken"`
+	Fee                *big.Int       `json:"fee"`
+	LivenessBond       *big.Int       `json:"livenessBond"`
+	LivenessBondStatus BondStatus     `json:"livenessBondStatus"`
+	ValidityBond       *big.Int       `json:"validityBond"`
+	ValidityBondStatus BondStatus     `json:"validityBondStatus"`
+	ProvedBlockHash    common.Hash    `json:"provedBlockHash"`
+	ContestBond        *big.Int       `json:"contestBond"`
+	ContestBondStatus  BondStatus     `json:"contestBondStatus"`
+	ClaimedBlockHash   common.Hash    `json:"claimedBlockHash"`
+	GasCost            *big.Int       `json:"gasCost"`
+	VerifiedAt         uint64         `json:"verifiedAt"`
+}
+
+// newRecord creates a new empty record of the given block.
+func newRecord(blockID uint64) *Record {
+	return &Record{
+		BlockID:      blockID,
+		Fee:          new(big.Int),
+		LivenessBond: new(big.Int),
+		ValidityBond: new(big.Int),
+		ContestBond:  new(big.Int),
+		GasCost:      new(big.Int),
+	}
+}
+
+// Book keeps the accounting records, the records of the unverified blocks are kept in memory, and settled
+// once the blocks are verified.
+type Book struct {
+	dir     string
+	period  time.Duration
+	chain   string
+	open    map[uint64]*Record
+	current *Period
+	mutex   sync.Mutex
+}
+
+// NewBook creates a new Book instance, the records are persisted in the given directory, empty means
+// no persistence. The settled records are summarized in periods of the given duration, and the P&L metrics
+// are labelled with the given L2 chain ID.
+func NewBook(dir string, period time.Duration, chain string) (*Book, error) {
+	b := &Book{dir: dir, period: period, chain: chain, open: make(map[uint64]*Record)}
+	if dir == "" {
+		return b, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, openFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		var records []*Record
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("failed to load open accounting records: %w", err)
+		}
+		for _, r := range records {
+			b.open[r.BlockID] = r
+		}
+	}
+
+	// Restore the P&L of the current period from the settled records.
+	settled, err := ReadSettled(dir)
+	if err != nil {
+		return nil, err
+	}
+	if periods := Summarize(settled, period); len(periods) != 0 {
+		if latest := periods[len(periods)-1]; latest.Start.Equal(b.periodStart(time.Now())) {
+			b.current = latest
+		}
+	}
+	b.updateMetrics()
+
+	return b, nil
+}
+
+// Assigned records the fee of a block assigned to a prover identity.
+func (b *Book) Assigned(blockID uint64, feeToken common.Address, fee *big.Int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	r := b.record(blockID)
+	r.FeeToken = feeToken
+	r.Fee = new(big.Int).Set(fee)
+	b.persist()
+}
+
+// Proposed records the liveness bond of a block assigned to a prover identity.
+func (b *Book) Proposed(
+	blockID uint64,
+	assignedProver common.Address,
+	livenessBond *big.Int,
+	proposedAt uint64,
+	minTier uint16,
+) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	r := b.record(blockID)
+	r.AssignedProver = assignedProver
+	r.LivenessBond = new(big.Int).Set(livenessBond)
+	r.LivenessBondStatus = BondLocked
+	r.ProposedAt = proposedAt
+	r.MinTier = minTier
+	b.persist()
+}
+
+// Proved settles the bonds overturned or confirmed by a new transition proof of the given block, inTime is
+// whether the proof was submitted in the proving window. If the proof is submitted by a prover identity,
+// its validity bond is recorded.
+func (b *Book) Proved(
+	blockID uint64,
+	prover common.Address,
+	ours bool,
+	blockHash common.Hash,
+	validityBond *big.Int,
+	inTime bool,
+) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	r, ok := b.open[blockID]
+	if !ok && !ours {
+		return
+	}
+	if !ok {
+		r = b.record(blockID)
+	}
+
+	// The liveness bond is settled by the first proof of the block.
+	if r.LivenessBondStatus == BondLocked {
+		if prover == r.AssignedProver && inTime {
+			r.LivenessBondStatus = BondReturned
+		} else {
+			r.LivenessBondStatus = BondForfeited
+		}
+	}
+	// A new proof is always of a higher tier, which overturns or confirms the previous transition.
+	if r.ValidityBondStatus == BondLocked {
+		if blockHash == r.ProvedBlockHash {
+			r.ValidityBondStatus = BondReturned
+		} else {
+			r.ValidityBondStatus = BondLost
+		}
+	}
+	if r.ContestBondStatus == BondLocked {
+		if blockHash == r.ClaimedBlockHash {
+			r.ContestBondStatus = BondWon
+		} else {
+			r.ContestBondStatus = BondLost
+		}
+	}
+
+	if ours {
+		r.ValidityBond = new(big.Int).Set(validityBond)
+		r.ValidityBondStatus = BondLocked
+		r.ProvedBlockHash = blockHash
+	}
+	b.persist()
+}
+
+// Contested records the contest bond of a transition contested by a prover identity, blockHash is
+// the block hash which the prover identity claims.
+func (b *Book) Contested(blockID uint64, blockHash common.Hash, contestBond *big.Int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	r := b.record(blockID)
+	r.ContestBond = new(big.Int).Set(contestBond)
+	r.ContestBondStatus = BondLocked
+	r.ClaimedBlockHash = blockHash
+	b.persist()
+}
+
+// Charged records the L1 gas cost of a transaction sent by a prover identity for the given block.
+func (b *Book) Charged(blockID uint64, cost *big.Int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	r := b.record(blockID)
+	r.GasCost = new(big.Int).Add(r.GasCost, cost)
+	b.persist()
+}
+
+// Verified settles the remaining bonds of the given verified block, and moves its record to the settled
+// records, returns nil if there is no record of the block.
+func (b *Book) Verified(blockID uint64, prover common.Address, blockHash common.Hash, verifiedAt uint64) *Record {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	r, ok := b.open[blockID]
+	if !ok {
+		return nil
+	}
+	delete(b.open, blockID)
+
+	// Fall back to the verified transition, in case the first proof was missed.
+	if r.LivenessBondStatus == BondLocked {
+		if prover == r.AssignedProver {
+			r.LivenessBondStatus = BondReturned
+		} else {
+			r.LivenessBondStatus = BondForfeited
+		}
+	}
+	if r.ValidityBondStatus == BondLocked {
+		if blockHash == r.ProvedBlockHash {
+			r.ValidityBondStatus = BondReturned
+		} else {
+			r.ValidityBondStatus = BondLost
+		}
+	}
+	if r.ContestBondStatus == BondLocked {
+		if blockHash == r.ClaimedBlockHash {
+			r.ContestBondStatus = BondWon
+		} else {
+			r.ContestBondStatus = BondLost
+		}
+	}
+	r.VerifiedAt = verifiedAt
+
+	start := b.periodStart(time.Unix(int64(verifiedAt), 0))
+	if b.current == nil || start.After(b.current.Start) {
+		b.current = newPeriod(start)
+	}
+	if start.Equal(b.current.Start) {
+		b.current.add(r)
+	}
+	b.updateMetrics()
+
+	b.settle(r)
+	b.persist()
+
+	return r
+}
+
+// Has checks whether there is an open record of the given block.
+func (b *Book) Has(blockID uint64) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	_, ok := b.open[blockID]
+	return ok
+}
+
+// LivenessBondLocked returns the proposal time and the minimum tier of the given block, if its liveness bond
+// is still locked.
+func (b *Book) LivenessBondLocked(blockID uint64) (uint64, uint16, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	r, ok := b.open[blockID]
+	if !ok || r.LivenessBondStatus != BondLocked {
+		return 0, 0, false
+	}
+	return r.ProposedAt, r.MinTier, true
+}
+
+// Open returns the records of the unverified blocks.
+func (b *Book) Open() []*Record {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	records := make([]*Record, 0, len(b.open))
+	for _, r := range b.open {
+		records = append(records, r)
+	}
+	return records
+}
+
+// record returns the open record of the given block, creates a new one if not found, the caller must
+// hold the mutex.
+func (b *Book) record(blockID uint64) *Record {
+	r, ok := b.open[blockID]
+	if !ok {
+		r = newRecord(blockID)
+		b.open[blockID] = r
+	}
+	return r
+}
+
+// periodStart returns the start time of the period containing the given time.
+func (b *Book) periodStart(t time.Time) time.Time {
+	return t.Truncate(b.period).UTC()
+}
+
+// updateMetrics updates the P&L metrics of the current period, the caller must hold the mutex.
+func (b *Book) updateMetrics() {
+	current := b.current
+	if current == nil {
+		current = newPeriod(b.periodStart(time.Now()))
+	}
+
+	for item, value := range map[string]*big.Int{
+		"fee":                     current.Fees,
+		"token_fee":               current.TokenFees,
+		"liveness_bond_forfeited": current.LivenessBondsForfeited,
+		"validity_bond_lost":      current.ValidityBondsLost,
+		"contest_bond_lost":       current.ContestBondsLost,
+		"gas_cost":                current.GasCost,
+	} {
+		ether, _ := utils.WeiToEther(value).Float64()
+		metrics.ProverPnLGaugeVec.WithLabelValues(b.chain, item).Set(ether)
+	}
+}
+
+// settle appends the given record to the settled records file, the caller must hold the mutex.
+func (b *Book) settle(r *Record) {
+	if b.dir == "" {
+		return
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		log.Error("Failed to marshal settled accounting record", "blockID", r.BlockID, "error", err)
+		return
+	}
+
+	f, err := os.OpenFile(filepath.Join(b.dir, settledFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		log.Error("Failed to open settled accounting records", "error", err)
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		log.Error("Failed to write settled accounting record", "blockID", r.BlockID, "error", err)
+	}
+}
+
+// persist writes the open records to the open records file, the caller must hold the mutex.
+func (b *Book) persist() {
+	if b.dir == "" {
+		return
+	}
+
+	records := make([]*Record, 0, len(b.open))
+	for _, r := range b.open {
+		records = append(records, r)
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		log.Error("Failed to marshal open accounting records", "error", err)
+		return
+	}
+
+	// Write to a temporary file at first, so the open records file will never be partially written.
+	file := filepath.Join(b.dir, openFileName)
+	if err := os.WriteFile(file+".tmp", data, 0600); err != nil {
+		log.Error("Failed to write open accounting records", "error", err)
+		return
+	}
+	if err := os.Rename(file+".tmp", file); err != nil {
+		log.Error("Failed to write open accounting records", "error", err)
+	}
+}
+
+// ReadSettled reads all the settled records persisted in the given directory.
+func ReadSettled(dir string) ([]*Record, error) {
+	f, err := os.Open(filepath.Join(dir, settledFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		r := new(Record)
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			return nil, fmt.Errorf("failed to read settled accounting record: %w", err)
+		}
+		records = append(records, r)
+	}
+
+	return records, scanner.Err()
+}
@@ -0,0 +1,185 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	testProver    = common.HexToAddress("0x01")
+	testOther     = common.HexToAddress("0x02")
+	testFeeToken  = common.HexToAddress("0x03")
+	testBlockHash = common.HexToHash("0x11")
+	testWrongHash = common.HexToHash("0x22")
+)
+
+type BookTestSuite struct {
+	suite.Suite
+	dir  string
+	book *Book
+	now  uint64
+}
+
+func (s *BookTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+
+	var err error
+	s.book, err = NewBook(s.dir, 24*time.Hour, "")
+	s.Nil(err)
+	s.now = uint64(time.Now().Unix())
+}
+
+func (s *BookTestSuite) TestAssignedAndProvedInTime() {
+	s.book.Assigned(1, common.Address{}, big.NewInt(100))
+	s.book.Proposed(1, testProver, big.NewInt(250), s.now, 100)
+	s.book.Proved(1, testProver, true, testBlockHash, big.NewInt(500), true)
+	s.book.Charged(1, big.NewInt(7))
+	s.book.Charged(1, big.NewInt(3))
+
+	r := s.book.Verified(1, testProver, testBlockHash, s.now)
+	s.NotNil(r)
+	s.Equal(BondReturned, r.LivenessBondStatus)
+	s.Equal(BondReturned, r.ValidityBondStatus)
+	s.Equal(int64(10), r.GasCost.Int64())
+	s.False(s.book.Has(1))
+	s.Nil(s.book.Verified(1, testProver, testBlockHash, s.now))
+}
+
+func (s *BookTestSuite) TestLivenessBondForfeited() {
+	s.book.Proposed(1, testProver, big.NewInt(250), s.now, 100)
+	_, _, ok := s.book.LivenessBondLocked(1)
+	s.True(ok)
+
+	// Proved by the assigned prover, but out of the proving window.
+	s.book.Proved(1, testProver, true, testBlockHash, big.NewInt(500), false)
+	_, _, ok = s.book.LivenessBondLocked(1)
+	s.False(ok)
+
+	r := s.book.Verified(1, testProver, testBlockHash, s.now)
+	s.Equal(BondForfeited, r.LivenessBondStatus)
+	s.Equal(BondReturned, r.ValidityBondStatus)
+
+	// Proved by another prover.
+	s.book.Proposed(2, testProver, big.NewInt(250), s.now, 100)
+	s.book.Proved(2, testOther, false, testBlockHash, big.NewInt(500), true)
+	r = s.book.Verified(2, testOther, testBlockHash, s.now)
+	s.Equal(BondForfeited, r.LivenessBondStatus)
+	s.Equal(BondStatus(""), r.ValidityBondStatus)
+}
+
+func (s *BookTestSuite) TestValidityBondLost() {
+	s.book.Proved(1, testProver, true, testWrongHash, big.NewInt(500), true)
+
+	// A higher tier proof overturns the transition.
+	s.book.Proved(1, testOther, false, testBlockHash, big.NewInt(1000), true)
+	r := s.book.Verified(1, testOther, testBlockHash, s.now)
+	s.Equal(BondLost, r.ValidityBondStatus)
+	s.Equal(int64(500), r.ValidityBond.Int64())
+}
+
+func (s *BookTestSuite) TestContest() {
+	// The contest is won by a higher tier proof of the claimed block hash.
+	s.book.Contested(1, testBlockHash, big.NewInt(300))
+	s.book.Proved(1, testOther, false, testBlockHash, big.NewInt(1000), true)
+	s.Equal(BondWon, s.book.Verified(1, testOther, testBlockHash, s.now).ContestBondStatus)
+
+	// The contest is lost once the contested transition is verified.
+	s.book.Contested(2, testBlockHash, big.NewInt(300))
+	s.Equal(BondLost, s.book.Verified(2, testOther, testWrongHash, s.now).ContestBondStatus)
+
+	// The blocks not assigned, proved or contested by the prover identities are not recorded.
+	s.book.Proved(3, testOther, false, testBlockHash, big.NewInt(1000), true)
+	s.False(s.book.Has(3))
+}
+
+func (s *BookTestSuite) TestPersistence() {
+	s.book.Proposed(1, testProver, big.NewInt(250), s.now, 100)
+	s.book.Assigned(2, testFeeToken, big.NewInt(100))
+	s.book.Proved(2, testProver, true, testBlockHash, big.NewInt(500), true)
+	s.book.Verified(2, testProver, testBlockHash, s.now)
+
+	book, err := NewBook(s.dir, 24*time.Hour, "")
+	s.Nil(err)
+	s.Len(book.Open(), 1)
+	s.True(book.Has(1))
+
+	settled, err := ReadSettled(s.dir)
+	s.Nil(err)
+	s.Len(settled, 1)
+	s.Equal(uint64(2), settled[0].BlockID)
+	s.Equal(testFeeToken, settled[0].FeeToken)
+
+	// The P&L of the current period is restored.
+	s.NotNil(book.current)
+	s.Equal(uint64(1), book.current.Blocks)
+	s.Equal(int64(100), book.current.TokenFees.Int64())
+}
+
+func (s *BookTestSuite) TestSummarize() {
+	day := uint64((24 * time.Hour).Seconds())
+	records := []*Record{
+		{VerifiedAt: day + 1, Fee: big.NewInt(1), LivenessBond: big.NewInt(2), LivenessBondStatus: BondForfeited},
+		{VerifiedAt: 1, Fee: big.NewInt(3), FeeToken: testFeeToken, GasCost: big.NewInt(4)},
+		{VerifiedAt: 2, ValidityBond: big.NewInt(5), ValidityBondStatus: BondLost},
+		{VerifiedAt: 3, ContestBond: big.NewInt(6), ContestBondStatus: BondWon},
+	}
+	for _, r := range records {
+		for _, v := range []**big.Int{&r.Fee, &r.LivenessBond, &r.ValidityBond, &r.ContestBond, &r.GasCost} {
+			if *v == nil {
+				*v = new(big.Int)
+			}
+		}
+	}
+
+	periods := Summarize(records, 24*time.Hour)
+	s.Len(periods, 2)
+	s.Equal(int64(0), periods[0].Start.Unix())
+	s.Equal(uint64(3), periods[0].Blocks)
+	s.Equal(int64(3), periods[0].TokenFees.Int64())
+	s.Equal(int64(4), periods[0].GasCost.Int64())
+	s.Equal(int64(5), periods[0].ValidityBondsLost.Int64())
+	s.Zero(periods[0].ContestBondsLost.Int64())
+	s.Equal(uint64(1), periods[1].Blocks)
+	s.Equal(int64(1), periods[1].Fees.Int64())
+	s.Equal(int64(2), periods[1].LivenessBondsForfeited.Int64())
+}
+
+func (s *BookTestSuite) TestExport() {
+	s.book.Assigned(1, common.Address{}, big.NewInt(100))
+	s.book.Proposed(1, testProver, big.NewInt(250), s.now, 100)
+	s.book.Verified(1, testProver, testBlockHash, s.now)
+
+	var out bytes.Buffer
+	exporter := &Exporter{dir: s.dir, format: FormatCSV, writer: &out}
+	_, err := exporter.Run(nil)
+	s.Nil(err)
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	s.Nil(err)
+	s.Len(rows, 2)
+	s.Equal(recordsHeader, rows[0])
+	s.Equal("1", rows[1][0])
+	s.Equal("100", rows[1][5])
+	s.Equal(string(BondReturned), rows[1][7])
+
+	out.Reset()
+	exporter = &Exporter{dir: s.dir, format: FormatJSON, period: 24 * time.Hour, summary: true, writer: &out}
+	_, err = exporter.Run(nil)
+	s.Nil(err)
+
+	var periods []*Period
+	s.Nil(json.Unmarshal(out.Bytes(), &periods))
+	s.Len(periods, 1)
+	s.Equal(int64(100), periods[0].Fees.Int64())
+}
+
+func TestBookTestSuite(t *testing.T) {
+	suite.Run(t, new(BookTestSuite))
+}
@@ -0,0 +1,156 @@
+package accounting
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+)
+
+// Export formats.
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Exporter exports the accounting records persisted by a prover, or their per-period P&L.
+type Exporter struct {
+	dir     string
+	format  string
+	period  time.Duration
+	summary bool
+	output  string
+	writer  io.Writer
+}
+
+// InitFromCli initializes the given exporter based on the command line flags.
+func (e *Exporter) InitFromCli(_ context.Context, c *cli.Context) error {
+	e.dir = c.String(flags.AccountingDir.Name)
+	e.format = c.String(flags.AccountingFormat.Name)
+	e.period = c.Duration(flags.AccountingPeriod.Name)
+	e.summary = c.Bool(flags.AccountingSummary.Name)
+	e.output = c.String(flags.AccountingOutput.Name)
+	e.writer = c.App.Writer
+
+	if e.dir == "" {
+		return fmt.Errorf("empty accounting directory")
+	}
+	if e.format != FormatJSON && e.format != FormatCSV {
+		return fmt.Errorf("invalid accounting export format: %s", e.format)
+	}
+	if e.summary && e.period <= 0 {
+		return fmt.Errorf("invalid accounting period: %s", e.period)
+	}
+
+	return nil
+}
+
+// Name returns the application name.
+func (e *Exporter) Name() string {
+	return "accounting-export"
+}
+
+// Run exports the settled records, or their per-period P&L, to the output file, or to the standard output.
+func (e *Exporter) Run(_ context.Context) (interface{}, error) {
+	records, err := ReadSettled(e.dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].BlockID < records[j].BlockID })
+
+	w := e.writer
+	if e.output != "" {
+		f, err := os.Create(e.output)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if e.summary {
+		return nil, writeRows(w, e.format, periodsHeader, Summarize(records, e.period), periodRow)
+	}
+	return nil, writeRows(w, e.format, recordsHeader, records, recordRow)
+}
+
+// writeRows writes the given rows in the given format.
+func writeRows[T any](w io.Writer, format string, header []string, rows []T, row func(T) []string) error {
+	if format == FormatJSON {
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := csvWriter.Write(row(r)); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+var recordsHeader = []string{
+	"blockID", "assignedProver", "proposedAt", "minTier", "feeToken", "fee",
+	"livenessBond", "livenessBondStatus", "validityBond", "validityBondStatus", "provedBlockHash",
+	"contestBond", "contestBondStatus", "claimedBlockHash", "gasCost", "verifiedAt",
+}
+
+// recordRow returns the CSV row of the given record.
+func recordRow(r *Record) []string {
+	return []string{
+		strconv.FormatUint(r.BlockID, 10),
+		r.AssignedProver.Hex(),
+		strconv.FormatUint(r.ProposedAt, 10),
+		strconv.FormatUint(uint64(r.MinTier), 10),
+		r.FeeToken.Hex(),
+		r.Fee.String(),
+		r.LivenessBond.String(),
+		string(r.LivenessBondStatus),
+		r.ValidityBond.String(),
+		string(r.ValidityBondStatus),
+		r.ProvedBlockHash.Hex(),
+		r.ContestBond.String(),
+		string(r.ContestBondStatus),
+		r.ClaimedBlockHash.Hex(),
+		r.GasCost.String(),
+		strconv.FormatUint(r.VerifiedAt, 10),
+	}
+}
+
+var periodsHeader = []string{
+	"start", "blocks", "fees", "tokenFees", "livenessBondsForfeited", "validityBondsLost", "contestBondsLost",
+	"gasCost",
+}
+
+// periodRow returns the CSV row of the given period.
+func periodRow(p *Period) []string {
+	return []string{
+		p.Start.Format(time.RFC3339),
+		strconv.FormatUint(p.Blocks, 10),
+		p.Fees.String(),
+		p.TokenFees.String(),
+		p.LivenessBondsForfeited.String(),
+		p.ValidityBondsLost.String(),
+		p.ContestBondsLost.String(),
+		p.GasCost.String(),
+	}
+}
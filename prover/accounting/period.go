@@ -0,0 +1,79 @@
+package accounting
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// Period is the P&L of the blocks verified in a period. Fees and gas costs are in wei, or in the fee
+// token units for the token fees, and the bonds are in the bond token units. The bonds returned or won
+// are not counted as earnings, since they were the prover's own bonds.
+type Period struct {
+	Start                  time.Time `json:"start"`
+	Blocks                 uint64    `json:"blocks"`
+	Fees                   *big.Int  `json:"fees"`
+	TokenFees              *big.Int  `json:"tokenFees"`
+	LivenessBondsForfeited *big.Int  `json:"livenessBondsForfeited"`
+	ValidityBondsLost      *big.Int  `json:"validityBondsLost"`
+	ContestBondsLost       *big.Int  `json:"contestBondsLost"`
+	GasCost                *big.Int  `json:"gasCost"`
+}
+
+// newPeriod creates a new empty period starting at the given time.
+func newPeriod(start time.Time) *Period {
+	return &Period{
+		Start:                  start,
+		Fees:                   new(big.Int),
+		TokenFees:              new(big.Int),
+		LivenessBondsForfeited: new(big.Int),
+		ValidityBondsLost:      new(big.Int),
+		ContestBondsLost:       new(big.Int),
+		GasCost:                new(big.Int),
+	}
+}
+
+// add adds the given settled record to the period.
+func (p *Period) add(r *Record) {
+	p.Blocks++
+	if r.FeeToken == rpc.ZeroAddress {
+		p.Fees.Add(p.Fees, r.Fee)
+	} else {
+		p.TokenFees.Add(p.TokenFees, r.Fee)
+	}
+	if r.LivenessBondStatus == BondForfeited {
+		p.LivenessBondsForfeited.Add(p.LivenessBondsForfeited, r.LivenessBond)
+	}
+	if r.ValidityBondStatus == BondLost {
+		p.ValidityBondsLost.Add(p.ValidityBondsLost, r.ValidityBond)
+	}
+	if r.ContestBondStatus == BondLost {
+		p.ContestBondsLost.Add(p.ContestBondsLost, r.ContestBond)
+	}
+	p.GasCost.Add(p.GasCost, r.GasCost)
+}
+
+// Summarize groups the given settled records into periods of the given duration by their verification
+// time, the periods are sorted by their start time.
+func Summarize(records []*Record, period time.Duration) []*Period {
+	byStart := make(map[time.Time]*Period)
+	for _, r := range records {
+		start := time.Unix(int64(r.VerifiedAt), 0).Truncate(period).UTC()
+		p, ok := byStart[start]
+		if !ok {
+			p = newPeriod(start)
+			byStart[start] = p
+		}
+		p.add(r)
+	}
+
+	periods := make([]*Period, 0, len(byStart))
+	for _, p := range byStart {
+		periods = append(periods, p)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+
+	return periods
+}
@@ -0,0 +1,237 @@
+package accounting
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// Recorder records the fees, bonds and gas costs of the prover identities into the book, from the on-chain
+// events and the receipts of their transactions.
+type Recorder struct {
+	rpc             *rpc.Client
+	book            *Book
+	assignmentHook  *bindings.AssignmentHook
+	proverAddresses []common.Address
+	// tiers returns the protocol proof tiers.
+	tiers func() []*rpc.TierProviderTierWithID
+	// Block ID of each charged transaction, so that each transaction is only charged once.
+	charged map[common.Hash]uint64
+	wg      sync.WaitGroup
+}
+
+// NewRecorder creates a new Recorder instance, the fees are not recorded if the given assignment hook
+// address is empty.
+func NewRecorder(
+	rpc *rpc.Client,
+	book *Book,
+	assignmentHookAddress common.Address,
+	proverAddresses []common.Address,
+	tiers func() []*rpc.TierProviderTierWithID,
+) (*Recorder, error) {
+	r := &Recorder{
+		rpc:             rpc,
+		book:            book,
+		proverAddresses: proverAddresses,
+		tiers:           tiers,
+		charged:         make(map[common.Hash]uint64),
+	}
+
+	if assignmentHookAddress != (common.Address{}) {
+		assignmentHook, err := bindings.NewAssignmentHook(assignmentHookAddress, rpc.L1)
+		if err != nil {
+			return nil, err
+		}
+		r.assignmentHook = assignmentHook
+	}
+
+	return r, nil
+}
+
+// Start starts the recording loop, which will be stopped when the given context is done.
+func (r *Recorder) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go r.loop(ctx)
+}
+
+// Wait waits until the recording loop exits.
+func (r *Recorder) Wait() {
+	r.wg.Wait()
+}
+
+// loop is the main loop of the recorder.
+func (r *Recorder) loop(ctx context.Context) {
+	defer r.wg.Done()
+
+	blockAssignedCh := make(chan *bindings.AssignmentHookBlockAssigned, 128)
+	blockProposedCh := make(chan *bindings.TaikoL1ClientBlockProposed, 128)
+	transitionProvedCh := make(chan *bindings.TaikoL1ClientTransitionProved, 128)
+	transitionContestedCh := make(chan *bindings.TaikoL1ClientTransitionContested, 128)
+	blockVerifiedCh := make(chan *bindings.TaikoL1ClientBlockVerified, 128)
+	subs := []event.Subscription{
+		rpc.SubscribeBlockProposed(r.rpc.TaikoL1, blockProposedCh),
+		rpc.SubscribeTransitionProved(r.rpc.TaikoL1, transitionProvedCh),
+		rpc.SubscribeTransitionContested(r.rpc.TaikoL1, transitionContestedCh),
+		rpc.SubscribeBlockVerified(r.rpc.TaikoL1, blockVerifiedCh),
+	}
+	if r.assignmentHook != nil {
+		subs = append(subs, rpc.SubscribeBlockAssigned(r.assignmentHook, blockAssignedCh, r.proverAddresses))
+	}
+	defer func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-blockAssignedCh:
+			r.onBlockAssigned(e)
+		case e := <-blockProposedCh:
+			r.onBlockProposed(e)
+		case e := <-transitionProvedCh:
+			err = r.onTransitionProved(ctx, e)
+		case e := <-transitionContestedCh:
+			err = r.onTransitionContested(ctx, e)
+		case e := <-blockVerifiedCh:
+			err = r.onBlockVerified(ctx, e)
+		}
+		if err != nil {
+			log.Warn("Failed to record prover accounting", "error", err)
+		}
+	}
+}
+
+// onBlockAssigned records the fee of a block assigned to a prover identity.
+func (r *Recorder) onBlockAssigned(e *bindings.AssignmentHookBlockAssigned) {
+	for _, tierFee := range e.Assignment.TierFees {
+		if tierFee.Tier == e.Meta.MinTier {
+			r.book.Assigned(e.Meta.Id, e.Assignment.FeeToken, tierFee.Fee)
+			return
+		}
+	}
+}
+
+// onBlockProposed records the liveness bond of a block assigned to a prover identity.
+func (r *Recorder) onBlockProposed(e *bindings.TaikoL1ClientBlockProposed) {
+	if !r.isProverIdentity(e.AssignedProver) {
+		return
+	}
+	r.book.Proposed(e.BlockId.Uint64(), e.AssignedProver, e.LivenessBond, e.Meta.Timestamp, e.Meta.MinTier)
+}
+
+// onTransitionProved settles the bonds of a newly proved block, and records the validity bond and the gas
+// cost if the proof is submitted by a prover identity.
+func (r *Recorder) onTransitionProved(ctx context.Context, e *bindings.TaikoL1ClientTransitionProved) error {
+	blockID := e.BlockId.Uint64()
+	ours := r.isProverIdentity(e.Prover)
+
+	inTime := true
+	if proposedAt, minTier, ok := r.book.LivenessBondLocked(blockID); ok {
+		header, err := r.rpc.L1.HeaderByHash(ctx, e.Raw.BlockHash)
+		if err != nil {
+			return err
+		}
+		inTime = header.Time <= proposedAt+r.provingWindow(minTier)
+	}
+
+	r.book.Proved(blockID, e.Prover, ours, e.Tran.BlockHash, e.ValidityBond, inTime)
+	if !ours {
+		return nil
+	}
+
+	return r.charge(ctx, blockID, e.Raw.TxHash)
+}
+
+// onTransitionContested records the contest bond and the gas cost of a transition contested by
+// a prover identity.
+func (r *Recorder) onTransitionContested(ctx context.Context, e *bindings.TaikoL1ClientTransitionContested) error {
+	if !r.isProverIdentity(e.Contester) {
+		return nil
+	}
+
+	r.book.Contested(e.BlockId.Uint64(), e.Tran.BlockHash, e.ContestBond)
+	return r.charge(ctx, e.BlockId.Uint64(), e.Raw.TxHash)
+}
+
+// onBlockVerified settles the record of a verified block.
+func (r *Recorder) onBlockVerified(ctx context.Context, e *bindings.TaikoL1ClientBlockVerified) error {
+	blockID := e.BlockId.Uint64()
+	for txHash, id := range r.charged {
+		if id <= blockID {
+			delete(r.charged, txHash)
+		}
+	}
+
+	if !r.book.Has(blockID) {
+		return nil
+	}
+
+	header, err := r.rpc.L1.HeaderByHash(ctx, e.Raw.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	if record := r.book.Verified(blockID, e.Prover, e.BlockHash, header.Time); record != nil {
+		log.Info(
+			"Block accounting settled",
+			"blockID", blockID,
+			"fee", record.Fee,
+			"livenessBond", record.LivenessBondStatus,
+			"validityBond", record.ValidityBondStatus,
+			"contestBond", record.ContestBondStatus,
+			"gasCost", record.GasCost,
+		)
+	}
+
+	return nil
+}
+
+// charge records the L1 gas cost of the given transaction, a transaction is only charged once.
+func (r *Recorder) charge(ctx context.Context, blockID uint64, txHash common.Hash) error {
+	if _, ok := r.charged[txHash]; ok {
+		return nil
+	}
+
+	receipt, err := r.rpc.L1.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return err
+	}
+
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	if receipt.BlobGasPrice != nil {
+		cost.Add(cost, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+	}
+
+	r.book.Charged(blockID, cost)
+	r.charged[txHash] = blockID
+
+	return nil
+}
+
+// provingWindow returns the proving window of the given tier in seconds.
+func (r *Recorder) provingWindow(tier uint16) uint64 {
+	for _, t := range r.tiers() {
+		if t.ID == tier {
+			return uint64((time.Duration(t.ProvingWindow) * time.Minute).Seconds())
+		}
+	}
+	return 0
+}
+
+// isProverIdentity checks whether the given address is one of the prover identities.
+func (r *Recorder) isProverIdentity(address common.Address) bool {
+	return slices.Contains(r.proverAddresses, address)
+}
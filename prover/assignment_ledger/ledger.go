@@ -0,0 +1,559 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+)
+
+// Status represents the on-chain status of a signed assignment.
+type Status string
+
+// Assignment statuses.
+const (
+	// StatusPending means the assignment has been signed, but not used on chain yet.
+	StatusPending Status = "pending"
+	// StatusUsed means the assignment has been used by a BlockProposed event.
+	StatusUsed Status = "used"
+	// StatusExpiredUnused means the assignment expired without being used on chain.
+	StatusExpiredUnused Status = "expired_unused"
+	// StatusProven means the assignment has been used, and the prover has proven the block.
+	StatusProven Status = "proven"
+)
+
+// DefaultRetention is the default duration to keep the finalized assignments in the ledger.
+var DefaultRetention = 24 * time.Hour
+
+// Assignment represents a proof assignment signed by the prover.
+type Assignment struct {
+	// Sequence number of the assignment in the ledger, used as the pagination cursor.
+	Seq           uint64             `json:"seq"`
+	RequestID     string             `json:"requestID"`
+	Prover        common.Address     `json:"prover"`
+	Proposer      common.Address     `json:"proposer"`
+	BlobHash      common.Hash        `json:"blobHash"`
+	TierFees      []encoding.TierFee `json:"tierFees"`
+	Expiry        uint64             `json:"expiry"`
+	MaxBlockID    uint64             `json:"maxBlockID"`
+	MaxProposedIn uint64             `json:"maxProposedIn"`
+	SignedAt      uint64             `json:"signedAt"`
+	Status        Status             `json:"status"`
+	BlockID       *big.Int           `json:"blockID,omitempty"`
+	ProposedIn    uint64             `json:"proposedIn,omitempty"`
+	UpdatedAt     uint64             `json:"updatedAt"`
+}
+
+// ProposerStats represents the cumulative assignment statistics of a proposer.
+type ProposerStats struct {
+	Signed        uint64 `json:"signed"`
+	Used          uint64 `json:"used"`
+	ExpiredUnused uint64 `json:"expiredUnused"`
+	Proven        uint64 `json:"proven"`
+}
+
+// state is the persisted state of the ledger.
+type state struct {
+	Assignments    []*Assignment                     `json:"assignments"`
+	Stats          map[common.Address]*ProposerStats `json:"stats"`
+	NextSeq        uint64                            `json:"nextSeq"`
+	LastVerifiedID uint64                            `json:"lastVerifiedID"`
+	L1Height       uint64                            `json:"l1Height"`
+}
+
+// Ledger keeps the records of all assignments signed by the prover, and their on-chain status.
+type Ledger struct {
+	assignments []*Assignment
+	byBlockID   map[uint64]*Assignment
+	stats       map[common.Address]*ProposerStats
+	nextSeq     uint64
+	// ID of the latest verified block, the used assignments of the verified blocks can no longer be proven.
+	lastVerifiedID uint64
+	// Height of the latest L1 block whose events have all been reconciled.
+	l1Height  uint64
+	retention time.Duration
+	// File to persist the ledger, empty means no persistence.
+	stateFile string
+	// ID of the L2 chain whose assignments are recorded, used in metrics.
+	chain     string
+	mutex     sync.RWMutex
+	persistCh chan struct{}
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// New creates a new assignment ledger instance, the finalized assignments will be removed after the given
+// retention. The assignments persisted in the given state file are restored, an empty file path means
+// no persistence, the state is written in background, and Close must be called to write the last changes.
+// The metrics are labelled with the given L2 chain ID.
+func New(retention time.Duration, stateFile string, chain string) (*Ledger, error) {
+	l := &Ledger{
+		byBlockID: make(map[uint64]*Assignment),
+		stats:     make(map[common.Address]*ProposerStats),
+		nextSeq:   1,
+		retention: retention,
+		stateFile: stateFile,
+		chain:     chain,
+		persistCh: make(chan struct{}, 1),
+		closeCh:   make(chan struct{}),
+	}
+
+	if err := l.load(); err != nil {
+		return nil, fmt.Errorf("failed to load assignment ledger state: %w", err)
+	}
+
+	if l.stateFile != "" {
+		l.wg.Add(1)
+		go l.persistLoop()
+	}
+
+	return l, nil
+}
+
+// Close stops writing the state in background, and writes the changes not persisted yet.
+func (l *Ledger) Close() {
+	l.closeOnce.Do(func() { close(l.closeCh) })
+	l.wg.Wait()
+}
+
+// Add records a newly signed assignment.
+func (l *Ledger) Add(a *Assignment) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := uint64(time.Now().Unix())
+	a.Seq = l.nextSeq
+	l.nextSeq++
+	a.Status = StatusPending
+	a.SignedAt = now
+	a.UpdatedAt = now
+
+	l.assignments = append(l.assignments, a)
+	l.proposerStats(a.Proposer).Signed++
+
+	metrics.ProverAssignmentSignedCounter.Add(1)
+	metrics.ProverAssignmentPendingGaugeVec.WithLabelValues(l.chain).Inc()
+
+	l.persist()
+}
+
+// MarkUsed marks the latest pending assignment of the given prover, proposer and blob hash, which is still
+// valid in the given L1 block, as used by the given L2 block.
+func (l *Ledger) MarkUsed(
+	prover common.Address,
+	proposer common.Address,
+	blobHash common.Hash,
+	blockID *big.Int,
+	l1Height uint64,
+) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// The same event might be reconciled again after a restart.
+	if a, ok := l.byBlockID[blockID.Uint64()]; ok && a.Prover == prover && a.ProposedIn == l1Height {
+		return true
+	}
+
+	for i := len(l.assignments) - 1; i >= 0; i-- {
+		a := l.assignments[i]
+		if a.Status != StatusPending ||
+			a.Prover != prover ||
+			a.Proposer != proposer ||
+			a.BlobHash != blobHash ||
+			a.MaxBlockID < l1Height {
+			continue
+		}
+
+		a.Status = StatusUsed
+		a.BlockID = new(big.Int).Set(blockID)
+		a.ProposedIn = l1Height
+		a.UpdatedAt = uint64(time.Now().Unix())
+		l.byBlockID[blockID.Uint64()] = a
+		l.proposerStats(proposer).Used++
+
+		metrics.ProverAssignmentUsedCounter.Add(1)
+		metrics.ProverAssignmentPendingGaugeVec.WithLabelValues(l.chain).Dec()
+
+		log.Debug("Assignment used", "requestID", a.RequestID, "proposer", proposer, "blockID", blockID)
+		l.persist()
+		return true
+	}
+
+	return false
+}
+
+// UnmarkUsed reverts the assignment of the given prover used by the given L2 block proposed in the given
+// L1 block back to pending, once the BlockProposed event is removed by an L1 reorg.
+func (l *Ledger) UnmarkUsed(prover common.Address, blockID *big.Int, l1Height uint64) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	a, ok := l.byBlockID[blockID.Uint64()]
+	if !ok || a.Prover != prover || a.ProposedIn != l1Height || (a.Status != StatusUsed && a.Status != StatusProven) {
+		return false
+	}
+
+	stats := l.proposerStats(a.Proposer)
+	if a.Status == StatusProven {
+		stats.Proven--
+	}
+	stats.Used--
+
+	delete(l.byBlockID, blockID.Uint64())
+	a.Status = StatusPending
+	a.BlockID = nil
+	a.ProposedIn = 0
+	a.UpdatedAt = uint64(time.Now().Unix())
+
+	metrics.ProverAssignmentPendingGaugeVec.WithLabelValues(l.chain).Inc()
+
+	log.Info("Assignment usage reorged out", "requestID", a.RequestID, "proposer", a.Proposer, "blockID", blockID)
+	l.persist()
+	return true
+}
+
+// MarkProven marks the used assignment of the given L2 block as proven.
+func (l *Ledger) MarkProven(blockID *big.Int) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	a, ok := l.byBlockID[blockID.Uint64()]
+	if !ok || a.Status != StatusUsed {
+		return false
+	}
+
+	a.Status = StatusProven
+	a.UpdatedAt = uint64(time.Now().Unix())
+	l.proposerStats(a.Proposer).Proven++
+
+	metrics.ProverAssignmentProvenCounter.Add(1)
+
+	l.persist()
+	return true
+}
+
+// UnmarkProven reverts the proven assignment of the given L2 block back to used, once the TransitionProved
+// event is removed by an L1 reorg.
+func (l *Ledger) UnmarkProven(blockID *big.Int) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	a, ok := l.byBlockID[blockID.Uint64()]
+	if !ok || a.Status != StatusProven {
+		return false
+	}
+
+	a.Status = StatusUsed
+	a.UpdatedAt = uint64(time.Now().Unix())
+	l.proposerStats(a.Proposer).Proven--
+
+	log.Info("Assignment proof reorged out", "requestID", a.RequestID, "proposer", a.Proposer, "blockID", blockID)
+	l.persist()
+	return true
+}
+
+// MarkVerified records the latest verified L2 block, the used assignments of the verified blocks are pruned
+// after the retention, whether the blocks were proven by the prover or not.
+func (l *Ledger) MarkVerified(blockID *big.Int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if blockID.Uint64() <= l.lastVerifiedID {
+		return
+	}
+	l.lastVerifiedID = blockID.Uint64()
+
+	// The verified blocks can no longer be proven or reorged out.
+	for id := range l.byBlockID {
+		if id <= l.lastVerifiedID {
+			delete(l.byBlockID, id)
+		}
+	}
+
+	l.prune()
+	l.persist()
+}
+
+// MarkExpired marks all pending assignments, which can no longer be used in the given L1 block,
+// as expired, and returns the number of newly expired assignments. All the events up to the given
+// L1 block must have been reconciled.
+func (l *Ledger) MarkExpired(l1Height uint64, l1Timestamp uint64) int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l1Height > l.l1Height {
+		l.l1Height = l1Height
+	}
+
+	expired := 0
+	for _, a := range l.assignments {
+		if a.Status != StatusPending || (a.MaxBlockID >= l1Height && a.Expiry >= l1Timestamp) {
+			continue
+		}
+
+		a.Status = StatusExpiredUnused
+		a.UpdatedAt = uint64(time.Now().Unix())
+		l.proposerStats(a.Proposer).ExpiredUnused++
+		expired++
+
+		metrics.ProverAssignmentExpiredUnusedCounter.Add(1)
+		metrics.ProverAssignmentPendingGaugeVec.WithLabelValues(l.chain).Dec()
+
+		log.Debug("Assignment expired unused", "requestID", a.RequestID, "proposer", a.Proposer)
+	}
+
+	l.prune()
+	l.persist()
+
+	return expired
+}
+
+// L1Height returns the height of the latest L1 block whose events have all been reconciled, the
+// events after it are reconciled again after a restart.
+func (l *Ledger) L1Height() uint64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.l1Height
+}
+
+// Assignments returns copies of at most limit assignments with the given status, or of all statuses if the
+// given status is empty, whose sequence numbers are greater than the given cursor, in the signing order.
+// The returned cursor is the sequence number of the last returned assignment if there might be more
+// assignments, zero otherwise. A zero limit means no limit.
+func (l *Ledger) Assignments(status Status, cursor uint64, limit int) ([]*Assignment, uint64) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	// The assignments are kept in the signing order, so they are sorted by their sequence numbers.
+	start := sort.Search(len(l.assignments), func(i int) bool { return l.assignments[i].Seq > cursor })
+
+	assignments := make([]*Assignment, 0)
+	for _, a := range l.assignments[start:] {
+		if status != "" && a.Status != status {
+			continue
+		}
+		if limit != 0 && len(assignments) == limit {
+			return assignments, assignments[len(assignments)-1].Seq
+		}
+
+		copied := *a
+		if a.BlockID != nil {
+			copied.BlockID = new(big.Int).Set(a.BlockID)
+		}
+		assignments = append(assignments, &copied)
+	}
+
+	return assignments, 0
+}
+
+// Pending returns the number of pending assignments signed by the given prover.
+func (l *Ledger) Pending(prover common.Address) int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	pending := 0
+	for _, a := range l.assignments {
+		if a.Status == StatusPending && a.Prover == prover {
+			pending++
+		}
+	}
+
+	return pending
+}
+
+// Stats returns the cumulative assignment statistics of at most limit proposers, whose addresses are
+// greater than the given cursor, in the address order. The returned cursor is the address of the last
+// returned proposer if there might be more proposers, nil otherwise. A zero limit means no limit.
+func (l *Ledger) Stats(cursor common.Address, limit int) (map[common.Address]ProposerStats, *common.Address) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	proposers := make([]common.Address, 0, len(l.stats))
+	for proposer := range l.stats {
+		if bytes.Compare(proposer.Bytes(), cursor.Bytes()) > 0 {
+			proposers = append(proposers, proposer)
+		}
+	}
+	sort.Slice(proposers, func(i, j int) bool { return bytes.Compare(proposers[i].Bytes(), proposers[j].Bytes()) < 0 })
+
+	var next *common.Address
+	if limit != 0 && len(proposers) > limit {
+		proposers = proposers[:limit]
+		next = &proposers[limit-1]
+	}
+
+	stats := make(map[common.Address]ProposerStats, len(proposers))
+	for _, proposer := range proposers {
+		stats[proposer] = *l.stats[proposer]
+	}
+
+	return stats, next
+}
+
+// proposerStats returns the statistics of the given proposer, the caller must hold the mutex.
+func (l *Ledger) proposerStats(proposer common.Address) *ProposerStats {
+	s, ok := l.stats[proposer]
+	if !ok {
+		s = new(ProposerStats)
+		l.stats[proposer] = s
+	}
+	return s
+}
+
+// prune removes the finalized assignments which are older than the retention, and returns whether any
+// assignment is removed, the caller must hold the mutex. The used assignments are only finalized once their
+// blocks are verified.
+func (l *Ledger) prune() bool {
+	deadline := uint64(time.Now().Add(-l.retention).Unix())
+
+	kept := l.assignments[:0]
+	for _, a := range l.assignments {
+		if a.UpdatedAt < deadline && l.isFinalized(a) {
+			if a.BlockID != nil {
+				delete(l.byBlockID, a.BlockID.Uint64())
+			}
+			continue
+		}
+		kept = append(kept, a)
+	}
+	pruned := len(kept) != len(l.assignments)
+	// Clear the removed tail, so that the removed assignments can be garbage collected.
+	clear(l.assignments[len(kept):])
+	l.assignments = kept
+
+	return pruned
+}
+
+// isFinalized returns whether the given assignment's status can no longer change, the caller must hold
+// the mutex.
+func (l *Ledger) isFinalized(a *Assignment) bool {
+	switch a.Status {
+	case StatusPending:
+		return false
+	case StatusUsed, StatusProven:
+		return a.BlockID != nil && a.BlockID.Uint64() <= l.lastVerifiedID
+	default:
+		return true
+	}
+}
+
+// load loads the persisted state from the state file.
+func (l *Ledger) load() error {
+	if l.stateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(l.stateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	st := new(state)
+	if err := json.Unmarshal(data, st); err != nil {
+		return err
+	}
+
+	l.assignments = st.Assignments
+	l.lastVerifiedID = st.LastVerifiedID
+	l.l1Height = st.L1Height
+	if st.NextSeq > l.nextSeq {
+		l.nextSeq = st.NextSeq
+	}
+	if st.Stats != nil {
+		l.stats = st.Stats
+	}
+	for _, a := range l.assignments {
+		switch a.Status {
+		case StatusPending:
+			metrics.ProverAssignmentPendingGaugeVec.WithLabelValues(l.chain).Inc()
+		case StatusUsed, StatusProven:
+			if a.BlockID != nil && a.BlockID.Uint64() > l.lastVerifiedID {
+				l.byBlockID[a.BlockID.Uint64()] = a
+			}
+		}
+	}
+
+	log.Info(
+		"Assignment ledger restored",
+		"assignments", len(l.assignments),
+		"lastVerifiedID", l.lastVerifiedID,
+		"l1Height", l.l1Height,
+	)
+
+	return nil
+}
+
+// persist schedules writing the current state to the state file, the caller must hold the mutex. The changes
+// made before the state is written are persisted together.
+func (l *Ledger) persist() {
+	if l.stateFile == "" {
+		return
+	}
+
+	select {
+	case l.persistCh <- struct{}{}:
+	default:
+	}
+}
+
+// persistLoop writes the state to the state file once it's changed, until the ledger is closed.
+func (l *Ledger) persistLoop() {
+	defer l.wg.Done()
+
+	for {
+		select {
+		case <-l.persistCh:
+			l.write()
+		case <-l.closeCh:
+			select {
+			case <-l.persistCh:
+				l.write()
+			default:
+			}
+			return
+		}
+	}
+}
+
+// write writes the current state to the state file, the file I/O is done without holding the mutex.
+func (l *Ledger) write() {
+	l.mutex.RLock()
+	data, err := json.Marshal(&state{
+		Assignments:    l.assignments,
+		Stats:          l.stats,
+		NextSeq:        l.nextSeq,
+		LastVerifiedID: l.lastVerifiedID,
+		L1Height:       l.l1Height,
+	})
+	l.mutex.RUnlock()
+	if err != nil {
+		log.Error("Failed to marshal assignment ledger state", "error", err)
+		return
+	}
+
+	// Write to a temporary file at first, so the state file will never be partially written.
+	tmp := l.stateFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		log.Error("Failed to write assignment ledger state", "error", err)
+		return
+	}
+	if err := os.Rename(tmp, l.stateFile); err != nil {
+		log.Error("Failed to write assignment ledger state", "error", err)
+	}
+}
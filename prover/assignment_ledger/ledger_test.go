@@ -0,0 +1,213 @@
+package ledger
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type AssignmentLedgerTestSuite struct {
+	suite.Suite
+	ledger   *Ledger
+	prover   common.Address
+	proposer common.Address
+	blobHash common.Hash
+}
+
+func (s *AssignmentLedgerTestSuite) SetupTest() {
+	var err error
+	s.ledger, err = New(DefaultRetention, "", "")
+	s.Nil(err)
+	s.prover = common.BytesToAddress([]byte{3})
+	s.proposer = common.BytesToAddress([]byte{1})
+	s.blobHash = common.BytesToHash([]byte{2})
+}
+
+func (s *AssignmentLedgerTestSuite) TestMarkUsedAndProven() {
+	s.ledger.Add(&Assignment{RequestID: "1", Prover: s.prover, Proposer: s.proposer, BlobHash: s.blobHash, MaxBlockID: 10})
+	s.ledger.Add(&Assignment{RequestID: "2", Prover: s.prover, Proposer: s.proposer, BlobHash: s.blobHash, MaxBlockID: 20})
+
+	// Unknown prover, proposer or blob hash.
+	s.False(s.ledger.MarkUsed(common.Address{}, s.proposer, s.blobHash, common.Big1, 5))
+	s.False(s.ledger.MarkUsed(s.prover, common.Address{}, s.blobHash, common.Big1, 5))
+	s.False(s.ledger.MarkUsed(s.prover, s.proposer, common.Hash{}, common.Big1, 5))
+
+	// The latest valid assignment should be used.
+	s.True(s.ledger.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big1, 5))
+	used, _ := s.ledger.Assignments(StatusUsed, 0, 0)
+	s.Equal(1, len(used))
+	s.Equal("2", used[0].RequestID)
+	s.Equal(common.Big1, used[0].BlockID)
+
+	// The remaining assignment is no longer valid in L1 block 11.
+	s.False(s.ledger.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big2, 11))
+
+	s.False(s.ledger.MarkProven(common.Big2))
+	s.True(s.ledger.MarkProven(common.Big1))
+	s.False(s.ledger.MarkProven(common.Big1))
+	proven, _ := s.ledger.Assignments(StatusProven, 0, 0)
+	s.Equal(1, len(proven))
+
+	stats, _ := s.ledger.Stats(common.Address{}, 0)
+	s.Equal(ProposerStats{Signed: 2, Used: 1, Proven: 1}, stats[s.proposer])
+}
+
+func (s *AssignmentLedgerTestSuite) TestMarkExpired() {
+	now := uint64(time.Now().Unix())
+	s.ledger.Add(&Assignment{RequestID: "1", Prover: s.prover, Proposer: s.proposer, MaxBlockID: 10, Expiry: now + 60})
+	s.ledger.Add(&Assignment{RequestID: "2", Prover: s.prover, Proposer: s.proposer, MaxBlockID: 20, Expiry: now + 60})
+	s.ledger.Add(&Assignment{RequestID: "3", Prover: s.prover, Proposer: s.proposer, MaxBlockID: 20, Expiry: now - 60})
+
+	s.Equal(0, s.ledger.MarkExpired(5, now-120))
+	s.Equal(2, s.ledger.MarkExpired(11, now))
+	s.Equal(0, s.ledger.MarkExpired(11, now))
+
+	pending, _ := s.ledger.Assignments(StatusPending, 0, 0)
+	s.Equal(1, len(pending))
+	s.Equal(1, s.ledger.Pending(s.prover))
+	s.Zero(s.ledger.Pending(common.Address{}))
+	s.Equal("2", pending[0].RequestID)
+	all, _ := s.ledger.Assignments("", 0, 0)
+	s.Equal(3, len(all))
+	stats, _ := s.ledger.Stats(common.Address{}, 0)
+	s.Equal(uint64(2), stats[s.proposer].ExpiredUnused)
+}
+
+func (s *AssignmentLedgerTestSuite) TestPrune() {
+	var err error
+	s.ledger, err = New(0, "", "")
+	s.Nil(err)
+	s.ledger.Add(&Assignment{RequestID: "1", Prover: s.prover, Proposer: s.proposer, MaxBlockID: 10})
+	s.ledger.Add(&Assignment{RequestID: "2", Prover: s.prover, Proposer: s.proposer, MaxBlockID: 20})
+
+	// Finalized assignments are removed, but the statistics are kept.
+	s.Equal(1, s.ledger.MarkExpired(11, 0))
+	all, _ := s.ledger.Assignments("", 0, 0)
+	s.Equal(2, len(all))
+	time.Sleep(time.Second)
+	s.Equal(0, s.ledger.MarkExpired(11, 0))
+	all, _ = s.ledger.Assignments("", 0, 0)
+	s.Equal(1, len(all))
+	stats, _ := s.ledger.Stats(common.Address{}, 0)
+	s.Equal(uint64(2), stats[s.proposer].Signed)
+}
+
+func (s *AssignmentLedgerTestSuite) TestPruneVerified() {
+	var err error
+	s.ledger, err = New(0, "", "")
+	s.Nil(err)
+	s.ledger.Add(&Assignment{RequestID: "1", Prover: s.prover, Proposer: s.proposer, BlobHash: s.blobHash, MaxBlockID: 10})
+	s.ledger.Add(&Assignment{RequestID: "2", Prover: s.prover, Proposer: s.proposer, BlobHash: s.blobHash, MaxBlockID: 10})
+	s.True(s.ledger.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big1, 5))
+	s.True(s.ledger.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big2, 5))
+	s.True(s.ledger.MarkProven(common.Big1))
+	time.Sleep(time.Second)
+
+	// Unverified assignments are kept, whether proven or not.
+	s.Equal(0, s.ledger.MarkExpired(5, 0))
+	all, _ := s.ledger.Assignments("", 0, 0)
+	s.Equal(2, len(all))
+
+	s.ledger.MarkVerified(common.Big1)
+	all, _ = s.ledger.Assignments("", 0, 0)
+	s.Equal(1, len(all))
+	s.Equal(common.Big2, all[0].BlockID)
+	s.False(s.ledger.MarkProven(common.Big1))
+
+	// Used but never proven by the prover.
+	s.ledger.MarkVerified(common.Big2)
+	all, _ = s.ledger.Assignments("", 0, 0)
+	s.Empty(all)
+	s.False(s.ledger.MarkProven(common.Big2))
+}
+
+func (s *AssignmentLedgerTestSuite) TestUnmarkReorged() {
+	s.ledger.Add(&Assignment{RequestID: "1", Prover: s.prover, Proposer: s.proposer, BlobHash: s.blobHash, MaxBlockID: 10})
+	s.True(s.ledger.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big1, 5))
+	s.True(s.ledger.MarkProven(common.Big1))
+
+	s.False(s.ledger.UnmarkProven(common.Big2))
+	s.True(s.ledger.UnmarkProven(common.Big1))
+	s.False(s.ledger.UnmarkProven(common.Big1))
+
+	s.False(s.ledger.UnmarkUsed(common.Address{}, common.Big1, 5))
+	s.False(s.ledger.UnmarkUsed(s.prover, common.Big1, 6))
+	s.True(s.ledger.UnmarkUsed(s.prover, common.Big1, 5))
+	s.Equal(1, s.ledger.Pending(s.prover))
+
+	stats, _ := s.ledger.Stats(common.Address{}, 0)
+	s.Equal(ProposerStats{Signed: 1}, stats[s.proposer])
+
+	// The assignment can be used again by the re-proposed block.
+	s.True(s.ledger.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big2, 6))
+	used, _ := s.ledger.Assignments(StatusUsed, 0, 0)
+	s.Equal(1, len(used))
+	s.Equal(common.Big2, used[0].BlockID)
+}
+
+func (s *AssignmentLedgerTestSuite) TestPagination() {
+	for i := 1; i <= 5; i++ {
+		s.ledger.Add(&Assignment{
+			RequestID:  big.NewInt(int64(i)).String(),
+			Prover:     s.prover,
+			Proposer:   common.BigToAddress(big.NewInt(int64(i))),
+			MaxBlockID: 10,
+		})
+	}
+
+	page, next := s.ledger.Assignments("", 0, 2)
+	s.Equal([]string{"1", "2"}, []string{page[0].RequestID, page[1].RequestID})
+	page, next = s.ledger.Assignments("", next, 2)
+	s.Equal([]string{"3", "4"}, []string{page[0].RequestID, page[1].RequestID})
+	page, next = s.ledger.Assignments("", next, 2)
+	s.Equal(1, len(page))
+	s.Equal("5", page[0].RequestID)
+	s.Zero(next)
+
+	stats, cursor := s.ledger.Stats(common.Address{}, 3)
+	s.Equal(3, len(stats))
+	s.Equal(common.BigToAddress(common.Big3), *cursor)
+	stats, cursor = s.ledger.Stats(*cursor, 3)
+	s.Equal(2, len(stats))
+	s.Contains(stats, common.BigToAddress(big.NewInt(5)))
+	s.Nil(cursor)
+}
+
+func (s *AssignmentLedgerTestSuite) TestPersistence() {
+	stateFile := filepath.Join(s.T().TempDir(), "assignments.json")
+	l, err := New(DefaultRetention, stateFile, "")
+	s.Nil(err)
+	l.Add(&Assignment{RequestID: "1", Prover: s.prover, Proposer: s.proposer, BlobHash: s.blobHash, MaxBlockID: 10})
+	l.Add(&Assignment{RequestID: "2", Prover: s.prover, Proposer: s.proposer, BlobHash: s.blobHash, MaxBlockID: 10})
+	s.True(l.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big1, 5))
+	s.Zero(l.MarkExpired(8, 0))
+	l.Close()
+
+	reloaded, err := New(DefaultRetention, stateFile, "")
+	s.Nil(err)
+	defer reloaded.Close()
+	s.Equal(uint64(8), reloaded.L1Height())
+
+	// The usage reconciled again after the restart doesn't use the other pending assignment.
+	s.True(reloaded.MarkUsed(s.prover, s.proposer, s.blobHash, common.Big1, 5))
+	all, _ := reloaded.Assignments("", 0, 0)
+	s.Equal(2, len(all))
+	s.Equal(1, reloaded.Pending(s.prover))
+	stats, _ := reloaded.Stats(common.Address{}, 0)
+	s.Equal(ProposerStats{Signed: 2, Used: 1}, stats[s.proposer])
+
+	// The block index and sequence numbers are restored as well.
+	s.True(reloaded.MarkProven(common.Big1))
+	reloaded.Add(&Assignment{RequestID: "3", Prover: s.prover, Proposer: s.proposer, MaxBlockID: 10})
+	page, _ := reloaded.Assignments("", all[1].Seq, 0)
+	s.Equal(1, len(page))
+	s.Equal("3", page[0].RequestID)
+}
+
+func TestAssignmentLedgerTestSuite(t *testing.T) {
+	suite.Run(t, new(AssignmentLedgerTestSuite))
+}
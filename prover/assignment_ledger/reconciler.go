@@ -0,0 +1,226 @@
+package ledger
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+var (
+	// reconcileInterval is the interval of checking the expired assignments.
+	reconcileInterval = 12 * time.Second
+	// expiryConfirmations is the number of L1 blocks to wait before marking an assignment as expired,
+	// so that the BlockProposed events of the recent L1 blocks can be received at first.
+	expiryConfirmations uint64 = 2
+	// backfillBatchSize is the number of L1 blocks whose events are filtered at once, when reconciling the
+	// events missed since the last run.
+	backfillBatchSize uint64 = 1000
+)
+
+// Reconciler matches the assignments in the ledger with the on-chain BlockProposed and
+// TransitionProved events of the prover identities, and prunes them once their blocks are verified.
+type Reconciler struct {
+	rpc             *rpc.Client
+	ledger          *Ledger
+	proverAddresses []common.Address
+	wg              sync.WaitGroup
+}
+
+// NewReconciler creates a new Reconciler instance.
+func NewReconciler(rpc *rpc.Client, ledger *Ledger, proverAddresses []common.Address) *Reconciler {
+	return &Reconciler{rpc: rpc, ledger: ledger, proverAddresses: proverAddresses}
+}
+
+// Start starts the reconciliation loop, which will be stopped when the given context is done.
+func (r *Reconciler) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go r.loop(ctx)
+}
+
+// Wait waits until the reconciliation loop exits.
+func (r *Reconciler) Wait() {
+	r.wg.Wait()
+}
+
+// loop is the main loop of the reconciler.
+func (r *Reconciler) loop(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(reconcileInterval)
+	defer ticker.Stop()
+
+	blockProposedCh := make(chan *bindings.TaikoL1ClientBlockProposed, 128)
+	transitionProvedCh := make(chan *bindings.TaikoL1ClientTransitionProved, 128)
+	blockVerifiedCh := make(chan *bindings.TaikoL1ClientBlockVerified, 128)
+	blockProposedSub := rpc.SubscribeBlockProposed(r.rpc.TaikoL1, blockProposedCh)
+	transitionProvedSub := rpc.SubscribeTransitionProved(r.rpc.TaikoL1, transitionProvedCh)
+	blockVerifiedSub := rpc.SubscribeBlockVerified(r.rpc.TaikoL1, blockVerifiedCh)
+	defer func() {
+		blockProposedSub.Unsubscribe()
+		transitionProvedSub.Unsubscribe()
+		blockVerifiedSub.Unsubscribe()
+	}()
+
+	// The assignments are only marked as expired once the events missed since the last run are reconciled,
+	// otherwise the assignments used meanwhile would be marked as expired.
+	backfilled := r.backfill(ctx) == nil
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-blockProposedCh:
+			r.onBlockProposed(e)
+		case e := <-transitionProvedCh:
+			r.onTransitionProved(e)
+		case e := <-blockVerifiedCh:
+			if !e.Raw.Removed {
+				r.ledger.MarkVerified(e.BlockId)
+			}
+		case <-ticker.C:
+			if !backfilled {
+				if backfilled = r.backfill(ctx) == nil; !backfilled {
+					continue
+				}
+			}
+			if err := r.markExpired(ctx); err != nil {
+				log.Warn("Failed to mark expired assignments", "error", err)
+			}
+		}
+	}
+}
+
+// backfill reconciles the events emitted since the latest L1 block reconciled by the ledger, which were
+// missed while the prover was not running.
+func (r *Reconciler) backfill(ctx context.Context) error {
+	start := r.ledger.L1Height()
+	if start == 0 {
+		return nil
+	}
+
+	head, err := r.rpc.L1.BlockNumber(ctx)
+	if err != nil {
+		log.Warn("Failed to get L1 head to backfill assignments", "error", err)
+		return err
+	}
+
+	log.Info("Backfill assignment events", "from", start, "to", head)
+
+	for start <= head {
+		end := min(start+backfillBatchSize-1, head)
+		if err := r.backfillRange(ctx, start, end); err != nil {
+			log.Warn("Failed to backfill assignment events", "from", start, "to", end, "error", err)
+			return err
+		}
+		start = end + 1
+	}
+
+	return nil
+}
+
+// backfillRange reconciles the events emitted in the given L1 blocks, a block is always proposed before
+// it's proven, and proven before it's verified.
+func (r *Reconciler) backfillRange(ctx context.Context, start uint64, end uint64) error {
+	opts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+
+	blockProposedIter, err := r.rpc.TaikoL1.FilterBlockProposed(opts, nil, r.proverAddresses)
+	if err != nil {
+		return err
+	}
+	defer blockProposedIter.Close()
+	for blockProposedIter.Next() {
+		r.onBlockProposed(blockProposedIter.Event)
+	}
+	if err := blockProposedIter.Error(); err != nil {
+		return err
+	}
+
+	transitionProvedIter, err := r.rpc.TaikoL1.FilterTransitionProved(opts, nil)
+	if err != nil {
+		return err
+	}
+	defer transitionProvedIter.Close()
+	for transitionProvedIter.Next() {
+		r.onTransitionProved(transitionProvedIter.Event)
+	}
+	if err := transitionProvedIter.Error(); err != nil {
+		return err
+	}
+
+	blockVerifiedIter, err := r.rpc.TaikoL1.FilterBlockVerified(opts, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer blockVerifiedIter.Close()
+	for blockVerifiedIter.Next() {
+		r.ledger.MarkVerified(blockVerifiedIter.Event.BlockId)
+	}
+
+	return blockVerifiedIter.Error()
+}
+
+// onBlockProposed marks the assignment used by the given BlockProposed event, or reverts it to pending if
+// the event is removed by an L1 reorg.
+func (r *Reconciler) onBlockProposed(e *bindings.TaikoL1ClientBlockProposed) {
+	if !slices.Contains(r.proverAddresses, e.AssignedProver) {
+		return
+	}
+	if e.Raw.Removed {
+		r.ledger.UnmarkUsed(e.AssignedProver, e.BlockId, e.Raw.BlockNumber)
+		return
+	}
+
+	if !r.ledger.MarkUsed(e.AssignedProver, e.Meta.Sender, e.Meta.BlobHash, e.BlockId, e.Raw.BlockNumber) {
+		log.Debug(
+			"No pending assignment found for the proposed block",
+			"blockID", e.BlockId,
+			"proposer", e.Meta.Sender,
+			"blobHash", common.Hash(e.Meta.BlobHash),
+		)
+	}
+}
+
+// onTransitionProved marks the assignment of the block proven by the given TransitionProved event as proven,
+// or reverts it to used if the event is removed by an L1 reorg.
+func (r *Reconciler) onTransitionProved(e *bindings.TaikoL1ClientTransitionProved) {
+	if !slices.Contains(r.proverAddresses, e.Prover) {
+		return
+	}
+	if e.Raw.Removed {
+		r.ledger.UnmarkProven(e.BlockId)
+		return
+	}
+
+	r.ledger.MarkProven(e.BlockId)
+}
+
+// markExpired marks the pending assignments which can no longer be used on chain as expired.
+func (r *Reconciler) markExpired(ctx context.Context) error {
+	l1Head, err := r.rpc.L1.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if l1Head <= expiryConfirmations {
+		return nil
+	}
+
+	header, err := r.rpc.L1.HeaderByNumber(ctx, new(big.Int).SetUint64(l1Head-expiryConfirmations))
+	if err != nil {
+		return err
+	}
+
+	if expired := r.ledger.MarkExpired(header.Number.Uint64(), header.Time); expired != 0 {
+		log.Info("Assignments expired without being used", "count", expired, "l1Height", header.Number)
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	handler "github.com/taikoxyz/taiko-client/prover/event_handler"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+	"github.com/taikoxyz/taiko-client/prover/proof_submitter/transaction"
+	state "github.com/taikoxyz/taiko-client/prover/shared_state"
+)
+
+// BlockProofStatus is the on-chain proof status of an L2 block.
+type BlockProofStatus struct {
+	IsSubmitted bool           `json:"isSubmitted"`
+	Invalid     bool           `json:"invalid"`
+	Tier        uint16         `json:"tier"`
+	Prover      common.Address `json:"prover"`
+	Contester   common.Address `json:"contester"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	StateRoot   common.Hash    `json:"stateRoot"`
+}
+
+// ProveBlockResult is the result of proving, or contesting, a single L2 block by hand.
+type ProveBlockResult struct {
+	BlockID        uint64            `json:"blockID"`
+	ProposedIn     uint64            `json:"proposedIn"`
+	AssignedProver common.Address    `json:"assignedProver"`
+	MinTier        uint16            `json:"minTier"`
+	ProofStatus    *BlockProofStatus `json:"proofStatus"`
+	Contest        bool              `json:"contest"`
+	Tier           uint16            `json:"tier"`
+	BlockHash      common.Hash       `json:"blockHash,omitempty"`
+	StateRoot      common.Hash       `json:"stateRoot,omitempty"`
+	Proof          hexutil.Bytes     `json:"proof,omitempty"`
+	GenerationTime string            `json:"generationTime,omitempty"`
+	DryRun         bool              `json:"dryRun"`
+	Sent           bool              `json:"sent"`
+	// On-chain proof status after the proof or contest was sent.
+	NewProofStatus *BlockProofStatus `json:"newProofStatus,omitempty"`
+}
+
+// BlockProver proves, or contests, a single L2 block by hand, without running the event-driven prover.
+type BlockProver struct {
+	prover  *Prover
+	blockID *big.Int
+	tier    uint16
+	contest bool
+	dryRun  bool
+}
+
+// InitFromCli initializes the given block prover instance based on the command line flags.
+func (b *BlockProver) InitFromCli(ctx context.Context, c *cli.Context) error {
+	cfg, err := NewConfigFromCliContext(c)
+	if err != nil {
+		return err
+	}
+
+	b.blockID = new(big.Int).SetUint64(c.Uint64(flags.ProveBlockID.Name))
+	b.tier = uint16(c.Uint(flags.ProveTier.Name))
+	b.contest = c.Bool(flags.ProveContest.Name)
+	b.dryRun = c.Bool(flags.ProveDryRun.Name)
+
+	return b.initFromConfig(ctx, cfg)
+}
+
+// initFromConfig initializes the clients, proof submitters and proof contester of the primary prover identity.
+func (b *BlockProver) initFromConfig(ctx context.Context, cfg *Config) (err error) {
+	p := &Prover{
+		cfg:               cfg,
+		ctx:               ctx,
+		sharedState:       state.New(),
+		proofGenerationCh: make(chan *proofProducer.ProofWithHeader, 1),
+	}
+
+	if p.rpc, err = newRPCClient(ctx, cfg, nil); err != nil {
+		return err
+	}
+
+	tiers, err := p.rpc.GetTiers(ctx)
+	if err != nil {
+		return err
+	}
+	p.sharedState.SetTiers(tiers)
+
+	if p.txmgr, err = txmgr.NewSimpleTxManager(
+		"prover",
+		log.Root(),
+		&metrics.TxMgrMetrics,
+		*cfg.TxmgrConfigs,
+	); err != nil {
+		return err
+	}
+
+	txBuilder := transaction.NewProveBlockTxBuilder(
+		p.rpc, p.cfg.TaikoL1Address,
+		p.cfg.GuardianProverMajorityAddress,
+		p.cfg.GuardianProverMinorityAddress,
+	)
+	if p.proofSubmitters, err = p.newProofSubmitters(p.txmgr, txBuilder, tiers); err != nil {
+		return err
+	}
+	p.proofContester = proofSubmitter.NewProofContester(
+		p.rpc,
+		p.cfg.ProveBlockGasLimit,
+		p.txmgr,
+		p.cfg.Graffiti,
+		txBuilder,
+	)
+
+	b.prover = p
+	return nil
+}
+
+// Name returns the application name.
+func (b *BlockProver) Name() string {
+	return "prove"
+}
+
+// Run proves, or contests, the given L2 block, and returns the result.
+func (b *BlockProver) Run(ctx context.Context) (interface{}, error) {
+	p := b.prover
+
+	blockInfo, err := p.rpc.GetL2BlockInfo(ctx, b.blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 block info (id: %d): %w", b.blockID, err)
+	}
+	e, err := handler.GetBlockProposedEventFromBlockID(
+		ctx,
+		p.rpc,
+		b.blockID,
+		new(big.Int).SetUint64(blockInfo.ProposedIn),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	proofStatus, err := rpc.GetBlockProofStatus(ctx, p.rpc, b.blockID, p.ProverAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProveBlockResult{
+		BlockID:        b.blockID.Uint64(),
+		ProposedIn:     blockInfo.ProposedIn,
+		AssignedProver: e.AssignedProver,
+		MinTier:        e.Meta.MinTier,
+		ProofStatus:    newBlockProofStatus(proofStatus),
+		Contest:        b.contest,
+		DryRun:         b.dryRun,
+	}
+
+	if b.contest {
+		err = b.contestBlock(ctx, e, proofStatus, result)
+	} else {
+		err = b.proveBlock(ctx, e, result)
+	}
+	if err != nil || !result.Sent {
+		return result, err
+	}
+
+	if proofStatus, err = rpc.GetBlockProofStatus(ctx, p.rpc, b.blockID, p.ProverAddress()); err != nil {
+		return result, err
+	}
+	result.NewProofStatus = newBlockProofStatus(proofStatus)
+
+	return result, nil
+}
+
+// proveBlock generates a proof for the given block, and sends it if it is not a dry run.
+func (b *BlockProver) proveBlock(
+	ctx context.Context,
+	e *bindings.TaikoL1ClientBlockProposed,
+	result *ProveBlockResult,
+) error {
+	p := b.prover
+
+	var submitter proofSubmitter.Submitter
+	if b.tier != 0 {
+		submitter = p.getSubmitterByTier(b.tier)
+	} else {
+		submitter = p.selectSubmitter(p.proofTier(e.Meta.MinTier))
+	}
+	if submitter == nil {
+		return fmt.Errorf("no proof submitter found, tier: %d, minTier: %d", b.tier, e.Meta.MinTier)
+	}
+	result.Tier = submitter.Tier()
+
+	log.Info("Generate block proof", "blockID", b.blockID, "tier", submitter.Tier())
+
+	start := time.Now()
+	if err := submitter.RequestProof(ctx, e); err != nil {
+		return err
+	}
+	proofWithHeader := <-p.proofGenerationCh
+
+	result.BlockHash = proofWithHeader.Opts.BlockHash
+	result.StateRoot = proofWithHeader.Opts.StateRoot
+	result.Proof = proofWithHeader.Proof
+	result.GenerationTime = time.Since(start).String()
+
+	if b.dryRun {
+		return nil
+	}
+
+	if err := submitter.SubmitProof(ctx, proofWithHeader); err != nil {
+		return err
+	}
+	result.Sent = true
+
+	return nil
+}
+
+// contestBlock contests the invalid proof submitted for the given block, and sends the contest if it is not
+// a dry run.
+func (b *BlockProver) contestBlock(
+	ctx context.Context,
+	e *bindings.TaikoL1ClientBlockProposed,
+	proofStatus *rpc.BlockProofStatus,
+	result *ProveBlockResult,
+) error {
+	if !proofStatus.IsSubmitted || !proofStatus.Invalid {
+		return errors.New("no invalid proof submitted for the block")
+	}
+	result.Tier = proofStatus.CurrentTransitionState.Tier
+
+	if b.dryRun {
+		return nil
+	}
+
+	if err := b.prover.proofContester.SubmitContest(
+		ctx,
+		b.blockID,
+		new(big.Int).SetUint64(result.ProposedIn),
+		proofStatus.ParentHeader.Hash(),
+		&e.Meta,
+		proofStatus.CurrentTransitionState.Tier,
+	); err != nil {
+		return err
+	}
+	result.Sent = true
+
+	return nil
+}
+
+// newBlockProofStatus creates a new BlockProofStatus from the given on-chain proof status.
+func newBlockProofStatus(proofStatus *rpc.BlockProofStatus) *BlockProofStatus {
+	status := &BlockProofStatus{IsSubmitted: proofStatus.IsSubmitted, Invalid: proofStatus.Invalid}
+	if ts := proofStatus.CurrentTransitionState; ts != nil {
+		status.Tier = ts.Tier
+		status.Prover = ts.Prover
+		status.Contester = ts.Contester
+		status.BlockHash = ts.BlockHash
+		status.StateRoot = ts.StateRoot
+	}
+
+	return status
+}
@@ -0,0 +1,29 @@
+package prover
+
+import (
+	"context"
+)
+
+func (s *ProverTestSuite) TestBlockProverDryRun() {
+	e := s.ProposeAndInsertValidBlock(s.proposer, s.d.ChainSyncer().BlobSyncer())
+	b := &BlockProver{prover: s.p, blockID: e.BlockId, dryRun: true}
+	s.Equal("prove", b.Name())
+
+	res, err := b.Run(context.Background())
+	s.Nil(err)
+	result, ok := res.(*ProveBlockResult)
+	s.True(ok)
+	s.Equal(e.BlockId.Uint64(), result.BlockID)
+	s.Equal(e.Raw.BlockNumber, result.ProposedIn)
+	s.Equal(e.AssignedProver, result.AssignedProver)
+	s.Equal(e.Meta.MinTier, result.Tier)
+	s.False(result.ProofStatus.IsSubmitted)
+	s.NotEmpty(result.Proof)
+	s.False(result.Sent)
+	s.Nil(result.NewProofStatus)
+
+	// There is no invalid proof to contest.
+	b.contest = true
+	_, err = b.Run(context.Background())
+	s.ErrorContains(err, "no invalid proof submitted")
+}
@@ -0,0 +1,153 @@
+package blockverifier
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// protocolState is the part of the protocol states used to find the verifiable blocks.
+type protocolState struct {
+	numBlocks           uint64
+	lastVerifiedBlockID uint64
+	lastUnpausedAt      uint64
+}
+
+// protocolReader reads the protocol states and transitions from TaikoL1.
+type protocolReader interface {
+	state(ctx context.Context) (*protocolState, error)
+	// verifiedBlockHash returns the block hash of the verified transition of the given block.
+	verifiedBlockHash(ctx context.Context, blockID uint64) (common.Hash, error)
+	// transition returns the transition of the given block with the given parent hash, or nil if not proved.
+	transition(ctx context.Context, blockID uint64, parentHash common.Hash) (*bindings.TaikoDataTransitionState, error)
+}
+
+// rpcProtocolReader is a protocolReader reading from the TaikoL1 contract.
+type rpcProtocolReader struct {
+	rpc *rpc.Client
+}
+
+// state implements the protocolReader interface.
+func (r *rpcProtocolReader) state(ctx context.Context) (*protocolState, error) {
+	stateVars, err := r.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocolState{
+		numBlocks:           stateVars.B.NumBlocks,
+		lastVerifiedBlockID: stateVars.B.LastVerifiedBlockId,
+		lastUnpausedAt:      stateVars.B.LastUnpausedAt,
+	}, nil
+}
+
+// verifiedBlockHash implements the protocolReader interface.
+func (r *rpcProtocolReader) verifiedBlockHash(ctx context.Context, blockID uint64) (common.Hash, error) {
+	block, err := r.rpc.GetL2BlockInfo(ctx, new(big.Int).SetUint64(blockID))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	ts, err := r.rpc.GetTransition(ctx, new(big.Int).SetUint64(blockID), block.VerifiedTransitionId)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return ts.BlockHash, nil
+}
+
+// transition implements the protocolReader interface.
+func (r *rpcProtocolReader) transition(
+	ctx context.Context,
+	blockID uint64,
+	parentHash common.Hash,
+) (*bindings.TaikoDataTransitionState, error) {
+	ts, err := r.rpc.TaikoL1.GetTransition0(&bind.CallOpts{Context: ctx}, blockID, parentHash)
+	if err != nil {
+		if strings.Contains(encoding.TryParsingCustomError(err).Error(), "L1_TRANSITION_NOT_FOUND") {
+			return nil, nil
+		}
+		return nil, encoding.TryParsingCustomError(err)
+	}
+
+	return &ts, nil
+}
+
+// scanResult is the result of scanning the verifiable blocks.
+type scanResult struct {
+	lastVerifiedBlockID uint64
+	// Number of the blocks which can be verified now.
+	verifiable uint64
+	// Time since when the first verifiable block can be verified.
+	verifiableSince time.Time
+}
+
+// scan counts the blocks after the last verified one which TaikoL1 would verify now, up to the given
+// maximum. Same as TaikoL1, it follows the chain of the transitions from the last verified block hash, and
+// stops at the first block without such a transition, with a contested one, or with one still in its
+// cooldown window, which starts from the proof, or from the last unpause if later.
+func scan(
+	ctx context.Context,
+	reader protocolReader,
+	tiers []*rpc.TierProviderTierWithID,
+	now time.Time,
+	maxBlocks uint64,
+) (*scanResult, error) {
+	state, err := reader.state(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &scanResult{lastVerifiedBlockID: state.lastVerifiedBlockID}
+
+	parentHash, err := reader.verifiedBlockHash(ctx, state.lastVerifiedBlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	for blockID := state.lastVerifiedBlockID + 1; blockID < state.numBlocks; blockID++ {
+		if result.verifiable >= maxBlocks {
+			break
+		}
+
+		ts, err := reader.transition(ctx, blockID, parentHash)
+		if err != nil {
+			return nil, err
+		}
+		if ts == nil || ts.Contester != rpc.ZeroAddress {
+			break
+		}
+		cooldownWindow, ok := cooldownWindowOf(tiers, ts.Tier)
+		if !ok {
+			break
+		}
+		readyAt := time.Unix(int64(max(ts.Timestamp, state.lastUnpausedAt)), 0).Add(cooldownWindow)
+		if now.Before(readyAt) {
+			break
+		}
+
+		if result.verifiable == 0 {
+			result.verifiableSince = readyAt
+		}
+		result.verifiable++
+		parentHash = ts.BlockHash
+	}
+
+	return result, nil
+}
+
+// cooldownWindowOf returns the cooldown window of the given tier.
+func cooldownWindowOf(tiers []*rpc.TierProviderTierWithID, tier uint16) (time.Duration, bool) {
+	for _, tierWithID := range tiers {
+		if tierWithID.ID == tier {
+			return time.Duration(tierWithID.CooldownWindow.Uint64()) * time.Minute, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,120 @@
+package blockverifier
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// testProtocolReader is a protocolReader of the given protocol states and transitions.
+type testProtocolReader struct {
+	protocolState
+	transitions map[uint64]*bindings.TaikoDataTransitionState
+}
+
+func (r *testProtocolReader) state(_ context.Context) (*protocolState, error) {
+	return &r.protocolState, nil
+}
+
+func (r *testProtocolReader) verifiedBlockHash(_ context.Context, blockID uint64) (common.Hash, error) {
+	return blockHash(blockID), nil
+}
+
+func (r *testProtocolReader) transition(
+	_ context.Context,
+	blockID uint64,
+	parentHash common.Hash,
+) (*bindings.TaikoDataTransitionState, error) {
+	if ts, ok := r.transitions[blockID]; ok && parentHash == blockHash(blockID-1) {
+		return ts, nil
+	}
+	return nil, nil
+}
+
+func blockHash(blockID uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(blockID + 1))
+}
+
+type ScannerTestSuite struct {
+	suite.Suite
+	reader *testProtocolReader
+	tiers  []*rpc.TierProviderTierWithID
+	now    time.Time
+}
+
+func (s *ScannerTestSuite) SetupTest() {
+	s.now = time.Unix(1_700_000_000, 0)
+	s.tiers = []*rpc.TierProviderTierWithID{
+		{ID: 100, ITierProviderTier: bindings.ITierProviderTier{CooldownWindow: big.NewInt(60)}},
+		{ID: 200, ITierProviderTier: bindings.ITierProviderTier{CooldownWindow: big.NewInt(240)}},
+	}
+	s.reader = &testProtocolReader{
+		protocolState: protocolState{numBlocks: 10, lastVerifiedBlockID: 3},
+		transitions:   make(map[uint64]*bindings.TaikoDataTransitionState),
+	}
+	// Blocks 4 to 8 are all proved with tier 100 two hours ago.
+	for blockID := uint64(4); blockID <= 8; blockID++ {
+		s.reader.transitions[blockID] = &bindings.TaikoDataTransitionState{
+			BlockHash: blockHash(blockID),
+			Tier:      100,
+			Timestamp: uint64(s.now.Add(-2 * time.Hour).Unix()),
+		}
+	}
+}
+
+func (s *ScannerTestSuite) scan(maxBlocks uint64) *scanResult {
+	result, err := scan(context.Background(), s.reader, s.tiers, s.now, maxBlocks)
+	s.Nil(err)
+	return result
+}
+
+func (s *ScannerTestSuite) TestVerifiable() {
+	result := s.scan(16)
+	s.Equal(uint64(3), result.lastVerifiedBlockID)
+	s.Equal(uint64(5), result.verifiable)
+	s.Equal(s.now.Add(-1*time.Hour), result.verifiableSince)
+
+	s.Equal(uint64(2), s.scan(2).verifiable)
+}
+
+func (s *ScannerTestSuite) TestStop() {
+	// In the cooldown window of a higher tier.
+	s.reader.transitions[7].Tier = 200
+	s.Equal(uint64(3), s.scan(16).verifiable)
+
+	// Contested.
+	s.reader.transitions[6].Contester = common.HexToAddress("0x01")
+	s.Equal(uint64(2), s.scan(16).verifiable)
+
+	// Unknown tier.
+	s.reader.transitions[5].Tier = 300
+	s.Equal(uint64(1), s.scan(16).verifiable)
+
+	// Proved with another parent.
+	s.reader.transitions[4].BlockHash = common.HexToHash("0x01")
+	s.Equal(uint64(1), s.scan(16).verifiable)
+	delete(s.reader.transitions, 4)
+	s.Zero(s.scan(16).verifiable)
+}
+
+func (s *ScannerTestSuite) TestUnpaused() {
+	// The cooldown windows restart once the protocol is unpaused.
+	s.reader.lastUnpausedAt = uint64(s.now.Add(-30 * time.Minute).Unix())
+	s.Zero(s.scan(16).verifiable)
+
+	s.reader.lastUnpausedAt = uint64(s.now.Add(-90 * time.Minute).Unix())
+	result := s.scan(16)
+	s.Equal(uint64(5), result.verifiable)
+	s.Equal(s.now.Add(-30*time.Minute), result.verifiableSince)
+}
+
+func TestScannerTestSuite(t *testing.T) {
+	suite.Run(t, new(ScannerTestSuite))
+}
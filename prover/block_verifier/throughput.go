@@ -0,0 +1,51 @@
+package blockverifier
+
+import (
+	"time"
+)
+
+// verification is a sent TaikoL1.verifyBlocks transaction.
+type verification struct {
+	time    time.Time
+	blocks  uint64
+	gasUsed uint64
+}
+
+// throughput keeps the verifications within a sliding window, to report the verification throughput.
+type throughput struct {
+	window        time.Duration
+	verifications []*verification
+}
+
+// newThroughput creates a new throughput with the given sliding window.
+func newThroughput(window time.Duration) *throughput {
+	return &throughput{window: window}
+}
+
+// add records a verification.
+func (t *throughput) add(v *verification) {
+	t.verifications = append(t.verifications, v)
+}
+
+// blocksPerHour returns the number of blocks verified per hour within the window, and the average gas used
+// for each of them.
+func (t *throughput) blocksPerHour(now time.Time) (float64, uint64) {
+	var kept []*verification
+	for _, v := range t.verifications {
+		if now.Sub(v.time) <= t.window {
+			kept = append(kept, v)
+		}
+	}
+	t.verifications = kept
+
+	var blocks, gasUsed uint64
+	for _, v := range t.verifications {
+		blocks += v.blocks
+		gasUsed += v.gasUsed
+	}
+	if blocks == 0 {
+		return 0, 0
+	}
+
+	return float64(blocks) / t.window.Hours(), gasUsed / blocks
+}
@@ -0,0 +1,27 @@
+package blockverifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThroughput(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	throughput := newThroughput(30 * time.Minute)
+
+	blocksPerHour, gasPerBlock := throughput.blocksPerHour(now)
+	require.Zero(t, blocksPerHour)
+	require.Zero(t, gasPerBlock)
+
+	throughput.add(&verification{time: now.Add(-40 * time.Minute), blocks: 8, gasUsed: 800_000})
+	throughput.add(&verification{time: now.Add(-20 * time.Minute), blocks: 4, gasUsed: 400_000})
+	throughput.add(&verification{time: now, blocks: 2, gasUsed: 300_000})
+
+	// The verifications out of the window are dropped.
+	blocksPerHour, gasPerBlock = throughput.blocksPerHour(now)
+	require.Equal(t, float64(12), blocksPerHour)
+	require.Equal(t, uint64(116_666), gasPerBlock)
+	require.Len(t, throughput.verifications, 2)
+}
@@ -0,0 +1,272 @@
+package blockverifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	pkgFlags "github.com/taikoxyz/taiko-client/pkg/flags"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+var (
+	// throughputWindow is the sliding window of the reported verification throughput.
+	throughputWindow = 1 * time.Hour
+)
+
+// BlockVerifier sends TaikoL1.verifyBlocks transactions, once enough proved blocks have passed their
+// cooldown windows, so that the blocks are verified, and the bonds are returned, even if no block is
+// proposed or proved for a while.
+type BlockVerifier struct {
+	rpc            *rpc.Client
+	txmgr          *txmgr.SimpleTxManager
+	reader         protocolReader
+	pauseWatcher   *rpc.PauseWatcher
+	configWatcher  *rpc.ConfigWatcher
+	throughput     *throughput
+	taikoL1Address common.Address
+
+	checkInterval time.Duration
+	minBlocks     uint64
+	maxBlocks     uint64
+	maxDelay      time.Duration
+	gasBudget     uint64
+	retryInterval time.Duration
+	maxRetries    uint64
+
+	ctx context.Context
+	wg  sync.WaitGroup
+}
+
+// InitFromCli initializes the given block verifier instance based on the command line flags.
+func (v *BlockVerifier) InitFromCli(ctx context.Context, c *cli.Context) (err error) {
+	v.ctx = ctx
+	v.taikoL1Address = common.HexToAddress(c.String(flags.TaikoL1Address.Name))
+	v.checkInterval = c.Duration(flags.VerifierCheckInterval.Name)
+	v.minBlocks = c.Uint64(flags.VerifierMinBlocks.Name)
+	v.maxBlocks = c.Uint64(flags.VerifierMaxBlocks.Name)
+	v.maxDelay = c.Duration(flags.VerifierMaxDelay.Name)
+	v.gasBudget = c.Uint64(flags.VerifierGasBudget.Name)
+	v.retryInterval = c.Duration(flags.BackOffRetryInterval.Name)
+	v.maxRetries = c.Uint64(flags.BackOffMaxRetries.Name)
+	v.throughput = newThroughput(throughputWindow)
+
+	if v.minBlocks == 0 || v.maxBlocks < v.minBlocks {
+		return fmt.Errorf("invalid verifier blocks range: [%d, %d]", v.minBlocks, v.maxBlocks)
+	}
+
+	privKey, err := crypto.ToECDSA(common.FromHex(c.String(flags.L1VerifierPrivKey.Name)))
+	if err != nil {
+		return fmt.Errorf("invalid L1 verifier private key: %w", err)
+	}
+	if v.txmgr, err = txmgr.NewSimpleTxManager(
+		"verifier",
+		log.Root(),
+		&metrics.TxMgrMetrics,
+		*pkgFlags.InitTxmgrConfigsFromCli(c.String(flags.L1HTTPEndpoint.Name), privKey, c),
+	); err != nil {
+		return err
+	}
+
+	if v.rpc, err = rpc.NewClient(ctx, &rpc.ClientConfig{
+		L1Endpoint:     c.String(flags.L1WSEndpoint.Name),
+		L2Endpoint:     c.String(flags.L2WSEndpoint.Name),
+		TaikoL1Address: v.taikoL1Address,
+		TaikoL2Address: common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+		Timeout:        c.Duration(flags.RPCTimeout.Name),
+	}); err != nil {
+		return err
+	}
+	v.reader = &rpcProtocolReader{rpc: v.rpc}
+
+	v.pauseWatcher = rpc.NewPauseWatcher(v.rpc, nil)
+	if err := v.pauseWatcher.Init(ctx); err != nil {
+		return err
+	}
+	v.configWatcher = rpc.NewConfigWatcher(v.rpc, nil)
+	return v.configWatcher.Init(ctx)
+}
+
+// Name returns the application name.
+func (v *BlockVerifier) Name() string {
+	return "verifier"
+}
+
+// Start starts the main loop of the block verifier.
+func (v *BlockVerifier) Start() error {
+	v.pauseWatcher.Start(v.ctx)
+	v.configWatcher.Start(v.ctx)
+
+	v.wg.Add(1)
+	go v.eventLoop()
+
+	return nil
+}
+
+// Close waits until the main loop exits.
+func (v *BlockVerifier) Close(_ context.Context) {
+	v.wg.Wait()
+	v.pauseWatcher.Wait()
+	v.configWatcher.Wait()
+	v.txmgr.Close()
+}
+
+// eventLoop starts the main loop of the block verifier.
+func (v *BlockVerifier) eventLoop() {
+	defer v.wg.Done()
+
+	ticker := time.NewTicker(v.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-v.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := backoff.Retry(v.check, backoff.WithContext(
+				backoff.WithMaxRetries(backoff.NewConstantBackOff(v.retryInterval), v.maxRetries),
+				v.ctx,
+			)); err != nil {
+				log.Error("Failed to verify blocks", "error", err)
+			}
+		}
+	}
+}
+
+// check verifies the verifiable blocks, if there are enough of them, or the oldest one has been
+// verifiable for too long.
+func (v *BlockVerifier) check() error {
+	// No block is verified while the protocol or the proving is paused.
+	if !v.pauseWatcher.CanProve() {
+		log.Debug("Protocol paused, skip verifying blocks")
+		return nil
+	}
+
+	now := time.Now()
+	result, err := scan(v.ctx, v.reader, v.configWatcher.Params().Tiers, now, v.maxBlocks)
+	if err != nil {
+		return err
+	}
+	metrics.VerifierVerifiableBlocksGauge.Set(float64(result.verifiable))
+
+	if result.verifiable == 0 {
+		return nil
+	}
+	if result.verifiable < v.minBlocks && now.Sub(result.verifiableSince) < v.maxDelay {
+		log.Debug(
+			"Not enough verifiable blocks",
+			"lastVerifiedBlockID", result.lastVerifiedBlockID,
+			"verifiable", result.verifiable,
+			"verifiableSince", result.verifiableSince,
+		)
+		return nil
+	}
+
+	count, gas, err := v.fitGasBudget(result.verifiable)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		log.Warn("Verifying a single block exceeds the gas budget", "gasBudget", v.gasBudget)
+		return nil
+	}
+
+	return v.verify(result.lastVerifiedBlockID, count, gas)
+}
+
+// fitGasBudget returns the number of the blocks, no more than the given one, which can be verified within
+// the gas budget, and the estimated gas of verifying them.
+func (v *BlockVerifier) fitGasBudget(count uint64) (uint64, uint64, error) {
+	for count > 0 {
+		data, err := encoding.TaikoL1ABI.Pack("verifyBlocks", count)
+		if err != nil {
+			return 0, 0, err
+		}
+		gas, err := v.rpc.L1.EstimateGas(v.ctx, ethereum.CallMsg{
+			From: v.txmgr.From(),
+			To:   &v.taikoL1Address,
+			Data: data,
+		})
+		if err != nil {
+			return 0, 0, encoding.TryParsingCustomError(err)
+		}
+		if gas <= v.gasBudget {
+			return count, gas, nil
+		}
+
+		// The gas is roughly proportional to the number of the verified blocks.
+		count = min(count-1, count*v.gasBudget/gas)
+	}
+
+	return 0, 0, nil
+}
+
+// verify sends a TaikoL1.verifyBlocks transaction to verify the given number of blocks, and reports the
+// verification throughput.
+func (v *BlockVerifier) verify(lastVerifiedBlockID uint64, count uint64, gas uint64) error {
+	data, err := encoding.TaikoL1ABI.Pack("verifyBlocks", count)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Verify blocks", "lastVerifiedBlockID", lastVerifiedBlockID, "count", count, "estimatedGas", gas)
+
+	receipt, err := v.txmgr.Send(v.ctx, txmgr.TxCandidate{
+		TxData:   data,
+		To:       &v.taikoL1Address,
+		GasLimit: min(gas+gas/5, v.gasBudget),
+	})
+	if err != nil {
+		metrics.VerifierTxCounter.WithLabelValues("failed").Inc()
+		return encoding.TryParsingCustomError(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		metrics.VerifierTxCounter.WithLabelValues("reverted").Inc()
+		metrics.VerifierGasUsedCounter.Add(float64(receipt.GasUsed))
+		return fmt.Errorf("failed to verify blocks: %s", receipt.TxHash.Hex())
+	}
+
+	// Some blocks may have been verified by other transactions in the meantime.
+	var verified uint64
+	for _, l := range receipt.Logs {
+		if l.Address != v.taikoL1Address {
+			continue
+		}
+		if _, err := v.rpc.TaikoL1.ParseBlockVerified(*l); err == nil {
+			verified++
+		}
+	}
+
+	metrics.VerifierTxCounter.WithLabelValues("success").Inc()
+	metrics.VerifierVerifiedBlocksCounter.Add(float64(verified))
+	metrics.VerifierGasUsedCounter.Add(float64(receipt.GasUsed))
+
+	now := time.Now()
+	v.throughput.add(&verification{time: now, blocks: verified, gasUsed: receipt.GasUsed})
+	blocksPerHour, gasPerBlock := v.throughput.blocksPerHour(now)
+	metrics.VerifierBlocksPerHourGauge.Set(blocksPerHour)
+
+	log.Info(
+		"Blocks verified",
+		"txHash", receipt.TxHash,
+		"verified", verified,
+		"gasUsed", receipt.GasUsed,
+		"blocksPerHour", blocksPerHour,
+		"gasPerBlock", gasPerBlock,
+	)
+
+	return nil
+}
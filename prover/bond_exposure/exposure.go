@@ -0,0 +1,359 @@
+package exposure
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/internal/utils"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+var (
+	// metricsInterval is the interval of updating the exposure metrics, since the number of outstanding
+	// assignments changes without any on-chain event.
+	metricsInterval = 12 * time.Second
+	// maxConcurrentBlockQueries is the maximum number of the concurrent block queries when initializing
+	// the tracker.
+	maxConcurrentBlockQueries = 16
+)
+
+// lockedBond is a liveness bond locked in an unproven block.
+type lockedBond struct {
+	prover common.Address
+	bond   *big.Int
+}
+
+// Tracker tracks the liveness bonds each prover identity has at risk, which are the bonds locked in the
+// unproven blocks assigned to the identity, and the bonds promised in its outstanding assignments.
+type Tracker struct {
+	rpc                *rpc.Client
+	chain              string
+	proverAddresses    []common.Address
+	livenessBond       *big.Int
+	maxExposureRatio   float64
+	pendingAssignments func(prover common.Address) int
+	locked             map[uint64]*lockedBond
+	released           map[uint64]*lockedBond
+	mutex              sync.RWMutex
+	wg                 sync.WaitGroup
+}
+
+// New creates a new Tracker instance. A new assignment will be refused once the exposure would exceed
+// the given ratio of the bond capital, zero means no limit. The pendingAssignments function returns the
+// number of signed assignments of a prover identity which have not been used or expired yet. The metrics
+// are labelled with the given L2 chain ID.
+func New(
+	rpc *rpc.Client,
+	chain string,
+	proverAddresses []common.Address,
+	livenessBond *big.Int,
+	maxExposureRatio float64,
+	pendingAssignments func(prover common.Address) int,
+) *Tracker {
+	return &Tracker{
+		rpc:                rpc,
+		chain:              chain,
+		proverAddresses:    proverAddresses,
+		livenessBond:       livenessBond,
+		maxExposureRatio:   maxExposureRatio,
+		pendingAssignments: pendingAssignments,
+		locked:             make(map[uint64]*lockedBond),
+		released:           make(map[uint64]*lockedBond),
+	}
+}
+
+// Init loads the liveness bonds locked in the unverified and unproven blocks assigned to the prover identities.
+// Nothing is loaded when there is no exposure limit.
+func (t *Tracker) Init(ctx context.Context) error {
+	if !t.enabled() {
+		return nil
+	}
+
+	stateVars, err := t.rpc.GetProtocolStateVariables(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("failed to get protocol state variables: %w", err)
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentBlockQueries)
+	for id := stateVars.B.LastVerifiedBlockId + 1; id < stateVars.B.NumBlocks; id++ {
+		id := id
+		g.Go(func() error {
+			block, err := t.rpc.TaikoL1.GetBlock(&bind.CallOpts{Context: gCtx}, id)
+			if err != nil {
+				return fmt.Errorf("failed to get block %d: %w", id, err)
+			}
+
+			// A block without any transition has not been proven yet.
+			if slices.Contains(t.proverAddresses, block.AssignedProver) && block.NextTransitionId <= 1 {
+				t.Lock(new(big.Int).SetUint64(id), block.AssignedProver, block.LivenessBond)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	for _, prover := range t.proverAddresses {
+		locked, _ := t.Exposure(prover)
+		log.Info(
+			"Liveness bond exposure initialized",
+			"prover", prover,
+			"locked", utils.WeiToEther(locked),
+			"blocks", t.LockedBlocks(prover),
+		)
+	}
+
+	return nil
+}
+
+// Start starts the loop which tracks the liveness bonds with the on-chain events, the loop
+// will be stopped when the given context is done. Nothing is tracked when there is no exposure limit.
+func (t *Tracker) Start(ctx context.Context) {
+	if !t.enabled() {
+		return
+	}
+
+	t.wg.Add(1)
+	go t.loop(ctx)
+}
+
+// Wait waits until the tracking loop exits.
+func (t *Tracker) Wait() {
+	t.wg.Wait()
+}
+
+// loop is the main loop of the tracker.
+func (t *Tracker) loop(ctx context.Context) {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(metricsInterval)
+	defer ticker.Stop()
+
+	blockProposedCh := make(chan *bindings.TaikoL1ClientBlockProposed, 128)
+	transitionProvedCh := make(chan *bindings.TaikoL1ClientTransitionProved, 128)
+	blockVerifiedCh := make(chan *bindings.TaikoL1ClientBlockVerified, 128)
+	blockProposedSub := rpc.SubscribeBlockProposed(t.rpc.TaikoL1, blockProposedCh)
+	transitionProvedSub := rpc.SubscribeTransitionProved(t.rpc.TaikoL1, transitionProvedCh)
+	blockVerifiedSub := rpc.SubscribeBlockVerified(t.rpc.TaikoL1, blockVerifiedCh)
+	defer func() {
+		blockProposedSub.Unsubscribe()
+		transitionProvedSub.Unsubscribe()
+		blockVerifiedSub.Unsubscribe()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-blockProposedCh:
+			if !slices.Contains(t.proverAddresses, e.AssignedProver) {
+				continue
+			}
+			// The block reorged out has never locked any bond.
+			if e.Raw.Removed {
+				t.Unlock(e.BlockId)
+			} else {
+				t.Lock(e.BlockId, e.AssignedProver, e.LivenessBond)
+			}
+		case e := <-transitionProvedCh:
+			// The bond released by a transition reorged out is locked again.
+			if e.Raw.Removed {
+				t.Restore(e.BlockId)
+			} else {
+				t.Release(e.BlockId)
+			}
+		case e := <-blockVerifiedCh:
+			if !e.Raw.Removed {
+				t.ReleaseUntil(e.BlockId)
+			}
+		case <-ticker.C:
+			t.updateMetrics()
+		}
+	}
+}
+
+// Lock records the liveness bond locked in the given block assigned to the given prover identity.
+func (t *Tracker) Lock(blockID *big.Int, prover common.Address, bond *big.Int) {
+	t.mutex.Lock()
+	t.locked[blockID.Uint64()] = &lockedBond{prover: prover, bond: new(big.Int).Set(bond)}
+	t.mutex.Unlock()
+
+	t.updateMetrics()
+}
+
+// Release releases the liveness bond locked in the given block, since the block has been proven.
+// The bond is either returned to the prover, or lost to the actual prover, and no longer at risk
+// in both cases.
+func (t *Tracker) Release(blockID *big.Int) {
+	t.mutex.Lock()
+	if l, ok := t.locked[blockID.Uint64()]; ok {
+		t.released[blockID.Uint64()] = l
+		delete(t.locked, blockID.Uint64())
+	}
+	t.mutex.Unlock()
+
+	t.updateMetrics()
+}
+
+// Restore locks the liveness bond released by the proof of the given block again, once the proof
+// has been reorged out.
+func (t *Tracker) Restore(blockID *big.Int) {
+	t.mutex.Lock()
+	if l, ok := t.released[blockID.Uint64()]; ok {
+		t.locked[blockID.Uint64()] = l
+		delete(t.released, blockID.Uint64())
+	}
+	t.mutex.Unlock()
+
+	t.updateMetrics()
+}
+
+// Unlock drops the liveness bond locked in the given block, once the block has been reorged out.
+func (t *Tracker) Unlock(blockID *big.Int) {
+	t.mutex.Lock()
+	delete(t.locked, blockID.Uint64())
+	delete(t.released, blockID.Uint64())
+	t.mutex.Unlock()
+
+	t.updateMetrics()
+}
+
+// ReleaseUntil releases the liveness bonds locked in all the blocks up to the given verified block.
+func (t *Tracker) ReleaseUntil(verifiedBlockID *big.Int) {
+	t.mutex.Lock()
+	for id := range t.locked {
+		if id <= verifiedBlockID.Uint64() {
+			delete(t.locked, id)
+		}
+	}
+	for id := range t.released {
+		if id <= verifiedBlockID.Uint64() {
+			delete(t.released, id)
+		}
+	}
+	t.mutex.Unlock()
+
+	t.updateMetrics()
+}
+
+// Exposure returns the liveness bonds locked in the unproven blocks assigned to the given prover identity,
+// and the liveness bonds promised in its outstanding assignments.
+func (t *Tracker) Exposure(prover common.Address) (*big.Int, *big.Int) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	locked := new(big.Int)
+	for _, l := range t.locked {
+		if l.prover == prover {
+			locked.Add(locked, l.bond)
+		}
+	}
+
+	promised := new(big.Int)
+	if t.pendingAssignments != nil {
+		promised.Mul(t.livenessBond, big.NewInt(int64(t.pendingAssignments(prover))))
+	}
+
+	return locked, promised
+}
+
+// LivenessBond returns the liveness bond of a new block.
+func (t *Tracker) LivenessBond() *big.Int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.livenessBond
+}
+
+// SetLivenessBond sets the liveness bond of a new block, once it's changed by a protocol upgrade.
+func (t *Tracker) SetLivenessBond(livenessBond *big.Int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.livenessBond = livenessBond
+}
+
+// LockedBlocks returns the number of unproven blocks assigned to the given prover identity.
+func (t *Tracker) LockedBlocks(prover common.Address) int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	count := 0
+	for _, l := range t.locked {
+		if l.prover == prover {
+			count++
+		}
+	}
+
+	return count
+}
+
+// CanAccept checks whether the given prover identity can accept one more assignment with the given token
+// balance, without the exposure exceeding the configured ratio of the bond capital. Since the locked bonds
+// have already been transferred out of the prover's wallet, the bond capital is the token balance plus
+// the locked bonds.
+func (t *Tracker) CanAccept(prover common.Address, balance *big.Int) bool {
+	if !t.enabled() {
+		return true
+	}
+
+	locked, promised := t.Exposure(prover)
+
+	exposure := new(big.Int).Add(locked, promised)
+	exposure.Add(exposure, t.LivenessBond())
+
+	capital := new(big.Float).SetInt(new(big.Int).Add(balance, locked))
+	limit, _ := new(big.Float).Mul(capital, big.NewFloat(t.maxExposureRatio)).Int(nil)
+
+	if exposure.Cmp(limit) > 0 {
+		log.Warn(
+			"Liveness bond exposure limit exceeded",
+			"prover", prover,
+			"locked", utils.WeiToEther(locked),
+			"promised", utils.WeiToEther(promised),
+			"balance", utils.WeiToEther(balance),
+			"limit", utils.WeiToEther(limit),
+		)
+		return false
+	}
+
+	return true
+}
+
+// enabled returns whether there is an exposure limit, the bonds are only tracked then.
+func (t *Tracker) enabled() bool {
+	return t.maxExposureRatio != 0
+}
+
+// updateMetrics updates the exposure metrics, with the total exposure of all prover identities.
+func (t *Tracker) updateMetrics() {
+	var (
+		locked   = new(big.Int)
+		promised = new(big.Int)
+	)
+	for _, prover := range t.proverAddresses {
+		l, p := t.Exposure(prover)
+		locked.Add(locked, l)
+		promised.Add(promised, p)
+	}
+
+	lockedEther, _ := utils.WeiToEther(locked).Float64()
+	promisedEther, _ := utils.WeiToEther(promised).Float64()
+
+	metrics.ProverBondLockedGaugeVec.WithLabelValues(t.chain).Set(lockedEther)
+	metrics.ProverBondPromisedGaugeVec.WithLabelValues(t.chain).Set(promisedEther)
+	metrics.ProverBondExposureGaugeVec.WithLabelValues(t.chain).Set(lockedEther + promisedEther)
+}
@@ -0,0 +1,133 @@
+package exposure
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type BondExposureTestSuite struct {
+	suite.Suite
+	tracker *Tracker
+	prover  common.Address
+	other   common.Address
+	pending int
+}
+
+func (s *BondExposureTestSuite) SetupTest() {
+	s.pending = 0
+	s.prover = common.BytesToAddress([]byte{1})
+	s.other = common.BytesToAddress([]byte{2})
+	s.tracker = New(
+		nil,
+		"",
+		[]common.Address{s.prover, s.other},
+		big.NewInt(10),
+		0.5,
+		func(prover common.Address) int {
+			if prover == s.prover {
+				return s.pending
+			}
+			return 0
+		},
+	)
+}
+
+func (s *BondExposureTestSuite) TestExposure() {
+	s.tracker.Lock(common.Big1, s.prover, big.NewInt(10))
+	s.tracker.Lock(common.Big2, s.prover, big.NewInt(10))
+	s.tracker.Lock(common.Big3, s.prover, big.NewInt(10))
+	s.tracker.Lock(big.NewInt(4), s.other, big.NewInt(10))
+	s.pending = 2
+
+	locked, promised := s.tracker.Exposure(s.prover)
+	s.Equal(big.NewInt(30), locked)
+	s.Equal(big.NewInt(20), promised)
+	s.Equal(3, s.tracker.LockedBlocks(s.prover))
+
+	locked, promised = s.tracker.Exposure(s.other)
+	s.Equal(big.NewInt(10), locked)
+	s.Zero(promised.Sign())
+	s.Equal(1, s.tracker.LockedBlocks(s.other))
+
+	s.tracker.Release(common.Big2)
+	locked, _ = s.tracker.Exposure(s.prover)
+	s.Equal(big.NewInt(20), locked)
+
+	s.tracker.ReleaseUntil(common.Big1)
+	locked, _ = s.tracker.Exposure(s.prover)
+	s.Equal(big.NewInt(10), locked)
+
+	s.tracker.ReleaseUntil(common.Big3)
+	locked, _ = s.tracker.Exposure(s.prover)
+	s.Zero(locked.Sign())
+	s.Equal(1, s.tracker.LockedBlocks(s.other))
+}
+
+func (s *BondExposureTestSuite) TestReorg() {
+	s.tracker.Lock(common.Big1, s.prover, big.NewInt(10))
+	s.tracker.Lock(common.Big2, s.prover, big.NewInt(10))
+
+	// The proof reorged out locks the bond again.
+	s.tracker.Release(common.Big1)
+	s.tracker.Restore(common.Big1)
+	locked, _ := s.tracker.Exposure(s.prover)
+	s.Equal(big.NewInt(20), locked)
+
+	// The block reorged out doesn't lock any bond.
+	s.tracker.Unlock(common.Big2)
+	locked, _ = s.tracker.Exposure(s.prover)
+	s.Equal(big.NewInt(10), locked)
+
+	// The bonds of the verified blocks are never locked again.
+	s.tracker.Release(common.Big1)
+	s.tracker.ReleaseUntil(common.Big1)
+	s.tracker.Restore(common.Big1)
+	s.Zero(s.tracker.LockedBlocks(s.prover))
+}
+
+func (s *BondExposureTestSuite) TestCanAccept() {
+	// Exposure: 10 locked + 10 promised + 10 for the new assignment, capital: 50 + 10 locked.
+	s.tracker.Lock(common.Big1, s.prover, big.NewInt(10))
+	s.pending = 1
+	s.True(s.tracker.CanAccept(s.prover, big.NewInt(50)))
+	s.False(s.tracker.CanAccept(s.prover, big.NewInt(49)))
+
+	s.pending = 2
+	s.False(s.tracker.CanAccept(s.prover, big.NewInt(50)))
+	s.True(s.tracker.CanAccept(s.prover, big.NewInt(70)))
+
+	// The exposure of the other identities is not counted.
+	s.True(s.tracker.CanAccept(s.other, big.NewInt(20)))
+}
+
+func (s *BondExposureTestSuite) TestSetLivenessBond() {
+	// Exposure: 10 locked + 20 promised + 20 for the new assignment, capital: 90 + 10 locked.
+	s.tracker.Lock(common.Big1, s.prover, big.NewInt(10))
+	s.pending = 1
+	s.tracker.SetLivenessBond(big.NewInt(20))
+	s.Equal(big.NewInt(20), s.tracker.LivenessBond())
+
+	_, promised := s.tracker.Exposure(s.prover)
+	s.Equal(big.NewInt(20), promised)
+	s.True(s.tracker.CanAccept(s.prover, big.NewInt(90)))
+	s.False(s.tracker.CanAccept(s.prover, big.NewInt(89)))
+}
+
+func (s *BondExposureTestSuite) TestCanAcceptNoLimit() {
+	s.tracker = New(nil, "", []common.Address{s.prover}, big.NewInt(10), 0, nil)
+	s.tracker.Lock(common.Big1, s.prover, big.NewInt(10))
+	s.True(s.tracker.CanAccept(s.prover, common.Big0))
+
+	// Nothing is loaded or tracked without a limit, so the RPC client is never used.
+	s.Nil(s.tracker.Init(context.Background()))
+	s.tracker.Start(context.Background())
+	s.tracker.Wait()
+}
+
+func TestBondExposureTestSuite(t *testing.T) {
+	suite.Run(t, new(BondExposureTestSuite))
+}
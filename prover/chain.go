@@ -0,0 +1,79 @@
+package prover
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// initChains initializes the provers of the additional L2 chains, their prover servers are routed under
+// `/chains/{chainID}` of the primary prover server.
+func (p *Prover) initChains(ctx context.Context) error {
+	// The primary chain can also be requested with its chain ID.
+	p.server.MountChain(p.rpc.L2.ChainID, p.server)
+	seen := map[string]bool{p.chainID(): true}
+
+	for _, chain := range p.cfg.Chains {
+		chainProver := &Prover{parent: p}
+		if err := InitFromConfig(ctx, chainProver, p.chainConfig(chain)); err != nil {
+			return fmt.Errorf("failed to initialize prover of chain %s: %w", chain.TaikoL1Address, err)
+		}
+		if seen[chainProver.chainID()] {
+			return fmt.Errorf("duplicate chain ID: %s", chainProver.chainID())
+		}
+		seen[chainProver.chainID()] = true
+
+		p.server.MountChain(chainProver.rpc.L2.ChainID, chainProver.server)
+		p.chains = append(p.chains, chainProver)
+
+		log.Info(
+			"L2 chain prover initialized",
+			"chainID", chainProver.chainID(),
+			"taikoL1", chain.TaikoL1Address,
+			"taikoL2", chain.TaikoL2Address,
+		)
+	}
+
+	return nil
+}
+
+// chainConfig returns the configurations of the prover of the given L2 chain, which are the same as the
+// primary chain prover's except the chain contracts and endpoints.
+func (p *Prover) chainConfig(chain *ChainConfig) *Config {
+	cfg := *p.cfg
+	cfg.L2WsEndpoint = chain.L2WsEndpoint
+	cfg.L2HttpEndpoint = chain.L2HttpEndpoint
+	cfg.TaikoL1Address = chain.TaikoL1Address
+	cfg.TaikoL2Address = chain.TaikoL2Address
+	cfg.TaikoTokenAddress = chain.TaikoTokenAddress
+	cfg.AssignmentHookAddress = chain.AssignmentHookAddress
+	cfg.RaikoL2Endpoint = chain.RaikoL2Endpoint
+	cfg.StartingBlockID = nil
+	cfg.Chains = nil
+
+	// The guardian provers are only supported on the primary chain.
+	cfg.GuardianProverMajorityAddress = common.Address{}
+	cfg.GuardianProverMinorityAddress = common.Address{}
+	cfg.GuardianProverHealthCheckServerEndpoint = nil
+
+	// The persisted states are kept per chain.
+	if cfg.SchedulerStateFile != "" {
+		cfg.SchedulerStateFile = cfg.SchedulerStateFile + "." + chain.TaikoL1Address.Hex()
+	}
+	if cfg.AssignmentLedgerStateFile != "" {
+		cfg.AssignmentLedgerStateFile = cfg.AssignmentLedgerStateFile + "." + chain.TaikoL1Address.Hex()
+	}
+	if cfg.AccountingDir != "" {
+		cfg.AccountingDir = filepath.Join(cfg.AccountingDir, chain.TaikoL1Address.Hex())
+	}
+
+	return &cfg
+}
+
+// chainID returns the ID of the L2 chain proven by the prover, used in metrics.
+func (p *Prover) chainID() string {
+	return p.rpc.L2.ChainID.String()
+}
@@ -2,10 +2,13 @@ package prover
 
 import (
 	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"net/url"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -13,8 +16,12 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
 	"github.com/taikoxyz/taiko-client/internal/utils"
+	engine "github.com/taikoxyz/taiko-client/prover/contest_engine"
+	"github.com/taikoxyz/taiko-client/prover/server"
+	gate "github.com/taikoxyz/taiko-client/prover/submission_gate"
 
 	pkgFlags "github.com/taikoxyz/taiko-client/pkg/flags"
 )
@@ -36,10 +43,14 @@ type Config struct {
 	GuardianProverMinorityAddress           common.Address
 	GuardianProverMajorityAddress           common.Address
 	GuardianProofSubmissionDelay            time.Duration
+	GuardianQuorumStuckThreshold            time.Duration
 	Graffiti                                string
 	BackOffMaxRetries                       uint64
 	BackOffRetryInterval                    time.Duration
 	ProveUnassignedBlocks                   bool
+	MaxUnassignedProofs                     uint64
+	UnassignedProofCosts                    map[uint16]*big.Int
+	UnassignedBondTokenPrice                *big.Int
 	ContesterMode                           bool
 	EnableLivenessBondProof                 bool
 	RPCTimeout                              time.Duration
@@ -56,6 +67,8 @@ type Config struct {
 	MaxBlockSlippage                        uint64
 	Allowance                               *big.Int
 	GuardianProverHealthCheckServerEndpoint *url.URL
+	GuardianOutboxFile                      string
+	GuardianOutboxSize                      int
 	RaikoHostEndpoint                       string
 	RaikoL1Endpoint                         string
 	RaikoL1BeaconEndpoint                   string
@@ -63,6 +76,21 @@ type Config struct {
 	L1NodeVersion                           string
 	L2NodeVersion                           string
 	BlockConfirmations                      uint64
+	PipelineWorkers                         uint64
+	AccountingDir                           string
+	AccountingPeriod                        time.Duration
+	Pricing                                 *server.PricingConfig
+	Auth                                    *server.AuthConfig
+	MaxBondExposureRatio                    float64
+	Contest                                 *engine.Config
+	SchedulerStateFile                      string
+	AssignmentLedgerStateFile               string
+	ProofCacheSize                          uint64
+	DropLateProofs                          bool
+	SubmissionGate                          *gate.Config
+	Identities                              []*server.Identity
+	Chains                                  []*ChainConfig
+	SgxInstanceExpiryAlert                  time.Duration
 	TxmgrConfigs                            *txmgr.CLIConfig
 }
 
@@ -158,6 +186,148 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		return nil, err
 	}
 
+	var pricing *server.PricingConfig
+	if c.Bool(flags.DynamicPricing.Name) {
+		bondTokenPrice, err := utils.EtherToWei(c.Float64(flags.PricingBondTokenPrice.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		pricing = &server.PricingConfig{
+			ProveBlockGas: c.Uint64(flags.PricingProveBlockGas.Name),
+			ExpectedL2Gas: c.Uint64(flags.PricingExpectedL2Gas.Name),
+			RaikoCostPerGas: map[uint16]*big.Int{
+				encoding.TierSgxID:        new(big.Int).SetUint64(c.Uint64(flags.PricingSgxCostPerGas.Name)),
+				encoding.TierSgxAndZkVMID: new(big.Int).SetUint64(c.Uint64(flags.PricingSgxAndZkVMCostPerGas.Name)),
+			},
+			UtilizationPremium: c.Float64(flags.PricingUtilizationPremium.Name),
+			BondCostRate:       c.Float64(flags.PricingBondCostRate.Name),
+			BondTokenPrice:     bondTokenPrice,
+			ProfitMargin:       c.Float64(flags.PricingProfitMargin.Name),
+		}
+	}
+
+	auth := &server.AuthConfig{
+		RequireSignature:          c.Bool(flags.RequireProposerSignature.Name),
+		RequestsPerMinute:         c.Uint64(flags.ProposerRequestsPerMinute.Name),
+		MaxOutstandingAssignments: c.Uint64(flags.MaxOutstandingAssignments.Name),
+	}
+	if c.IsSet(flags.AllowedProposers.Name) {
+		for _, address := range strings.Split(c.String(flags.AllowedProposers.Name), ",") {
+			if !common.IsHexAddress(strings.TrimSpace(address)) {
+				return nil, fmt.Errorf("invalid allowed proposer address: %s", address)
+			}
+			auth.AllowedProposers = append(auth.AllowedProposers, common.HexToAddress(strings.TrimSpace(address)))
+		}
+	}
+	if c.IsSet(flags.APIKeys.Name) {
+		for _, key := range strings.Split(c.String(flags.APIKeys.Name), ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				auth.APIKeys = append(auth.APIKeys, key)
+			}
+		}
+	}
+
+	maxBondExposureRatio := c.Float64(flags.MaxBondExposureRatio.Name)
+	if maxBondExposureRatio < 0 {
+		return nil, fmt.Errorf("invalid max bond exposure ratio: %v", maxBondExposureRatio)
+	}
+
+	if c.Duration(flags.AccountingPeriod.Name) <= 0 {
+		return nil, fmt.Errorf("invalid accounting period: %v", c.Duration(flags.AccountingPeriod.Name))
+	}
+
+	var submissionGate *gate.Config
+	if c.Float64(flags.SubmissionTargetBaseFee.Name) != 0 {
+		targetBaseFee, err := utils.GWeiToWei(c.Float64(flags.SubmissionTargetBaseFee.Name))
+		if err != nil {
+			return nil, err
+		}
+		submissionGate = &gate.Config{
+			TargetBaseFee: targetBaseFee,
+			SafetyMargin:  c.Duration(flags.SubmissionSafetyMargin.Name),
+		}
+	}
+
+	var chains []*ChainConfig
+	if c.IsSet(flags.ChainsFile.Name) {
+		if chains, err = loadChains(
+			c.String(flags.ChainsFile.Name),
+			common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+			common.HexToAddress(c.String(flags.TaikoTokenAddress.Name)),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	var identities []*server.Identity
+	if c.IsSet(flags.IdentitiesFile.Name) {
+		if identities, err = loadIdentities(
+			c.String(flags.IdentitiesFile.Name),
+			&server.Identity{
+				PrivateKey:           l1ProverPrivKey,
+				MinOptimisticTierFee: minOptimisticTierFee,
+				MinSgxTierFee:        minSgxTierFee,
+				MinSgxAndZkVMTierFee: minSgxAndZkVMTierFee,
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	unassignedSgxProofCost, err := utils.EtherToWei(c.Float64(flags.UnassignedSgxProofCost.Name))
+	if err != nil {
+		return nil, err
+	}
+	unassignedSgxAndZkVMProofCost, err := utils.EtherToWei(c.Float64(flags.UnassignedSgxAndZkVMProofCost.Name))
+	if err != nil {
+		return nil, err
+	}
+	unassignedBondTokenPrice, err := utils.EtherToWei(c.Float64(flags.UnassignedBondTokenPrice.Name))
+	if err != nil {
+		return nil, err
+	}
+	unassignedProofCosts := map[uint16]*big.Int{
+		encoding.TierSgxID:        unassignedSgxProofCost,
+		encoding.TierSgxAndZkVMID: unassignedSgxAndZkVMProofCost,
+	}
+
+	var contest *engine.Config
+	if c.Bool(flags.ContesterMode.Name) {
+		confidence := c.Float64(flags.ContestConfidence.Name)
+		if confidence < 0 || confidence > 1 {
+			return nil, fmt.Errorf("invalid contest confidence: %v", confidence)
+		}
+
+		sgxProofCost, err := utils.EtherToWei(c.Float64(flags.ContestSgxProofCost.Name))
+		if err != nil {
+			return nil, err
+		}
+		sgxAndZkVMProofCost, err := utils.EtherToWei(c.Float64(flags.ContestSgxAndZkVMProofCost.Name))
+		if err != nil {
+			return nil, err
+		}
+		bondTokenPrice, err := utils.EtherToWei(c.Float64(flags.ContestBondTokenPrice.Name))
+		if err != nil {
+			return nil, err
+		}
+		maxBondsAtRisk, err := utils.EtherToWei(c.Float64(flags.ContestMaxBondsAtRisk.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		contest = &engine.Config{
+			ProofCosts: map[uint16]*big.Int{
+				encoding.TierSgxID:        sgxProofCost,
+				encoding.TierSgxAndZkVMID: sgxAndZkVMProofCost,
+			},
+			BondTokenPrice:       bondTokenPrice,
+			Confidence:           confidence,
+			MaxBondsAtRisk:       maxBondsAtRisk,
+			MinCooldownRemaining: c.Duration(flags.ContestMinCooldownRemaining.Name),
+		}
+	}
+
 	return &Config{
 		L1WsEndpoint:                            c.String(flags.L1WSEndpoint.Name),
 		L1HttpEndpoint:                          c.String(flags.L1HTTPEndpoint.Name),
@@ -178,11 +348,17 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		GuardianProverMinorityAddress:           common.HexToAddress(c.String(flags.GuardianProverMinority.Name)),
 		GuardianProverMajorityAddress:           common.HexToAddress(c.String(flags.GuardianProverMajority.Name)),
 		GuardianProofSubmissionDelay:            c.Duration(flags.GuardianProofSubmissionDelay.Name),
+		GuardianQuorumStuckThreshold:            c.Duration(flags.GuardianQuorumStuckThreshold.Name),
 		GuardianProverHealthCheckServerEndpoint: guardianProverHealthCheckServerEndpoint,
+		GuardianOutboxFile:                      c.String(flags.GuardianOutboxFile.Name),
+		GuardianOutboxSize:                      c.Int(flags.GuardianOutboxSize.Name),
 		Graffiti:                                c.String(flags.Graffiti.Name),
 		BackOffMaxRetries:                       c.Uint64(flags.BackOffMaxRetries.Name),
 		BackOffRetryInterval:                    c.Duration(flags.BackOffRetryInterval.Name),
 		ProveUnassignedBlocks:                   c.Bool(flags.ProveUnassignedBlocks.Name),
+		MaxUnassignedProofs:                     c.Uint64(flags.MaxUnassignedProofs.Name),
+		UnassignedProofCosts:                    unassignedProofCosts,
+		UnassignedBondTokenPrice:                unassignedBondTokenPrice,
 		ContesterMode:                           c.Bool(flags.ContesterMode.Name),
 		EnableLivenessBondProof:                 c.Bool(flags.EnableLivenessBondProof.Name),
 		RPCTimeout:                              c.Duration(flags.RPCTimeout.Name),
@@ -201,6 +377,21 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		L1NodeVersion:                           c.String(flags.L1NodeVersion.Name),
 		L2NodeVersion:                           c.String(flags.L2NodeVersion.Name),
 		BlockConfirmations:                      c.Uint64(flags.BlockConfirmations.Name),
+		PipelineWorkers:                         c.Uint64(flags.PipelineWorkers.Name),
+		AccountingDir:                           c.String(flags.AccountingDir.Name),
+		AccountingPeriod:                        c.Duration(flags.AccountingPeriod.Name),
+		Pricing:                                 pricing,
+		Auth:                                    auth,
+		MaxBondExposureRatio:                    maxBondExposureRatio,
+		Contest:                                 contest,
+		SchedulerStateFile:                      c.String(flags.SchedulerStateFile.Name),
+		AssignmentLedgerStateFile:               c.String(flags.AssignmentLedgerStateFile.Name),
+		ProofCacheSize:                          c.Uint64(flags.ProofCacheSize.Name),
+		DropLateProofs:                          c.Bool(flags.DropLateProofs.Name),
+		SubmissionGate:                          submissionGate,
+		Identities:                              identities,
+		Chains:                                  chains,
+		SgxInstanceExpiryAlert:                  c.Duration(flags.SgxInstanceExpiryAlert.Name),
 		TxmgrConfigs: pkgFlags.InitTxmgrConfigsFromCli(
 			c.String(flags.L1HTTPEndpoint.Name),
 			l1ProverPrivKey,
@@ -208,3 +399,120 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		),
 	}, nil
 }
+
+// ChainConfig contains the configurations of an additional Taiko L2 chain proven by the prover, which
+// settles on the same L1 as the primary chain, and is proven with the same prover identities.
+type ChainConfig struct {
+	L2WsEndpoint          string         `json:"l2WsEndpoint"`
+	L2HttpEndpoint        string         `json:"l2HttpEndpoint"`
+	TaikoL1Address        common.Address `json:"taikoL1Address"`
+	TaikoL2Address        common.Address `json:"taikoL2Address"`
+	TaikoTokenAddress     common.Address `json:"taikoTokenAddress"`
+	AssignmentHookAddress common.Address `json:"assignmentHookAddress"`
+	RaikoL2Endpoint       string         `json:"raikoL2Endpoint"`
+}
+
+// loadChains loads the additional L2 chains from the given JSON file, the Taiko token not set in the file
+// defaults to the one of the primary chain, and the raiko L2 endpoint defaults to the L2 HTTP endpoint.
+func loadChains(path string, primaryTaikoL1 common.Address, primaryTaikoToken common.Address) ([]*ChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prover chains file: %w", err)
+	}
+
+	var chains []*ChainConfig
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("invalid prover chains file: %w", err)
+	}
+
+	seen := map[common.Address]bool{primaryTaikoL1: true}
+	for i, chain := range chains {
+		if chain.L2WsEndpoint == "" || chain.L2HttpEndpoint == "" {
+			return nil, fmt.Errorf("empty L2 endpoint of chain %d", i)
+		}
+		if chain.TaikoL1Address == (common.Address{}) ||
+			chain.TaikoL2Address == (common.Address{}) ||
+			chain.AssignmentHookAddress == (common.Address{}) {
+			return nil, fmt.Errorf("empty protocol contract address of chain %d", i)
+		}
+		if seen[chain.TaikoL1Address] {
+			return nil, fmt.Errorf("duplicate chain: %s", chain.TaikoL1Address)
+		}
+		seen[chain.TaikoL1Address] = true
+
+		if chain.TaikoTokenAddress == (common.Address{}) {
+			chain.TaikoTokenAddress = primaryTaikoToken
+		}
+		if chain.RaikoL2Endpoint == "" {
+			chain.RaikoL2Endpoint = chain.L2HttpEndpoint
+		}
+	}
+
+	return chains, nil
+}
+
+// identityEntry is an entry of the prover identities file, the fees are in GWei.
+type identityEntry struct {
+	PrivateKey           string   `json:"privateKey"`
+	Capacity             uint64   `json:"capacity"`
+	MinOptimisticTierFee *float64 `json:"minOptimisticTierFee"`
+	MinSgxTierFee        *float64 `json:"minSgxTierFee"`
+	MinSgxAndZkVMTierFee *float64 `json:"minSgxAndZkVMTierFee"`
+}
+
+// loadIdentities loads the additional prover identities from the given JSON file, the fees not set in the file
+// default to the ones of the primary identity.
+func loadIdentities(path string, primary *server.Identity) ([]*server.Identity, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prover identities file: %w", err)
+	}
+
+	var entries []*identityEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid prover identities file: %w", err)
+	}
+
+	var (
+		identities []*server.Identity
+		seen       = map[common.Address]bool{crypto.PubkeyToAddress(primary.PrivateKey.PublicKey): true}
+	)
+	for i, entry := range entries {
+		key, err := crypto.ToECDSA(common.FromHex(entry.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key of prover identity %d: %w", i, err)
+		}
+
+		identity := &server.Identity{
+			PrivateKey:           key,
+			Capacity:             entry.Capacity,
+			MinOptimisticTierFee: primary.MinOptimisticTierFee,
+			MinSgxTierFee:        primary.MinSgxTierFee,
+			MinSgxAndZkVMTierFee: primary.MinSgxAndZkVMTierFee,
+		}
+		if seen[identity.Address()] {
+			return nil, fmt.Errorf("duplicate prover identity: %s", identity.Address())
+		}
+		seen[identity.Address()] = true
+
+		for _, fee := range []struct {
+			gwei *float64
+			wei  **big.Int
+		}{
+			{entry.MinOptimisticTierFee, &identity.MinOptimisticTierFee},
+			{entry.MinSgxTierFee, &identity.MinSgxTierFee},
+			{entry.MinSgxAndZkVMTierFee, &identity.MinSgxAndZkVMTierFee},
+		} {
+			if fee.gwei == nil {
+				continue
+			}
+			if *fee.wei, err = utils.GWeiToWei(*fee.gwei); err != nil {
+				return nil, fmt.Errorf("invalid fee of prover identity %d: %w", i, err)
+			}
+		}
+
+		identities = append(identities, identity)
+	}
+
+	return identities, nil
+}
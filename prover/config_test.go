@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 
 	"github.com/taikoxyz/taiko-client/cmd/flags"
 	"github.com/taikoxyz/taiko-client/internal/utils"
+	"github.com/taikoxyz/taiko-client/prover/server"
 )
 
 var (
@@ -59,10 +62,24 @@ func (s *ProverTestSuite) TestNewConfigFromCliContextGuardianProver() {
 		s.Nil(new(Prover).InitFromCli(context.Background(), ctx))
 		s.True(c.ProveUnassignedBlocks)
 		s.Equal(uint64(100), c.MaxProposedIn)
+		s.Equal(uint64(2), c.MaxUnassignedProofs)
+		s.Equal(uint64(2_000_000_000_000_000), c.UnassignedBondTokenPrice.Uint64())
 		s.Equal(os.Getenv("ASSIGNMENT_HOOK_ADDRESS"), c.AssignmentHookAddress.String())
 		allowanceWithDecimal, err := utils.EtherToWei(allowance)
 		s.Nil(err)
 		s.Equal(allowanceWithDecimal.Uint64(), c.Allowance.Uint64())
+		s.Equal([]common.Address{common.HexToAddress(taikoL1), common.HexToAddress(taikoL2)}, c.Auth.AllowedProposers)
+		s.Equal([]string{"key1", "key2"}, c.Auth.APIKeys)
+		s.Equal(uint64(60), c.Auth.RequestsPerMinute)
+		s.Equal(0.5, c.MaxBondExposureRatio)
+		s.Equal("scheduler.json", c.SchedulerStateFile)
+		s.Equal("assignments.json", c.AssignmentLedgerStateFile)
+		s.Equal(uint64(256), c.ProofCacheSize)
+		s.True(c.DropLateProofs)
+		s.Equal(uint64(20_000_000_000), c.SubmissionGate.TargetBaseFee.Uint64())
+		s.Equal(30*time.Minute, c.SubmissionGate.SafetyMargin)
+		s.Equal(0.9, c.Contest.Confidence)
+		s.Equal(time.Minute, c.Contest.MinCooldownRemaining)
 
 		return err
 	}
@@ -89,11 +106,25 @@ func (s *ProverTestSuite) TestNewConfigFromCliContextGuardianProver() {
 		"--" + flags.AssignmentHookAddress.Name, os.Getenv("ASSIGNMENT_HOOK_ADDRESS"),
 		"--" + flags.Graffiti.Name, "",
 		"--" + flags.ProveUnassignedBlocks.Name,
+		"--" + flags.MaxUnassignedProofs.Name, "2",
+		"--" + flags.UnassignedBondTokenPrice.Name, "0.002",
 		"--" + flags.MaxProposedIn.Name, "100",
 		"--" + flags.Allowance.Name, fmt.Sprint(allowance),
 		"--" + flags.L1NodeVersion.Name, l1NodeVersion,
 		"--" + flags.L2NodeVersion.Name, l2NodeVersion,
 		"--" + flags.RaikoHostEndpoint.Name, "https://dummy.raiko.xyz",
+		"--" + flags.AllowedProposers.Name, taikoL1 + "," + taikoL2,
+		"--" + flags.APIKeys.Name, "key1, key2",
+		"--" + flags.ProposerRequestsPerMinute.Name, "60",
+		"--" + flags.MaxBondExposureRatio.Name, "0.5",
+		"--" + flags.SchedulerStateFile.Name, "scheduler.json",
+		"--" + flags.AssignmentLedgerStateFile.Name, "assignments.json",
+		"--" + flags.ProofCacheSize.Name, "256",
+		"--" + flags.DropLateProofs.Name,
+		"--" + flags.SubmissionTargetBaseFee.Name, "20",
+		"--" + flags.SubmissionSafetyMargin.Name, "30m",
+		"--" + flags.ContestConfidence.Name, "0.9",
+		"--" + flags.ContestMinCooldownRemaining.Name, "1m",
 	}))
 }
 
@@ -106,6 +137,97 @@ func (s *ProverTestSuite) TestNewConfigFromCliContextProverKeyError() {
 	}), "invalid L1 prover private key")
 }
 
+func (s *ProverTestSuite) TestLoadIdentities() {
+	key, err := crypto.GenerateKey()
+	s.Nil(err)
+
+	path := filepath.Join(s.T().TempDir(), "identities.json")
+	s.Nil(os.WriteFile(path, []byte(fmt.Sprintf(
+		`[{"privateKey": "%s", "capacity": 4, "minSgxTierFee": 2}]`,
+		common.Bytes2Hex(crypto.FromECDSA(key)),
+	)), 0600))
+
+	primary := &server.Identity{
+		PrivateKey:           s.p.cfg.L1ProverPrivKey,
+		MinOptimisticTierFee: common.Big1,
+		MinSgxTierFee:        common.Big1,
+		MinSgxAndZkVMTierFee: common.Big1,
+	}
+	identities, err := loadIdentities(path, primary)
+	s.Nil(err)
+	s.Len(identities, 1)
+	s.Equal(crypto.PubkeyToAddress(key.PublicKey), identities[0].Address())
+	s.Equal(uint64(4), identities[0].Capacity)
+	s.Equal(common.Big1, identities[0].MinOptimisticTierFee)
+	s.Equal(uint64(2_000_000_000), identities[0].MinSgxTierFee.Uint64())
+
+	// The primary identity can not be listed again.
+	s.Nil(os.WriteFile(path, []byte(fmt.Sprintf(
+		`[{"privateKey": "%s"}]`,
+		common.Bytes2Hex(crypto.FromECDSA(s.p.cfg.L1ProverPrivKey)),
+	)), 0600))
+	_, err = loadIdentities(path, primary)
+	s.ErrorContains(err, "duplicate prover identity")
+}
+
+func (s *ProverTestSuite) TestLoadChains() {
+	var (
+		path       = filepath.Join(s.T().TempDir(), "chains.json")
+		taikoL1    = common.BytesToAddress([]byte{1})
+		taikoToken = common.BytesToAddress([]byte{2})
+		addr       = func(b byte) string { return common.BytesToAddress([]byte{b}).Hex() }
+	)
+	s.Nil(os.WriteFile(path, []byte(fmt.Sprintf(`[
+		{
+			"l2WsEndpoint": "ws://l2a",
+			"l2HttpEndpoint": "http://l2a",
+			"taikoL1Address": "%s",
+			"taikoL2Address": "%s",
+			"assignmentHookAddress": "%s"
+		},
+		{
+			"l2WsEndpoint": "ws://l2b",
+			"l2HttpEndpoint": "http://l2b",
+			"taikoL1Address": "%s",
+			"taikoL2Address": "%s",
+			"assignmentHookAddress": "%s",
+			"taikoTokenAddress": "%s",
+			"raikoL2Endpoint": "http://raiko-l2b"
+		}
+	]`, addr(3), addr(4), addr(8), addr(5), addr(6), addr(9), addr(7))), 0600))
+
+	chains, err := loadChains(path, taikoL1, taikoToken)
+	s.Nil(err)
+	s.Len(chains, 2)
+	s.Equal(common.BytesToAddress([]byte{3}), chains[0].TaikoL1Address)
+	s.Equal(common.BytesToAddress([]byte{8}), chains[0].AssignmentHookAddress)
+	s.Equal(taikoToken, chains[0].TaikoTokenAddress)
+	s.Equal("http://l2a", chains[0].RaikoL2Endpoint)
+	s.Equal(common.BytesToAddress([]byte{7}), chains[1].TaikoTokenAddress)
+	s.Equal("http://raiko-l2b", chains[1].RaikoL2Endpoint)
+
+	// The primary chain can not be added again.
+	s.Nil(os.WriteFile(path, []byte(fmt.Sprintf(
+		`[{"l2WsEndpoint": "ws://l2a", "l2HttpEndpoint": "http://l2a", "taikoL1Address": "%s", `+
+			`"taikoL2Address": "%s", "assignmentHookAddress": "%s"}]`,
+		addr(1), addr(4), addr(8),
+	)), 0600))
+	_, err = loadChains(path, taikoL1, taikoToken)
+	s.ErrorContains(err, "duplicate chain")
+
+	s.Nil(os.WriteFile(path, []byte(fmt.Sprintf(`[{"l2WsEndpoint": "ws://l2a", "taikoL1Address": "%s"}]`, addr(3))), 0600))
+	_, err = loadChains(path, taikoL1, taikoToken)
+	s.ErrorContains(err, "empty L2 endpoint")
+
+	// Each chain has its own assignment hook.
+	s.Nil(os.WriteFile(path, []byte(fmt.Sprintf(
+		`[{"l2WsEndpoint": "ws://l2a", "l2HttpEndpoint": "http://l2a", "taikoL1Address": "%s", "taikoL2Address": "%s"}]`,
+		addr(3), addr(4),
+	)), 0600))
+	_, err = loadChains(path, taikoL1, taikoToken)
+	s.ErrorContains(err, "empty protocol contract address")
+}
+
 func (s *ProverTestSuite) SetupApp() *cli.App {
 	app := cli.NewApp()
 	app.Flags = []cli.Flag{
@@ -123,6 +245,10 @@ func (s *ProverTestSuite) SetupApp() *cli.App {
 		&cli.StringFlag{Name: flags.GuardianProverMinority.Name},
 		&cli.StringFlag{Name: flags.Graffiti.Name},
 		&cli.BoolFlag{Name: flags.ProveUnassignedBlocks.Name},
+		&cli.Uint64Flag{Name: flags.MaxUnassignedProofs.Name},
+		&cli.Float64Flag{Name: flags.UnassignedSgxProofCost.Name},
+		&cli.Float64Flag{Name: flags.UnassignedSgxAndZkVMProofCost.Name},
+		&cli.Float64Flag{Name: flags.UnassignedBondTokenPrice.Name},
 		&cli.DurationFlag{Name: flags.RPCTimeout.Name},
 		&cli.Uint64Flag{Name: flags.ProverCapacity.Name},
 		&cli.Uint64Flag{Name: flags.MinOptimisticTierFee.Name},
@@ -134,6 +260,21 @@ func (s *ProverTestSuite) SetupApp() *cli.App {
 		&cli.StringFlag{Name: flags.L1NodeVersion.Name},
 		&cli.StringFlag{Name: flags.L2NodeVersion.Name},
 		&cli.StringFlag{Name: flags.RaikoHostEndpoint.Name},
+		&cli.StringFlag{Name: flags.AllowedProposers.Name},
+		&cli.BoolFlag{Name: flags.RequireProposerSignature.Name},
+		&cli.StringFlag{Name: flags.APIKeys.Name},
+		&cli.Uint64Flag{Name: flags.ProposerRequestsPerMinute.Name},
+		&cli.Uint64Flag{Name: flags.MaxOutstandingAssignments.Name},
+		&cli.Float64Flag{Name: flags.MaxBondExposureRatio.Name},
+		&cli.StringFlag{Name: flags.SchedulerStateFile.Name},
+		&cli.StringFlag{Name: flags.AssignmentLedgerStateFile.Name},
+		&cli.Uint64Flag{Name: flags.ProofCacheSize.Name},
+		&cli.BoolFlag{Name: flags.DropLateProofs.Name},
+		&cli.Float64Flag{Name: flags.SubmissionTargetBaseFee.Name},
+		&cli.DurationFlag{Name: flags.SubmissionSafetyMargin.Name},
+		&cli.Float64Flag{Name: flags.ContestConfidence.Name},
+		&cli.DurationFlag{Name: flags.ContestMinCooldownRemaining.Name},
+		&cli.DurationFlag{Name: flags.AccountingPeriod.Name, Value: flags.AccountingPeriod.Value},
 	}
 	app.Flags = append(app.Flags, flags.TxmgrFlags...)
 	app.Action = func(ctx *cli.Context) error {
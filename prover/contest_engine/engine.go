@@ -0,0 +1,312 @@
+package engine
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// Action represents an action the contester can take on a proven or contested transition.
+type Action string
+
+// Contester actions.
+const (
+	// ActionContest means contesting the current transition.
+	ActionContest Action = "contest"
+	// ActionEscalate means submitting a higher tier proof to resolve the current contest.
+	ActionEscalate Action = "escalate"
+	// ActionAbstain means doing nothing.
+	ActionAbstain Action = "abstain"
+)
+
+// Config contains the configurations of the contest decision engine.
+type Config struct {
+	// Estimated cost in wei of generating and submitting a proof of each tier.
+	ProofCosts map[uint16]*big.Int
+	// Price of one bond token in wei, zero means the bonds are not valued in Ether, so the
+	// expected values are calculated in bond tokens, and the proof costs are ignored.
+	BondTokenPrice *big.Int
+	// Probability that the local L2 canonical chain is correct when it differs from a transition.
+	Confidence float64
+	// Maximum total bonds at risk in the ongoing contests and escalations, zero means no limit.
+	MaxBondsAtRisk *big.Int
+	// Minimum remaining cooldown window required to contest a transition.
+	MinCooldownRemaining time.Duration
+}
+
+// Situation describes the on-chain state of a transition which differs from the local L2 canonical chain.
+type Situation struct {
+	BlockID *big.Int
+	// Tier of the current transition.
+	Tier uint16
+	// Contester of the current transition, zero address if it has not been contested.
+	Contester common.Address
+	// Timestamp of the latest proof or contest of the current transition.
+	Timestamp uint64
+}
+
+// Decision represents the action decided by the engine.
+type Decision struct {
+	Action Action
+	// Minimum tier of the proof to request, only set when escalating.
+	Tier uint16
+	// Expected value of the action in wei, or in bond tokens if the bond token price is not set.
+	ExpectedValue *big.Int
+	// Bonds which will be at risk if the action is taken.
+	BondsAtRisk *big.Int
+	Reason      string
+}
+
+// Engine decides whether to contest, escalate or abstain, based on the expected value of each action,
+// with the protocol rewards: a winning contester receives a quarter of the contested validity bond,
+// and a losing contester forfeits its contest bond.
+type Engine struct {
+	cfg           *Config
+	proverAddress common.Address
+	// tiers returns the protocol proof tiers.
+	tiers func() []*rpc.TierProviderTierWithID
+	// provableTier returns the lowest tier no lower than the given tier this prover can prove.
+	provableTier func(minTier uint16) (uint16, bool)
+	reserved     map[uint64]*big.Int
+	mutex        sync.Mutex
+}
+
+// New creates a new contest decision engine instance.
+func New(
+	cfg *Config,
+	proverAddress common.Address,
+	tiers func() []*rpc.TierProviderTierWithID,
+	provableTier func(minTier uint16) (uint16, bool),
+) *Engine {
+	if cfg.BondTokenPrice == nil || cfg.BondTokenPrice.Sign() == 0 {
+		log.Warn("Bond token price not set, contest decisions will ignore the proof costs")
+	}
+
+	return &Engine{
+		cfg:           cfg,
+		proverAddress: proverAddress,
+		tiers:         tiers,
+		provableTier:  provableTier,
+		reserved:      make(map[uint64]*big.Int),
+	}
+}
+
+// Decide decides the action to take on the given transition, which differs from the local
+// L2 canonical chain. The bonds at risk are not reserved in the budget until the action is committed.
+func (e *Engine) Decide(s *Situation) *Decision {
+	d := e.decide(s)
+
+	log.Info(
+		"Contest decision",
+		"blockID", s.BlockID,
+		"action", d.Action,
+		"currentTier", s.Tier,
+		"proofTier", d.Tier,
+		"contester", s.Contester,
+		"expectedValue", d.ExpectedValue,
+		"bondsAtRisk", utils.WeiToEther(d.BondsAtRisk),
+		"reason", d.Reason,
+	)
+
+	return d
+}
+
+// decide calculates the expected values, and decides the action to take.
+func (e *Engine) decide(s *Situation) *Decision {
+	abstain := func(reason string) *Decision {
+		return &Decision{Action: ActionAbstain, ExpectedValue: common.Big0, BondsAtRisk: common.Big0, Reason: reason}
+	}
+
+	current := e.tier(s.Tier)
+	if current == nil {
+		return abstain("unknown tier")
+	}
+
+	// A transition can only be contested or escalated before its cooldown window ends.
+	deadline := s.Timestamp + current.CooldownWindow.Uint64()*60
+	remaining := time.Duration(int64(deadline)-time.Now().Unix()) * time.Second
+	if remaining < e.cfg.MinCooldownRemaining {
+		return abstain("cooldown window nearly over")
+	}
+
+	// The higher tier proof this prover can submit, and its costs.
+	var (
+		nextTier, canProve = e.provableTier(s.Tier + 1)
+		next               = e.tier(nextTier)
+		proofCost          = new(big.Int)
+		nextValidityBond   = new(big.Int)
+	)
+	if canProve && next != nil {
+		proofCost = e.proofCost(nextTier)
+		nextValidityBond = next.ValidityBond
+	}
+
+	var (
+		action    Action
+		gain      *big.Float
+		loss      *big.Float
+		atRisk    *big.Int
+		confident = big.NewFloat(e.cfg.Confidence)
+		wrong     = big.NewFloat(1 - e.cfg.Confidence)
+		reward    = new(big.Int).Rsh(current.ValidityBond, 2)
+	)
+	switch s.Contester {
+	case rpc.ZeroAddress:
+		// Contest: win a quarter of the validity bond if we are right, and lose the contest bond if we
+		// are wrong. We will also resolve the contest ourselves with a higher tier proof if we can.
+		action = ActionContest
+		gain = new(big.Float).Mul(confident, e.bondValue(reward))
+		loss = new(big.Float).Mul(wrong, e.bondValue(current.ContestBond))
+		atRisk = new(big.Int).Add(current.ContestBond, nextValidityBond)
+	case e.proverAddress:
+		// Escalate our own contest: get back the contest bond with the reward if we are right, and lose
+		// the higher tier validity bond if we are wrong.
+		if !canProve {
+			return abstain("no higher tier proof can be submitted by this prover")
+		}
+		action = ActionEscalate
+		gain = new(big.Float).Mul(confident, e.bondValue(new(big.Int).Add(current.ContestBond, reward)))
+		loss = new(big.Float).Mul(wrong, e.bondValue(nextValidityBond))
+		atRisk = new(big.Int).Add(current.ContestBond, nextValidityBond)
+	default:
+		// Escalate another prover's contest: the contest rewards go to the active contester.
+		if !canProve {
+			return abstain("no higher tier proof can be submitted by this prover")
+		}
+		action = ActionEscalate
+		gain = new(big.Float)
+		loss = new(big.Float).Mul(wrong, e.bondValue(nextValidityBond))
+		atRisk = new(big.Int).Set(nextValidityBond)
+	}
+
+	ev, _ := new(big.Float).Sub(gain, loss).Int(nil)
+	ev.Sub(ev, proofCost)
+
+	if ev.Sign() <= 0 {
+		reason := "negative expected value"
+		if s.Contester != rpc.ZeroAddress && s.Contester != e.proverAddress {
+			reason = "another contester is active"
+		}
+		return &Decision{Action: ActionAbstain, ExpectedValue: ev, BondsAtRisk: common.Big0, Reason: reason}
+	}
+
+	if !e.fitsBudget(s.BlockID, atRisk) {
+		return &Decision{Action: ActionAbstain, ExpectedValue: ev, BondsAtRisk: common.Big0, Reason: "budget exceeded"}
+	}
+
+	d := &Decision{Action: action, ExpectedValue: ev, BondsAtRisk: atRisk, Reason: "positive expected value"}
+	if action == ActionEscalate {
+		d.Tier = nextTier
+	}
+	return d
+}
+
+// Commit reserves the bonds at risk of the given decision on the given block in the budget, once the caller
+// takes the decided action, and returns false if the budget no longer allows the action.
+func (e *Engine) Commit(blockID *big.Int, d *Decision) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if !e.withinBudget(blockID, d.BondsAtRisk) {
+		log.Info("Contest budget exceeded, dropping the action", "blockID", blockID, "action", d.Action)
+		return false
+	}
+
+	e.reserved[blockID.Uint64()] = new(big.Int).Set(d.BondsAtRisk)
+	return true
+}
+
+// Release releases the bonds reserved for the given block, once the committed action failed.
+func (e *Engine) Release(blockID *big.Int) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	delete(e.reserved, blockID.Uint64())
+}
+
+// ReleaseUntil releases the bonds reserved for the blocks up to the given verified block.
+func (e *Engine) ReleaseUntil(verifiedBlockID *big.Int) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for id := range e.reserved {
+		if id <= verifiedBlockID.Uint64() {
+			delete(e.reserved, id)
+		}
+	}
+}
+
+// BondsAtRisk returns the total bonds reserved in the ongoing contests and escalations.
+func (e *Engine) BondsAtRisk() *big.Int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.bondsAtRisk()
+}
+
+// fitsBudget returns whether the given bonds can be reserved for the given block.
+func (e *Engine) fitsBudget(blockID *big.Int, bonds *big.Int) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.withinBudget(blockID, bonds)
+}
+
+// withinBudget returns whether the given bonds can be reserved for the given block, replacing its current
+// reservation, the caller must hold the mutex.
+func (e *Engine) withinBudget(blockID *big.Int, bonds *big.Int) bool {
+	total := new(big.Int).Add(e.bondsAtRisk(), bonds)
+	if reserved, ok := e.reserved[blockID.Uint64()]; ok {
+		total.Sub(total, reserved)
+	}
+
+	return e.cfg.MaxBondsAtRisk == nil || e.cfg.MaxBondsAtRisk.Sign() == 0 || total.Cmp(e.cfg.MaxBondsAtRisk) <= 0
+}
+
+// bondsAtRisk returns the total reserved bonds, the caller must hold the mutex.
+func (e *Engine) bondsAtRisk() *big.Int {
+	total := new(big.Int)
+	for _, bonds := range e.reserved {
+		total.Add(total, bonds)
+	}
+	return total
+}
+
+// tier returns the protocol tier with the given ID.
+func (e *Engine) tier(id uint16) *rpc.TierProviderTierWithID {
+	for _, t := range e.tiers() {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// bondValue returns the value of the given amount of bond tokens, in wei if the bond token price is set.
+func (e *Engine) bondValue(bond *big.Int) *big.Float {
+	if e.cfg.BondTokenPrice == nil || e.cfg.BondTokenPrice.Sign() == 0 {
+		return new(big.Float).SetInt(bond)
+	}
+
+	value := new(big.Int).Mul(bond, e.cfg.BondTokenPrice)
+	return new(big.Float).SetInt(value.Div(value, big.NewInt(params.Ether)))
+}
+
+// proofCost returns the estimated cost of submitting a proof of the given tier, which is ignored
+// when the bond token price is not set.
+func (e *Engine) proofCost(tier uint16) *big.Int {
+	if e.cfg.BondTokenPrice == nil || e.cfg.BondTokenPrice.Sign() == 0 {
+		return new(big.Int)
+	}
+	if cost, ok := e.cfg.ProofCosts[tier]; ok && cost != nil {
+		return cost
+	}
+	return new(big.Int)
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+type ContestEngineTestSuite struct {
+	suite.Suite
+	engine        *Engine
+	proverAddress common.Address
+	provable      []uint16
+}
+
+func testTier(id uint16, validityBond, contestBond int64) *rpc.TierProviderTierWithID {
+	return &rpc.TierProviderTierWithID{
+		ID: id,
+		ITierProviderTier: bindings.ITierProviderTier{
+			ValidityBond:   new(big.Int).Mul(big.NewInt(validityBond), big.NewInt(params.Ether)),
+			ContestBond:    new(big.Int).Mul(big.NewInt(contestBond), big.NewInt(params.Ether)),
+			CooldownWindow: big.NewInt(60),
+		},
+	}
+}
+
+func (s *ContestEngineTestSuite) SetupTest() {
+	s.proverAddress = common.BytesToAddress([]byte{1})
+	s.provable = []uint16{encoding.TierSgxID, encoding.TierSgxAndZkVMID}
+	s.engine = s.newEngine(&Config{Confidence: 0.99, MinCooldownRemaining: time.Minute})
+}
+
+func (s *ContestEngineTestSuite) newEngine(cfg *Config) *Engine {
+	tiers := []*rpc.TierProviderTierWithID{
+		testTier(encoding.TierOptimisticID, 20, 20),
+		testTier(encoding.TierSgxID, 250, 500),
+		testTier(encoding.TierSgxAndZkVMID, 500, 1000),
+		testTier(encoding.TierGuardianMajorityID, 0, 0),
+	}
+	return New(
+		cfg,
+		s.proverAddress,
+		func() []*rpc.TierProviderTierWithID { return tiers },
+		func(minTier uint16) (uint16, bool) {
+			for _, tier := range s.provable {
+				if tier >= minTier {
+					return tier, true
+				}
+			}
+			return 0, false
+		},
+	)
+}
+
+func (s *ContestEngineTestSuite) situation(id int64, tier uint16, contester common.Address) *Situation {
+	return &Situation{
+		BlockID:   big.NewInt(id),
+		Tier:      tier,
+		Contester: contester,
+		Timestamp: uint64(time.Now().Unix()),
+	}
+}
+
+func (s *ContestEngineTestSuite) TestContest() {
+	d := s.engine.Decide(s.situation(1, encoding.TierOptimisticID, rpc.ZeroAddress))
+	s.Equal(ActionContest, d.Action)
+	s.Equal(1, d.ExpectedValue.Sign())
+	// Contest bond of the optimistic tier, and validity bond of the SGX tier.
+	s.Equal(new(big.Int).Mul(big.NewInt(270), big.NewInt(params.Ether)), d.BondsAtRisk)
+	// The bonds are only reserved once the contest is committed.
+	s.Zero(s.engine.BondsAtRisk().Sign())
+	s.True(s.engine.Commit(common.Big1, d))
+	s.Equal(d.BondsAtRisk, s.engine.BondsAtRisk())
+
+	s.engine.ReleaseUntil(common.Big1)
+	s.Zero(s.engine.BondsAtRisk().Sign())
+}
+
+func (s *ContestEngineTestSuite) TestContestNegativeExpectedValue() {
+	// Reward: 0.6 * 5, loss: 0.4 * 20.
+	s.engine = s.newEngine(&Config{Confidence: 0.6})
+	d := s.engine.Decide(s.situation(1, encoding.TierOptimisticID, rpc.ZeroAddress))
+	s.Equal(ActionAbstain, d.Action)
+	s.Equal(-1, d.ExpectedValue.Sign())
+	s.Zero(s.engine.BondsAtRisk().Sign())
+}
+
+func (s *ContestEngineTestSuite) TestContestProofCost() {
+	// Reward: 0.99 * 5 tokens worth 0.005 Ether, the SGX proof costs 0.01 Ether.
+	s.engine = s.newEngine(&Config{
+		Confidence:     0.99,
+		BondTokenPrice: big.NewInt(params.Ether / 1000),
+		ProofCosts:     map[uint16]*big.Int{encoding.TierSgxID: big.NewInt(params.Ether / 100)},
+	})
+	s.Equal(ActionAbstain, s.engine.Decide(s.situation(1, encoding.TierOptimisticID, rpc.ZeroAddress)).Action)
+
+	s.engine.cfg.ProofCosts[encoding.TierSgxID] = big.NewInt(params.Ether / 1000)
+	s.Equal(ActionContest, s.engine.Decide(s.situation(1, encoding.TierOptimisticID, rpc.ZeroAddress)).Action)
+}
+
+func (s *ContestEngineTestSuite) TestEscalate() {
+	d := s.engine.Decide(s.situation(1, encoding.TierOptimisticID, s.proverAddress))
+	s.Equal(ActionEscalate, d.Action)
+	s.Equal(encoding.TierSgxID, d.Tier)
+
+	// Escalating another prover's contest only has costs.
+	d = s.engine.Decide(s.situation(2, encoding.TierOptimisticID, common.BytesToAddress([]byte{2})))
+	s.Equal(ActionAbstain, d.Action)
+	s.Equal("another contester is active", d.Reason)
+
+	// No higher tier can be proven by this prover.
+	d = s.engine.Decide(s.situation(3, encoding.TierSgxAndZkVMID, s.proverAddress))
+	s.Equal(ActionAbstain, d.Action)
+}
+
+func (s *ContestEngineTestSuite) TestCooldownWindow() {
+	situation := s.situation(1, encoding.TierOptimisticID, rpc.ZeroAddress)
+	situation.Timestamp = uint64(time.Now().Add(-time.Hour).Unix())
+	d := s.engine.Decide(situation)
+	s.Equal(ActionAbstain, d.Action)
+	s.Equal("cooldown window nearly over", d.Reason)
+}
+
+func (s *ContestEngineTestSuite) TestBudget() {
+	s.engine = s.newEngine(&Config{
+		Confidence:     0.99,
+		MaxBondsAtRisk: new(big.Int).Mul(big.NewInt(300), big.NewInt(params.Ether)),
+	})
+	contest := s.engine.Decide(s.situation(1, encoding.TierOptimisticID, rpc.ZeroAddress))
+	s.Equal(ActionContest, contest.Action)
+	// Uncommitted decisions don't take the budget.
+	s.Equal(ActionContest, s.engine.Decide(s.situation(2, encoding.TierOptimisticID, rpc.ZeroAddress)).Action)
+	s.True(s.engine.Commit(common.Big1, contest))
+
+	// Committing again for the same block replaces its reservation.
+	escalate := s.engine.Decide(s.situation(1, encoding.TierOptimisticID, s.proverAddress))
+	s.Equal(ActionEscalate, escalate.Action)
+	s.True(s.engine.Commit(common.Big1, escalate))
+
+	d := s.engine.Decide(s.situation(2, encoding.TierOptimisticID, rpc.ZeroAddress))
+	s.Equal(ActionAbstain, d.Action)
+	s.Equal("budget exceeded", d.Reason)
+	s.False(s.engine.Commit(common.Big2, contest))
+
+	// A failed action releases its reservation.
+	s.engine.Release(common.Big1)
+	s.Zero(s.engine.BondsAtRisk().Sign())
+	s.True(s.engine.Commit(common.Big2, contest))
+
+	s.engine.ReleaseUntil(common.Big2)
+	s.Zero(s.engine.BondsAtRisk().Sign())
+}
+
+func TestContestEngineTestSuite(t *testing.T) {
+	suite.Run(t, new(ContestEngineTestSuite))
+}
@@ -9,34 +9,56 @@ import (
 
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	engine "github.com/taikoxyz/taiko-client/prover/contest_engine"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	scheduler "github.com/taikoxyz/taiko-client/prover/proof_scheduler"
 )
 
 // AssignmentExpiredEventHandler is responsible for handling the expiration of proof assignments.
 type AssignmentExpiredEventHandler struct {
 	rpc               *rpc.Client
-	proverAddress     common.Address
+	proverAddresses   []common.Address
 	proofSubmissionCh chan<- *proofProducer.ProofRequestBody
 	proofContestCh    chan<- *proofProducer.ContestRequestBody
 	contesterMode     bool
+	contestEngine     *engine.Engine
+	scheduler         *scheduler.Scheduler
 }
 
 // NewAssignmentExpiredEventHandler creates a new AssignmentExpiredEventHandler instance.
 func NewAssignmentExpiredEventHandler(
 	rpc *rpc.Client,
-	proverAddress common.Address,
+	proverAddresses []common.Address,
 	proofSubmissionCh chan *proofProducer.ProofRequestBody,
 	proofContestCh chan *proofProducer.ContestRequestBody,
 	contesterMode bool,
+	contestEngine *engine.Engine,
+	scheduler *scheduler.Scheduler,
 ) *AssignmentExpiredEventHandler {
-	return &AssignmentExpiredEventHandler{rpc, proverAddress, proofSubmissionCh, proofContestCh, contesterMode}
+	return &AssignmentExpiredEventHandler{
+		rpc,
+		proverAddresses,
+		proofSubmissionCh,
+		proofContestCh,
+		contesterMode,
+		contestEngine,
+		scheduler,
+	}
 }
 
 // Handle implements the AssignmentExpiredHandler interface.
 func (h *AssignmentExpiredEventHandler) Handle(
 	ctx context.Context,
 	e *bindings.TaikoL1ClientBlockProposed,
-) error {
+) (err error) {
+	// The opportunistic proving of the block is finished, unless a proof is requested.
+	proofRequested := false
+	defer func() {
+		if err == nil && !proofRequested && h.scheduler != nil {
+			h.scheduler.Forget(e.BlockId)
+		}
+	}()
+
 	log.Info(
 		"Proof assignment window is expired",
 		"blockID", e.BlockId,
@@ -45,14 +67,22 @@ func (h *AssignmentExpiredEventHandler) Handle(
 	)
 
 	// Check if we still need to generate a new proof for that block.
-	proofStatus, err := rpc.GetBlockProofStatus(ctx, h.rpc, e.BlockId, h.proverAddress)
+	proofStatus, err := rpc.GetBlockProofStatus(
+		ctx,
+		h.rpc,
+		e.BlockId,
+		proverAddressOf(h.proverAddresses, e.AssignedProver),
+	)
 	if err != nil {
 		return err
 	}
 	if !proofStatus.IsSubmitted {
-		go func() {
-			h.proofSubmissionCh <- &proofProducer.ProofRequestBody{Tier: e.Meta.MinTier, Event: e}
-		}()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case h.proofSubmissionCh <- &proofProducer.ProofRequestBody{Tier: e.Meta.MinTier, Event: e}:
+			proofRequested = true
+		}
 		return nil
 	}
 	// If there is already a proof submitted and there is no need to contest
@@ -61,23 +91,55 @@ func (h *AssignmentExpiredEventHandler) Handle(
 		return nil
 	}
 
+	// Decide whether it is worth contesting the transition, or resolving the current contest.
+	tier := proofStatus.CurrentTransitionState.Tier + 1
+	if h.contestEngine != nil {
+		decision := h.contestEngine.Decide(&engine.Situation{
+			BlockID:   e.BlockId,
+			Tier:      proofStatus.CurrentTransitionState.Tier,
+			Contester: proofStatus.CurrentTransitionState.Contester,
+			Timestamp: proofStatus.CurrentTransitionState.Timestamp,
+		})
+		if decision.Action == engine.ActionAbstain || !h.contestEngine.Commit(e.BlockId, decision) {
+			return nil
+		}
+		if decision.Action == engine.ActionEscalate {
+			tier = decision.Tier
+		}
+	}
+
 	// If there is no contester, we submit a contest to protocol.
-	go func() {
-		if proofStatus.CurrentTransitionState.Contester == rpc.ZeroAddress {
-			h.proofContestCh <- &proofProducer.ContestRequestBody{
-				BlockID:    e.BlockId,
-				ProposedIn: new(big.Int).SetUint64(e.Raw.BlockNumber),
-				ParentHash: proofStatus.ParentHeader.Hash(),
-				Meta:       &e.Meta,
-				Tier:       proofStatus.CurrentTransitionState.Tier,
-			}
-		} else {
-			h.proofSubmissionCh <- &proofProducer.ProofRequestBody{
-				Tier:  proofStatus.CurrentTransitionState.Tier + 1,
-				Event: e,
-			}
+	if proofStatus.CurrentTransitionState.Contester == rpc.ZeroAddress {
+		select {
+		case <-ctx.Done():
+			h.releaseBonds(e.BlockId)
+			return ctx.Err()
+		case h.proofContestCh <- &proofProducer.ContestRequestBody{
+			BlockID:    e.BlockId,
+			ProposedIn: new(big.Int).SetUint64(e.Raw.BlockNumber),
+			ParentHash: proofStatus.ParentHeader.Hash(),
+			Meta:       &e.Meta,
+			Tier:       proofStatus.CurrentTransitionState.Tier,
+		}:
 		}
-	}()
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		h.releaseBonds(e.BlockId)
+		return ctx.Err()
+	case h.proofSubmissionCh <- &proofProducer.ProofRequestBody{Tier: tier, Event: e}:
+		proofRequested = true
+	}
 
 	return nil
 }
+
+// releaseBonds releases the bonds reserved for contesting or escalating the given block, if the contest or
+// the proof is not requested.
+func (h *AssignmentExpiredEventHandler) releaseBonds(blockID *big.Int) {
+	if h.contestEngine != nil {
+		h.contestEngine.Release(blockID)
+	}
+}
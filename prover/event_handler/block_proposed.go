@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -18,8 +19,10 @@ import (
 	"github.com/taikoxyz/taiko-client/internal/utils"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	engine "github.com/taikoxyz/taiko-client/prover/contest_engine"
 	guardianProverHeartbeater "github.com/taikoxyz/taiko-client/prover/guardian_prover_heartbeater"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	scheduler "github.com/taikoxyz/taiko-client/prover/proof_scheduler"
 	state "github.com/taikoxyz/taiko-client/prover/shared_state"
 )
 
@@ -32,17 +35,18 @@ var (
 // BlockProposedEventHandler is responsible for handling the BlockProposed event as a prover.
 type BlockProposedEventHandler struct {
 	sharedState           *state.SharedState
-	proverAddress         common.Address
+	proverAddresses       []common.Address
 	genesisHeightL1       uint64
 	rpc                   *rpc.Client
 	proofGenerationCh     chan<- *proofProducer.ProofWithHeader
-	assignmentExpiredCh   chan<- *bindings.TaikoL1ClientBlockProposed
-	proofSubmissionCh     chan<- *proofProducer.ProofRequestBody
 	proofContestCh        chan<- *proofProducer.ContestRequestBody
+	scheduler             *scheduler.Scheduler
 	backOffRetryInterval  time.Duration
 	backOffMaxRetrys      uint64
 	contesterMode         bool
 	proveUnassignedBlocks bool
+	proofCache            *proofProducer.ProofCache
+	contestEngine         *engine.Engine
 	// Guardian prover related.
 	isGuardian      bool
 	submissionDelay time.Duration
@@ -51,35 +55,37 @@ type BlockProposedEventHandler struct {
 // NewBlockProposedEventHandlerOps is the options for creating a new BlockProposedEventHandler.
 type NewBlockProposedEventHandlerOps struct {
 	SharedState           *state.SharedState
-	ProverAddress         common.Address
+	ProverAddresses       []common.Address
 	GenesisHeightL1       uint64
 	RPC                   *rpc.Client
 	ProofGenerationCh     chan *proofProducer.ProofWithHeader
-	AssignmentExpiredCh   chan *bindings.TaikoL1ClientBlockProposed
-	ProofSubmissionCh     chan *proofProducer.ProofRequestBody
 	ProofContestCh        chan *proofProducer.ContestRequestBody
+	Scheduler             *scheduler.Scheduler
 	BackOffRetryInterval  time.Duration
 	BackOffMaxRetrys      uint64
 	ContesterMode         bool
 	ProveUnassignedBlocks bool
 	SubmissionDelay       time.Duration
+	ProofCache            *proofProducer.ProofCache
+	ContestEngine         *engine.Engine
 }
 
 // NewBlockProposedEventHandler creates a new BlockProposedEventHandler instance.
 func NewBlockProposedEventHandler(opts *NewBlockProposedEventHandlerOps) *BlockProposedEventHandler {
 	return &BlockProposedEventHandler{
 		opts.SharedState,
-		opts.ProverAddress,
+		opts.ProverAddresses,
 		opts.GenesisHeightL1,
 		opts.RPC,
 		opts.ProofGenerationCh,
-		opts.AssignmentExpiredCh,
-		opts.ProofSubmissionCh,
 		opts.ProofContestCh,
+		opts.Scheduler,
 		opts.BackOffRetryInterval,
 		opts.BackOffMaxRetrys,
 		opts.ContesterMode,
 		opts.ProveUnassignedBlocks,
+		opts.ProofCache,
+		opts.ContestEngine,
 		false,
 		opts.SubmissionDelay,
 	}
@@ -132,6 +138,9 @@ func (h *BlockProposedEventHandler) Handle(
 		"blobUsed", e.Meta.BlobUsed,
 	)
 	metrics.ProverReceivedProposedBlockGauge.Set(float64(e.BlockId.Uint64()))
+	metrics.ProverChainReceivedProposedIDGaugeVec.WithLabelValues(h.rpc.L2.ChainID.String()).Set(
+		float64(e.BlockId.Uint64()),
+	)
 
 	// Move l1Current cursor.
 	newL1Current, err := h.rpc.L1.HeaderByHash(ctx, e.Raw.BlockHash)
@@ -197,6 +206,10 @@ func (h *BlockProposedEventHandler) checkL1Reorg(
 		} else {
 			h.sharedState.SetLastHandledBlockID(reorgCheckResult.LastHandledBlockIDToReset.Uint64())
 		}
+		// The proofs of the reorged blocks are no longer valid.
+		if h.proofCache != nil {
+			h.proofCache.ForgetFrom(new(big.Int).SetUint64(h.sharedState.GetLastHandledBlockID() + 1))
+		}
 		return errL1Reorged
 	}
 
@@ -267,7 +280,7 @@ func (h *BlockProposedEventHandler) checkExpirationAndSubmitProof(
 		ctx,
 		h.rpc,
 		e.BlockId,
-		h.proverAddress,
+		proverAddressOf(h.proverAddresses, e.AssignedProver),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to check whether the L2 block needs a new proof: %w", err)
@@ -295,13 +308,30 @@ func (h *BlockProposedEventHandler) checkExpirationAndSubmitProof(
 			return nil
 		}
 
-		// The proof submitted to protocol is invalid.
-		h.proofContestCh <- &proofProducer.ContestRequestBody{
+		// The proof submitted to protocol is invalid, we decide whether it is worth contesting it.
+		if h.contestEngine != nil {
+			decision := h.contestEngine.Decide(&engine.Situation{
+				BlockID:   e.BlockId,
+				Tier:      proofStatus.CurrentTransitionState.Tier,
+				Contester: proofStatus.CurrentTransitionState.Contester,
+				Timestamp: proofStatus.CurrentTransitionState.Timestamp,
+			})
+			if decision.Action != engine.ActionContest || !h.contestEngine.Commit(e.BlockId, decision) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			h.releaseBonds(e.BlockId)
+			return ctx.Err()
+		case h.proofContestCh <- &proofProducer.ContestRequestBody{
 			BlockID:    e.BlockId,
 			ProposedIn: new(big.Int).SetUint64(e.Raw.BlockNumber),
 			ParentHash: proofStatus.ParentHeader.Hash(),
 			Meta:       &e.Meta,
 			Tier:       e.Meta.MinTier,
+		}:
 		}
 		return nil
 	}
@@ -311,9 +341,10 @@ func (h *BlockProposedEventHandler) checkExpirationAndSubmitProof(
 		return fmt.Errorf("failed to check if the proving window is expired: %w", err)
 	}
 
-	// If the proving window is not expired, we need to check if the current prover is the assigned prover,
+	// If the proving window is not expired, we need to check if any current prover identity is the assigned prover,
 	// if no and the current prover wants to prove unassigned blocks, then we should wait for its expiration.
-	if !windowExpired && e.AssignedProver != h.proverAddress {
+	isAssigned := slices.Contains(h.proverAddresses, e.AssignedProver)
+	if !windowExpired && !isAssigned {
 		log.Info(
 			"Proposed block is not provable by current prover at the moment",
 			"blockID", e.BlockId,
@@ -328,11 +359,12 @@ func (h *BlockProposedEventHandler) checkExpirationAndSubmitProof(
 				"assignProver", e.AssignedProver,
 				"timeToExpire", timeToExpire,
 			)
-			time.AfterFunc(
+			h.scheduler.Schedule(ctx, &scheduler.Job{
+				Kind:  scheduler.JobAssignmentExpired,
+				Event: e,
 				// Add another 60 seconds, to ensure one more L1 block will be mined before the proof submission
-				timeToExpire+proofExpirationDelay,
-				func() { h.assignmentExpiredCh <- e },
-			)
+				ReadyAt: expiredAt.Add(proofExpirationDelay),
+			})
 		}
 
 		return nil
@@ -363,13 +395,23 @@ func (h *BlockProposedEventHandler) checkExpirationAndSubmitProof(
 
 	metrics.ProverProofsAssigned.Add(1)
 
-	time.AfterFunc(submissionDelay, func() {
-		h.proofSubmissionCh <- &proofProducer.ProofRequestBody{Tier: tier, Event: e}
-	})
+	// The proof of a block assigned to the current prover should be submitted before the proving window closes.
+	job := &scheduler.Job{Kind: scheduler.JobProve, Tier: tier, Event: e, ReadyAt: time.Now().Add(submissionDelay)}
+	if !windowExpired && isAssigned {
+		job.Deadline = expiredAt
+	}
+	h.scheduler.Schedule(ctx, job)
 
 	return nil
 }
 
+// releaseBonds releases the bonds reserved for contesting the given block, if the contest is not requested.
+func (h *BlockProposedEventHandler) releaseBonds(blockID *big.Int) {
+	if h.contestEngine != nil {
+		h.contestEngine.Release(blockID)
+	}
+}
+
 // ========================= Guardian Prover =========================
 
 // NewBlockProposedGuardianEventHandlerOps is the options for creating a new BlockProposedEventHandler.
@@ -8,18 +8,18 @@ import (
 
 	"github.com/taikoxyz/taiko-client/bindings"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	scheduler "github.com/taikoxyz/taiko-client/prover/proof_scheduler"
 	state "github.com/taikoxyz/taiko-client/prover/shared_state"
 )
 
 func (s *EventHandlerTestSuite) TestBlockProposedHandle() {
 	opts := &NewBlockProposedEventHandlerOps{
 		SharedState:           &state.SharedState{},
-		ProverAddress:         common.Address{},
+		ProverAddresses:       []common.Address{{}},
 		GenesisHeightL1:       0,
 		RPC:                   s.RPCClient,
 		ProofGenerationCh:     make(chan *proofProducer.ProofWithHeader),
-		AssignmentExpiredCh:   make(chan *bindings.TaikoL1ClientBlockProposed),
-		ProofSubmissionCh:     make(chan *proofProducer.ProofRequestBody),
+		Scheduler:             s.newScheduler(),
 		ProofContestCh:        make(chan *proofProducer.ContestRequestBody),
 		BackOffRetryInterval:  1 * time.Minute,
 		BackOffMaxRetrys:      5,
@@ -35,12 +35,11 @@ func (s *EventHandlerTestSuite) TestBlockProposedHandle() {
 func (s *EventHandlerTestSuite) TestGetRandomBumpedSubmissionDelay() {
 	opts := &NewBlockProposedEventHandlerOps{
 		SharedState:           &state.SharedState{},
-		ProverAddress:         common.Address{},
+		ProverAddresses:       []common.Address{{}},
 		GenesisHeightL1:       0,
 		RPC:                   s.RPCClient,
 		ProofGenerationCh:     make(chan *proofProducer.ProofWithHeader),
-		AssignmentExpiredCh:   make(chan *bindings.TaikoL1ClientBlockProposed),
-		ProofSubmissionCh:     make(chan *proofProducer.ProofRequestBody),
+		Scheduler:             s.newScheduler(),
 		ProofContestCh:        make(chan *proofProducer.ContestRequestBody),
 		BackOffRetryInterval:  1 * time.Minute,
 		BackOffMaxRetrys:      5,
@@ -64,3 +63,15 @@ func (s *EventHandlerTestSuite) TestGetRandomBumpedSubmissionDelay() {
 		opts.SubmissionDelay.Seconds()*(1+(submissionDelayRandomBumpRange/100)),
 	)
 }
+
+func (s *EventHandlerTestSuite) newScheduler() *scheduler.Scheduler {
+	proofScheduler, err := scheduler.New(
+		&scheduler.Config{Capacity: 1024},
+		s.RPCClient,
+		make(chan *proofProducer.ProofRequestBody, 1024),
+		make(chan *bindings.TaikoL1ClientBlockProposed, 1024),
+		nil,
+	)
+	s.Nil(err)
+	return proofScheduler
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/internal/utils"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	engine "github.com/taikoxyz/taiko-client/prover/contest_engine"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
 
@@ -19,6 +20,7 @@ type TransitionContestedEventHandler struct {
 	rpc               *rpc.Client
 	proofSubmissionCh chan<- *proofProducer.ProofRequestBody
 	contesterMode     bool
+	contestEngine     *engine.Engine
 }
 
 // NewTransitionContestedEventHandler creates a new TransitionContestedEventHandler instance.
@@ -26,8 +28,9 @@ func NewTransitionContestedEventHandler(
 	rpc *rpc.Client,
 	proofSubmissionCh chan *proofProducer.ProofRequestBody,
 	contesterMode bool,
+	contestEngine *engine.Engine,
 ) *TransitionContestedEventHandler {
-	return &TransitionContestedEventHandler{rpc, proofSubmissionCh, contesterMode}
+	return &TransitionContestedEventHandler{rpc, proofSubmissionCh, contesterMode, contestEngine}
 }
 
 // Handle implements the TransitionContestedHandler interface.
@@ -60,7 +63,7 @@ func (h *TransitionContestedEventHandler) Handle(
 	}
 
 	// Compare the contested transition to the block in local L2 canonical chain.
-	isValid, err := isValidProof(
+	isValid, err := IsValidProof(
 		ctx,
 		h.rpc,
 		e.BlockId,
@@ -84,28 +87,55 @@ func (h *TransitionContestedEventHandler) Handle(
 		return nil
 	}
 
-	// If the proof is invalid, we contest it.
+	// If the contested transition is invalid, we decide whether it is worth resolving the contest
+	// with a higher tier proof.
+	tier := e.Tier + 1
+	if h.contestEngine != nil {
+		decision := h.contestEngine.Decide(&engine.Situation{
+			BlockID:   e.BlockId,
+			Tier:      contestedTransition.Tier,
+			Contester: contestedTransition.Contester,
+			Timestamp: contestedTransition.Timestamp,
+		})
+		if decision.Action != engine.ActionEscalate || !h.contestEngine.Commit(e.BlockId, decision) {
+			return nil
+		}
+		tier = decision.Tier
+	}
+
 	blockInfo, err := h.rpc.GetL2BlockInfo(ctx, e.BlockId)
 	if err != nil {
+		h.releaseBonds(e.BlockId)
 		return err
 	}
 
-	blockProposedEvent, err := getBlockProposedEventFromBlockID(
+	blockProposedEvent, err := GetBlockProposedEventFromBlockID(
 		ctx,
 		h.rpc,
 		e.BlockId,
 		new(big.Int).SetUint64(blockInfo.ProposedIn),
 	)
 	if err != nil {
+		h.releaseBonds(e.BlockId)
 		return err
 	}
 
-	go func() {
-		h.proofSubmissionCh <- &proofProducer.ProofRequestBody{
-			Tier:  e.Tier + 1, // We need to send a higher tier proof to resolve the current contest.
-			Event: blockProposedEvent,
-		}
-	}()
+	select {
+	case <-ctx.Done():
+		h.releaseBonds(e.BlockId)
+		return ctx.Err()
+	case h.proofSubmissionCh <- &proofProducer.ProofRequestBody{
+		Tier:  tier, // We need to send a higher tier proof to resolve the current contest.
+		Event: blockProposedEvent,
+	}:
+	}
 
 	return nil
 }
+
+// releaseBonds releases the bonds reserved for escalating the given block, if the proof is not requested.
+func (h *TransitionContestedEventHandler) releaseBonds(blockID *big.Int) {
+	if h.contestEngine != nil {
+		h.contestEngine.Release(blockID)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/internal/metrics"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	engine "github.com/taikoxyz/taiko-client/prover/contest_engine"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
 
@@ -18,6 +20,7 @@ type TransitionProvedEventHandler struct {
 	rpc            *rpc.Client
 	proofContestCh chan<- *proofProducer.ContestRequestBody
 	contesterMode  bool
+	contestEngine  *engine.Engine
 }
 
 // NewTransitionProvedEventHandler creates a new TransitionProvedEventHandler instance.
@@ -25,8 +28,9 @@ func NewTransitionProvedEventHandler(
 	rpc *rpc.Client,
 	proofContestCh chan *proofProducer.ContestRequestBody,
 	contesterMode bool,
+	contestEngine *engine.Engine,
 ) *TransitionProvedEventHandler {
-	return &TransitionProvedEventHandler{rpc, proofContestCh, contesterMode}
+	return &TransitionProvedEventHandler{rpc, proofContestCh, contesterMode, contestEngine}
 }
 
 // Handle implements the TransitionProvedHandler interface.
@@ -35,6 +39,9 @@ func (h *TransitionProvedEventHandler) Handle(
 	e *bindings.TaikoL1ClientTransitionProved,
 ) error {
 	metrics.ProverReceivedProvenBlockGauge.Set(float64(e.BlockId.Uint64()))
+	metrics.ProverChainReceivedProvenIDGaugeVec.WithLabelValues(h.rpc.L2.ChainID.String()).Set(
+		float64(e.BlockId.Uint64()),
+	)
 
 	// If this prover is in contest mode, we check the validity of this proof and if it's invalid,
 	// contest it with a higher tier proof.
@@ -42,7 +49,7 @@ func (h *TransitionProvedEventHandler) Handle(
 		return nil
 	}
 
-	isValid, err := isValidProof(
+	isValid, err := IsValidProof(
 		ctx,
 		h.rpc,
 		e.BlockId,
@@ -58,14 +65,36 @@ func (h *TransitionProvedEventHandler) Handle(
 		return nil
 	}
 
-	// If the proof is invalid, we contest it.
+	// If the proof is invalid, we decide whether it is worth contesting it.
+	if h.contestEngine != nil {
+		transition, err := h.rpc.TaikoL1.GetTransition0(
+			&bind.CallOpts{Context: ctx},
+			e.BlockId.Uint64(),
+			e.Tran.ParentHash,
+		)
+		if err != nil {
+			return err
+		}
+		decision := h.contestEngine.Decide(&engine.Situation{
+			BlockID:   e.BlockId,
+			Tier:      transition.Tier,
+			Contester: transition.Contester,
+			Timestamp: transition.Timestamp,
+		})
+		if decision.Action != engine.ActionContest || !h.contestEngine.Commit(e.BlockId, decision) {
+			return nil
+		}
+	}
+
 	blockInfo, err := h.rpc.GetL2BlockInfo(ctx, e.BlockId)
 	if err != nil {
+		h.releaseBonds(e.BlockId)
 		return err
 	}
 
 	meta, err := getMetadataFromBlockID(ctx, h.rpc, e.BlockId, new(big.Int).SetUint64(blockInfo.ProposedIn))
 	if err != nil {
+		h.releaseBonds(e.BlockId)
 		return err
 	}
 
@@ -79,14 +108,24 @@ func (h *TransitionProvedEventHandler) Handle(
 		"stateRoot", common.Bytes2Hex(e.Tran.StateRoot[:]),
 	)
 
-	go func() {
-		h.proofContestCh <- &proofProducer.ContestRequestBody{
-			BlockID:    e.BlockId,
-			ProposedIn: new(big.Int).SetUint64(blockInfo.ProposedIn),
-			ParentHash: e.Tran.ParentHash,
-			Meta:       meta,
-			Tier:       e.Tier,
-		}
-	}()
+	select {
+	case <-ctx.Done():
+		h.releaseBonds(e.BlockId)
+		return ctx.Err()
+	case h.proofContestCh <- &proofProducer.ContestRequestBody{
+		BlockID:    e.BlockId,
+		ProposedIn: new(big.Int).SetUint64(blockInfo.ProposedIn),
+		ParentHash: e.Tran.ParentHash,
+		Meta:       meta,
+		Tier:       e.Tier,
+	}:
+	}
 	return nil
 }
+
+// releaseBonds releases the bonds reserved for contesting the given block, if the contest is not requested.
+func (h *TransitionProvedEventHandler) releaseBonds(blockID *big.Int) {
+	if h.contestEngine != nil {
+		h.contestEngine.Release(blockID)
+	}
+}
@@ -117,8 +117,9 @@ func (s *EventHandlerTestSuite) SetupTest() {
 func (s *EventHandlerTestSuite) TestTransitionProvedHandle() {
 	handler := NewTransitionProvedEventHandler(
 		s.RPCClient,
-		make(chan *proofProducer.ContestRequestBody),
+		make(chan *proofProducer.ContestRequestBody, 1),
 		true,
+		nil,
 	)
 	e := s.ProposeAndInsertValidBlock(s.proposer, s.blobSyncer)
 	err := handler.Handle(context.Background(), &bindings.TaikoL1ClientTransitionProved{
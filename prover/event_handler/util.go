@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -30,8 +31,8 @@ func isBlockVerified(ctx context.Context, rpc *rpc.Client, id *big.Int) (bool, e
 	return id.Uint64() <= stateVars.B.LastVerifiedBlockId, nil
 }
 
-// isValidProof checks if the given proof is a valid one, comparing to current L2 node canonical chain.
-func isValidProof(
+// IsValidProof checks if the given proof is a valid one, comparing to current L2 node canonical chain.
+func IsValidProof(
 	ctx context.Context,
 	rpc *rpc.Client,
 	blockID *big.Int,
@@ -68,8 +69,8 @@ func getProvingWindow(
 	return 0, errTierNotFound
 }
 
-// getBlockProposedEventFromBlockID fetches the BlockProposed event by the given block id.
-func getBlockProposedEventFromBlockID(
+// GetBlockProposedEventFromBlockID fetches the BlockProposed event by the given block id.
+func GetBlockProposedEventFromBlockID(
 	ctx context.Context,
 	rpc *rpc.Client,
 	id *big.Int,
@@ -120,7 +121,7 @@ func getMetadataFromBlockID(
 	id *big.Int,
 	proposedIn *big.Int,
 ) (*bindings.TaikoDataBlockMetadata, error) {
-	e, err := getBlockProposedEventFromBlockID(ctx, rpc, id, proposedIn)
+	e, err := GetBlockProposedEventFromBlockID(ctx, rpc, id, proposedIn)
 	if err != nil {
 		return nil, err
 	}
@@ -146,3 +147,13 @@ func isProvingWindowExpired(
 
 	return now > expiredAt, time.Unix(int64(expiredAt), 0), time.Duration(expiredAt-now) * time.Second, nil
 }
+
+// proverAddressOf returns the address of the current prover identity which the given assigned prover is,
+// or the primary prover address if the block is not assigned to any of the current prover identities.
+func proverAddressOf(proverAddresses []common.Address, assignedProver common.Address) common.Address {
+	if slices.Contains(proverAddresses, assignedProver) {
+		return assignedProver
+	}
+
+	return proverAddresses[0]
+}
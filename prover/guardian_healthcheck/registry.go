@@ -0,0 +1,69 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// registryCacheTTL is the duration for which the loaded guardians are cached.
+var registryCacheTTL = 1 * time.Minute
+
+// guardianRegistry returns the current guardians, and their IDs.
+type guardianRegistry interface {
+	Guardians(ctx context.Context) (map[common.Address]uint64, error)
+}
+
+// contractRegistry loads the current guardians from the GuardianProver contracts.
+type contractRegistry struct {
+	contracts []*bindings.GuardianProver
+	guardians map[common.Address]uint64
+	updatedAt time.Time
+	mutex     sync.Mutex
+}
+
+// Guardians implements the guardianRegistry interface, a guardian of several contracts gets the ID in
+// the first contract.
+func (r *contractRegistry) Guardians(ctx context.Context) (map[common.Address]uint64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.guardians != nil && time.Since(r.updatedAt) < registryCacheTTL {
+		return r.guardians, nil
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	guardians := make(map[common.Address]uint64)
+	for _, contract := range r.contracts {
+		numGuardians, err := contract.NumGuardians(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get NumGuardians: %w", err)
+		}
+		for i := uint64(0); i < numGuardians.Uint64(); i++ {
+			address, err := contract.Guardians(opts, new(big.Int).SetUint64(i))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get guardian %d: %w", i, err)
+			}
+			if _, ok := guardians[address]; ok {
+				continue
+			}
+			id, err := contract.GuardianIds(opts, address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get guardian ID of %s: %w", address, err)
+			}
+			guardians[address] = id.Uint64()
+		}
+	}
+
+	r.guardians = guardians
+	r.updatedAt = time.Now()
+
+	return guardians, nil
+}
@@ -0,0 +1,362 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/labstack/echo/v4"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// healthCheckReq is the request body sent by a guardian prover with each heartbeat.
+type healthCheckReq struct {
+	ProverAddress      string `json:"prover"`
+	HeartBeatSignature []byte `json:"heartBeatSignature"`
+	LatestL1Block      uint64 `json:"latestL1Block"`
+	LatestL2Block      uint64 `json:"latestL2Block"`
+}
+
+// signedBlockReq is the request body sent by a guardian prover when it signs a block.
+type signedBlockReq struct {
+	BlockID   uint64         `json:"blockID"`
+	BlockHash string         `json:"blockHash"`
+	Signature []byte         `json:"signature"`
+	Prover    common.Address `json:"proverAddress"`
+}
+
+// startupReq is the request body sent by a guardian prover when it starts up.
+type startupReq struct {
+	ProverAddress   string `json:"prover"`
+	GuardianVersion string `json:"guardianVersion"`
+	L1NodeVersion   string `json:"l1NodeVersion"`
+	L2NodeVersion   string `json:"l2NodeVersion"`
+	Revision        string `json:"revision"`
+	Signature       []byte `json:"signature"`
+}
+
+// GuardianLiveness is the liveness of a guardian.
+type GuardianLiveness struct {
+	Address           common.Address `json:"address"`
+	ID                uint64         `json:"id"`
+	Alive             bool           `json:"alive"`
+	Heartbeat         *Heartbeat     `json:"heartbeat,omitempty"`
+	LatestSignedBlock uint64         `json:"latestSignedBlock"`
+}
+
+// VersionReport is the versions reported by the guardians in their startup messages, keyed by the version
+// kind, then by the version.
+type VersionReport struct {
+	Skewed   bool                                   `json:"skewed"`
+	Versions map[string]map[string][]common.Address `json:"versions"`
+	Missing  []common.Address                       `json:"missing"`
+}
+
+// BlockConflict is a block which the guardians signed different hashes of.
+type BlockConflict struct {
+	BlockID uint64                           `json:"blockID"`
+	Hashes  map[common.Hash][]common.Address `json:"hashes"`
+}
+
+// Server is a guardian prover health check server, which receives the heartbeats, startup messages and
+// signed blocks from the guardian provers, and serves their liveness, version skew and signature conflicts.
+type Server struct {
+	echo            *echo.Echo
+	port            uint64
+	registry        guardianRegistry
+	store           *store
+	livenessTimeout time.Duration
+}
+
+// InitFromCli initializes the given health check server based on the command line flags.
+func (s *Server) InitFromCli(ctx context.Context, c *cli.Context) error {
+	if !c.IsSet(flags.GuardianProverMajority.Name) {
+		return errors.New("empty GuardianProverMajority contract address")
+	}
+
+	l1, err := rpc.NewEthClient(ctx, c.String(flags.L1WSEndpoint.Name), c.Duration(flags.RPCTimeout.Name))
+	if err != nil {
+		return err
+	}
+
+	registry := new(contractRegistry)
+	for _, address := range []string{
+		c.String(flags.GuardianProverMajority.Name),
+		c.String(flags.GuardianProverMinority.Name),
+	} {
+		if common.HexToAddress(address) == rpc.ZeroAddress {
+			continue
+		}
+		contract, err := bindings.NewGuardianProver(common.HexToAddress(address), l1)
+		if err != nil {
+			return err
+		}
+		registry.contracts = append(registry.contracts, contract)
+	}
+
+	s.init(
+		registry,
+		c.Uint64(flags.HealthCheckPort.Name),
+		c.Uint64(flags.HealthCheckRetention.Name),
+		c.Duration(flags.HealthCheckLivenessTimeout.Name),
+	)
+
+	return nil
+}
+
+// init initializes the HTTP server with the given guardian registry.
+func (s *Server) init(registry guardianRegistry, port uint64, retention uint64, livenessTimeout time.Duration) {
+	s.echo = echo.New()
+	s.echo.HideBanner = true
+	s.port = port
+	s.registry = registry
+	s.store = newStore(retention)
+	s.livenessTimeout = livenessTimeout
+
+	s.echo.GET("/", s.Health)
+	s.echo.GET("/healthz", s.Health)
+	s.echo.POST("/healthCheck", s.PostHeartbeat)
+	s.echo.POST("/startup", s.PostStartup)
+	s.echo.POST("/signedBlock", s.PostSignedBlock)
+	s.echo.GET("/liveness", s.GetLiveness)
+	s.echo.GET("/versions", s.GetVersions)
+	s.echo.GET("/signedBlocks/conflicts", s.GetConflicts)
+	s.echo.GET("/signedBlocks/:blockID", s.GetSignedBlocks)
+}
+
+// Name returns the application name.
+func (s *Server) Name() string {
+	return "guardian-healthcheck"
+}
+
+// Start starts the HTTP server.
+func (s *Server) Start() error {
+	go func() {
+		if err := s.echo.Start(fmt.Sprintf(":%v", s.port)); !errors.Is(err, http.ErrServerClosed) {
+			log.Crit("Failed to start guardian health check server", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Close shuts down the HTTP server.
+func (s *Server) Close(ctx context.Context) {
+	if err := s.echo.Shutdown(ctx); err != nil {
+		log.Error("Failed to shut down guardian health check server", "error", err)
+	}
+}
+
+// Health endpoints for probes.
+func (s *Server) Health(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+// PostHeartbeat handles a heartbeat of a guardian prover.
+func (s *Server) PostHeartbeat(c echo.Context) error {
+	req := new(healthCheckReq)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	guardian := common.HexToAddress(req.ProverAddress)
+	hash := crypto.Keccak256Hash([]byte("HEART_BEAT"))
+	if err := s.verify(c.Request().Context(), guardian, hash, req.HeartBeatSignature); err != nil {
+		return err
+	}
+
+	s.store.addHeartbeat(guardian, &Heartbeat{
+		ReceivedAt:    time.Now(),
+		LatestL1Block: req.LatestL1Block,
+		LatestL2Block: req.LatestL2Block,
+	})
+
+	return c.NoContent(http.StatusOK)
+}
+
+// PostStartup handles a startup message of a guardian prover.
+func (s *Server) PostStartup(c echo.Context) error {
+	req := new(startupReq)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	guardian := common.HexToAddress(req.ProverAddress)
+	hash := crypto.Keccak256Hash(
+		guardian.Bytes(),
+		[]byte(req.Revision),
+		[]byte(req.GuardianVersion),
+		[]byte(req.L1NodeVersion),
+		[]byte(req.L2NodeVersion),
+	)
+	if err := s.verify(c.Request().Context(), guardian, hash, req.Signature); err != nil {
+		return err
+	}
+
+	s.store.addStartup(guardian, &Startup{
+		ReceivedAt:      time.Now(),
+		GuardianVersion: req.GuardianVersion,
+		L1NodeVersion:   req.L1NodeVersion,
+		L2NodeVersion:   req.L2NodeVersion,
+		Revision:        req.Revision,
+	})
+
+	log.Info(
+		"Guardian prover started",
+		"guardian", guardian,
+		"guardianVersion", req.GuardianVersion,
+		"l1NodeVersion", req.L1NodeVersion,
+		"l2NodeVersion", req.L2NodeVersion,
+	)
+
+	return c.NoContent(http.StatusOK)
+}
+
+// PostSignedBlock handles a block signed by a guardian prover.
+func (s *Server) PostSignedBlock(c echo.Context) error {
+	req := new(signedBlockReq)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	blockHash := common.HexToHash(req.BlockHash)
+	if err := s.verify(c.Request().Context(), req.Prover, blockHash, req.Signature); err != nil {
+		return err
+	}
+
+	s.store.addSignedBlock(&SignedBlock{
+		Guardian:   req.Prover,
+		BlockID:    req.BlockID,
+		BlockHash:  blockHash,
+		Signature:  req.Signature,
+		ReceivedAt: time.Now(),
+	})
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetLiveness handles a query to the liveness of each guardian, a guardian is alive if its latest heartbeat
+// was received within the liveness timeout.
+func (s *Server) GetLiveness(c echo.Context) error {
+	guardians, err := s.guardians(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	liveness := make([]*GuardianLiveness, 0, len(guardians))
+	for _, guardian := range guardians {
+		heartbeat := s.store.heartbeat(guardian.Address)
+		liveness = append(liveness, &GuardianLiveness{
+			Address:           guardian.Address,
+			ID:                guardian.ID,
+			Alive:             heartbeat != nil && time.Since(heartbeat.ReceivedAt) <= s.livenessTimeout,
+			Heartbeat:         heartbeat,
+			LatestSignedBlock: s.store.latestSignedBlock(guardian.Address),
+		})
+	}
+
+	return c.JSON(http.StatusOK, liveness)
+}
+
+// GetVersions handles a query to the versions reported by the guardians, the versions are skewed if the
+// guardians reported different versions of any kind.
+func (s *Server) GetVersions(c echo.Context) error {
+	guardians, err := s.guardians(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	report := &VersionReport{Versions: make(map[string]map[string][]common.Address)}
+	for _, guardian := range guardians {
+		startup := s.store.startup(guardian.Address)
+		if startup == nil {
+			report.Missing = append(report.Missing, guardian.Address)
+			continue
+		}
+		for kind, version := range map[string]string{
+			"guardianVersion": startup.GuardianVersion,
+			"l1NodeVersion":   startup.L1NodeVersion,
+			"l2NodeVersion":   startup.L2NodeVersion,
+			"revision":        startup.Revision,
+		} {
+			if report.Versions[kind] == nil {
+				report.Versions[kind] = make(map[string][]common.Address)
+			}
+			report.Versions[kind][version] = append(report.Versions[kind][version], guardian.Address)
+		}
+	}
+	for _, versions := range report.Versions {
+		report.Skewed = report.Skewed || len(versions) > 1
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetConflicts handles a query to the blocks which the guardians signed different hashes of.
+func (s *Server) GetConflicts(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.store.conflicts())
+}
+
+// GetSignedBlocks handles a query to the signatures of the given block.
+func (s *Server) GetSignedBlocks(c echo.Context) error {
+	blockID, err := strconv.ParseUint(c.Param("blockID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid block ID")
+	}
+
+	return c.JSON(http.StatusOK, s.store.blockSignatures(blockID))
+}
+
+// guardian is a current guardian, and its ID.
+type guardian struct {
+	Address common.Address
+	ID      uint64
+}
+
+// guardians returns the current guardians, sorted by ID.
+func (s *Server) guardians(ctx context.Context) ([]*guardian, error) {
+	registered, err := s.registry.Guardians(ctx)
+	if err != nil {
+		log.Error("Failed to load guardians", "error", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "failed to load guardians")
+	}
+
+	guardians := make([]*guardian, 0, len(registered))
+	for address, id := range registered {
+		guardians = append(guardians, &guardian{Address: address, ID: id})
+	}
+	sort.Slice(guardians, func(i, j int) bool { return guardians[i].ID < guardians[j].ID })
+
+	return guardians, nil
+}
+
+// verify checks whether the given signature of the given hash is signed by the given guardian, and the
+// guardian is a current guardian of the GuardianProver contracts.
+func (s *Server) verify(ctx context.Context, guardian common.Address, hash common.Hash, sig []byte) error {
+	pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil || crypto.PubkeyToAddress(*pubKey) != guardian {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid signature")
+	}
+
+	guardians, err := s.registry.Guardians(ctx)
+	if err != nil {
+		log.Error("Failed to load guardians", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to load guardians")
+	}
+	if _, ok := guardians[guardian]; !ok {
+		return echo.NewHTTPError(http.StatusForbidden, "not a guardian")
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+)
+
+// staticRegistry is a guardian registry with a fixed set of guardians.
+type staticRegistry map[common.Address]uint64
+
+// Guardians implements the guardianRegistry interface.
+func (r staticRegistry) Guardians(_ context.Context) (map[common.Address]uint64, error) {
+	return r, nil
+}
+
+type HealthCheckServerTestSuite struct {
+	suite.Suite
+	s    *Server
+	keys []*ecdsa.PrivateKey
+}
+
+func (s *HealthCheckServerTestSuite) SetupTest() {
+	registry := make(staticRegistry)
+	s.keys = nil
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		s.Nil(err)
+		s.keys = append(s.keys, key)
+		registry[crypto.PubkeyToAddress(key.PublicKey)] = uint64(i + 1)
+	}
+
+	s.s = new(Server)
+	s.s.init(registry, 0, 2, time.Minute)
+}
+
+// post sends the given request to the given route, and returns the response status code.
+func (s *HealthCheckServerTestSuite) post(route string, req interface{}) int {
+	body, err := json.Marshal(req)
+	s.Nil(err)
+
+	httpReq := httptest.NewRequest(http.MethodPost, "/"+route, bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	s.s.echo.ServeHTTP(rec, httpReq)
+
+	return rec.Code
+}
+
+// get sends a GET request to the given route, and decodes the response into the given result.
+func (s *HealthCheckServerTestSuite) get(route string, result interface{}) {
+	rec := httptest.NewRecorder()
+	s.s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, route, nil))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Nil(json.Unmarshal(rec.Body.Bytes(), result))
+}
+
+// signBlock signs the given block hash as the given guardian, in the same way as the guardian prover.
+func (s *HealthCheckServerTestSuite) signBlock(key *ecdsa.PrivateKey, blockID uint64, hash common.Hash) int {
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	s.Nil(err)
+
+	return s.post("signedBlock", &signedBlockReq{
+		BlockID:   blockID,
+		BlockHash: hash.Hex(),
+		Signature: sig,
+		Prover:    crypto.PubkeyToAddress(key.PublicKey),
+	})
+}
+
+// startup sends the startup message with the given version as the given guardian.
+func (s *HealthCheckServerTestSuite) startup(key *ecdsa.PrivateKey, version string) int {
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	sig, err := crypto.Sign(crypto.Keccak256Hash(
+		address.Bytes(),
+		[]byte("rev"),
+		[]byte(version),
+		[]byte("l1"),
+		[]byte("l2"),
+	).Bytes(), key)
+	s.Nil(err)
+
+	return s.post("startup", &startupReq{
+		ProverAddress:   address.Hex(),
+		GuardianVersion: version,
+		L1NodeVersion:   "l1",
+		L2NodeVersion:   "l2",
+		Revision:        "rev",
+		Signature:       sig,
+	})
+}
+
+func (s *HealthCheckServerTestSuite) TestHeartbeat() {
+	sig, err := crypto.Sign(crypto.Keccak256Hash([]byte("HEART_BEAT")).Bytes(), s.keys[0])
+	s.Nil(err)
+	address := crypto.PubkeyToAddress(s.keys[0].PublicKey)
+
+	s.Equal(http.StatusOK, s.post("healthCheck", &healthCheckReq{
+		ProverAddress:      address.Hex(),
+		HeartBeatSignature: sig,
+		LatestL1Block:      10,
+		LatestL2Block:      20,
+	}))
+	// The signature must be signed by the claimed guardian.
+	s.Equal(http.StatusUnauthorized, s.post("healthCheck", &healthCheckReq{
+		ProverAddress:      crypto.PubkeyToAddress(s.keys[1].PublicKey).Hex(),
+		HeartBeatSignature: sig,
+	}))
+
+	var liveness []*GuardianLiveness
+	s.get("/liveness", &liveness)
+	s.Len(liveness, 3)
+	s.Equal(address, liveness[0].Address)
+	s.Equal(uint64(1), liveness[0].ID)
+	s.True(liveness[0].Alive)
+	s.Equal(uint64(20), liveness[0].Heartbeat.LatestL2Block)
+	s.False(liveness[1].Alive)
+	s.Nil(liveness[1].Heartbeat)
+}
+
+func (s *HealthCheckServerTestSuite) TestNotGuardian() {
+	key, err := crypto.GenerateKey()
+	s.Nil(err)
+	s.Equal(http.StatusForbidden, s.signBlock(key, 1, common.HexToHash("0x1")))
+}
+
+func (s *HealthCheckServerTestSuite) TestVersions() {
+	s.Equal(http.StatusOK, s.startup(s.keys[0], "v1"))
+	s.Equal(http.StatusOK, s.startup(s.keys[1], "v1"))
+
+	var report VersionReport
+	s.get("/versions", &report)
+	s.False(report.Skewed)
+	s.Equal([]common.Address{crypto.PubkeyToAddress(s.keys[2].PublicKey)}, report.Missing)
+
+	s.Equal(http.StatusOK, s.startup(s.keys[2], "v2"))
+	s.get("/versions", &report)
+	s.True(report.Skewed)
+	s.Len(report.Versions["guardianVersion"]["v1"], 2)
+	s.Len(report.Versions["guardianVersion"]["v2"], 1)
+}
+
+func (s *HealthCheckServerTestSuite) TestSignedBlocks() {
+	hash, otherHash := common.HexToHash("0x1"), common.HexToHash("0x2")
+	s.Equal(http.StatusOK, s.signBlock(s.keys[0], 1, hash))
+	s.Equal(http.StatusOK, s.signBlock(s.keys[1], 1, hash))
+	s.Equal(http.StatusOK, s.signBlock(s.keys[2], 1, otherHash))
+	s.Equal(http.StatusOK, s.signBlock(s.keys[0], 2, hash))
+
+	var signed []*SignedBlock
+	s.get("/signedBlocks/1", &signed)
+	s.Len(signed, 3)
+
+	var conflicts []*BlockConflict
+	s.get("/signedBlocks/conflicts", &conflicts)
+	s.Len(conflicts, 1)
+	s.Equal(uint64(1), conflicts[0].BlockID)
+	s.Len(conflicts[0].Hashes[hash], 2)
+	s.Equal([]common.Address{crypto.PubkeyToAddress(s.keys[2].PublicKey)}, conflicts[0].Hashes[otherHash])
+
+	// The signatures out of the retention are dropped.
+	s.Equal(http.StatusOK, s.signBlock(s.keys[0], 3, hash))
+	s.get("/signedBlocks/1", &signed)
+	s.Empty(signed)
+	s.get("/signedBlocks/conflicts", &conflicts)
+	s.Empty(conflicts)
+
+	var liveness []*GuardianLiveness
+	s.get("/liveness", &liveness)
+	s.Equal(uint64(3), liveness[0].LatestSignedBlock)
+}
+
+func TestHealthCheckServerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckServerTestSuite))
+}
@@ -0,0 +1,158 @@
+package healthcheck
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Heartbeat is the latest heartbeat received from a guardian.
+type Heartbeat struct {
+	ReceivedAt    time.Time `json:"receivedAt"`
+	LatestL1Block uint64    `json:"latestL1Block"`
+	LatestL2Block uint64    `json:"latestL2Block"`
+}
+
+// Startup is the latest startup message received from a guardian.
+type Startup struct {
+	ReceivedAt      time.Time `json:"receivedAt"`
+	GuardianVersion string    `json:"guardianVersion"`
+	L1NodeVersion   string    `json:"l1NodeVersion"`
+	L2NodeVersion   string    `json:"l2NodeVersion"`
+	Revision        string    `json:"revision"`
+}
+
+// SignedBlock is a block signed by a guardian.
+type SignedBlock struct {
+	Guardian   common.Address `json:"guardian"`
+	BlockID    uint64         `json:"blockID"`
+	BlockHash  common.Hash    `json:"blockHash"`
+	Signature  []byte         `json:"signature"`
+	ReceivedAt time.Time      `json:"receivedAt"`
+}
+
+// store keeps the latest heartbeat and startup message of each guardian, and the signed blocks of the
+// latest blocks in memory.
+type store struct {
+	retention    uint64
+	heartbeats   map[common.Address]*Heartbeat
+	startups     map[common.Address]*Startup
+	signedBlocks map[uint64]map[common.Address]*SignedBlock
+	latestSigned map[common.Address]uint64
+	mutex        sync.RWMutex
+}
+
+// newStore creates a new store instance, which keeps the signed blocks of the given number of latest blocks.
+func newStore(retention uint64) *store {
+	return &store{
+		retention:    retention,
+		heartbeats:   make(map[common.Address]*Heartbeat),
+		startups:     make(map[common.Address]*Startup),
+		signedBlocks: make(map[uint64]map[common.Address]*SignedBlock),
+		latestSigned: make(map[common.Address]uint64),
+	}
+}
+
+// addHeartbeat records the given heartbeat of the given guardian.
+func (s *store) addHeartbeat(guardian common.Address, heartbeat *Heartbeat) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.heartbeats[guardian] = heartbeat
+}
+
+// addStartup records the given startup message of the given guardian.
+func (s *store) addStartup(guardian common.Address, startup *Startup) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.startups[guardian] = startup
+}
+
+// addSignedBlock records the given signed block, and drops the signed blocks out of the retention.
+func (s *store) addSignedBlock(block *SignedBlock) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.signedBlocks[block.BlockID] == nil {
+		s.signedBlocks[block.BlockID] = make(map[common.Address]*SignedBlock)
+	}
+	s.signedBlocks[block.BlockID][block.Guardian] = block
+	if block.BlockID > s.latestSigned[block.Guardian] {
+		s.latestSigned[block.Guardian] = block.BlockID
+	}
+
+	var latest uint64
+	for id := range s.signedBlocks {
+		latest = max(latest, id)
+	}
+	for id := range s.signedBlocks {
+		if id+s.retention <= latest {
+			delete(s.signedBlocks, id)
+		}
+	}
+}
+
+// heartbeat returns the latest heartbeat of the given guardian.
+func (s *store) heartbeat(guardian common.Address) *Heartbeat {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.heartbeats[guardian]
+}
+
+// startup returns the latest startup message of the given guardian.
+func (s *store) startup(guardian common.Address) *Startup {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.startups[guardian]
+}
+
+// latestSignedBlock returns the ID of the latest block signed by the given guardian.
+func (s *store) latestSignedBlock(guardian common.Address) uint64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.latestSigned[guardian]
+}
+
+// blockSignatures returns the signatures of the given block, sorted by guardian.
+func (s *store) blockSignatures(blockID uint64) []*SignedBlock {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	signed := make([]*SignedBlock, 0, len(s.signedBlocks[blockID]))
+	for _, block := range s.signedBlocks[blockID] {
+		signed = append(signed, block)
+	}
+	sort.Slice(signed, func(i, j int) bool { return signed[i].Guardian.Cmp(signed[j].Guardian) < 0 })
+
+	return signed
+}
+
+// conflicts returns the blocks which the guardians signed different hashes of, sorted by block ID.
+func (s *store) conflicts() []*BlockConflict {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	conflicts := make([]*BlockConflict, 0)
+	for id, blocks := range s.signedBlocks {
+		hashes := make(map[common.Hash][]common.Address)
+		for guardian, block := range blocks {
+			hashes[block.BlockHash] = append(hashes[block.BlockHash], guardian)
+		}
+		if len(hashes) <= 1 {
+			continue
+		}
+		for _, guardians := range hashes {
+			sort.Slice(guardians, func(i, j int) bool { return guardians[i].Cmp(guardians[j]) < 0 })
+		}
+		conflicts = append(conflicts, &BlockConflict{BlockID: id, Hashes: hashes})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].BlockID < conflicts[j].BlockID })
+
+	return conflicts
+}
@@ -0,0 +1,401 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// checkInterval is the interval of checking the transitions stuck below quorum.
+var checkInterval = 1 * time.Minute
+
+// Names of the guardian prover contracts.
+const (
+	Majority = "majority"
+	Minority = "minority"
+)
+
+// Guardian is a guardian prover registered in a GuardianProver contract.
+type Guardian struct {
+	ID      uint64         `json:"id"`
+	Address common.Address `json:"address"`
+}
+
+// Approval is the approval status of a transition by the guardians of a GuardianProver contract.
+type Approval struct {
+	Contract      string      `json:"contract"`
+	BlockID       uint64      `json:"blockID"`
+	BlockHash     common.Hash `json:"blockHash"`
+	Approvals     int         `json:"approvals"`
+	MinGuardians  uint32      `json:"minGuardians"`
+	QuorumReached bool        `json:"quorumReached"`
+	Approved      []*Guardian `json:"approved"`
+	Missing       []*Guardian `json:"missing"`
+	FirstSeenAt   time.Time   `json:"firstSeenAt"`
+	Stuck         bool        `json:"stuck"`
+}
+
+// guardianContract is a GuardianProver contract, with its current guardians.
+type guardianContract struct {
+	name         string
+	address      common.Address
+	contract     *bindings.GuardianProver
+	guardians    []*Guardian
+	minGuardians uint32
+}
+
+// transitionKey identifies a transition approved through a GuardianProver contract.
+type transitionKey struct {
+	contract  common.Address
+	blockID   uint64
+	blockHash common.Hash
+}
+
+// approvalState is the tracked approval state of a transition.
+type approvalState struct {
+	approvers     []common.Address
+	quorumReached bool
+	firstSeenAt   time.Time
+	alerted       bool
+}
+
+// Monitor tracks how many guardians have approved each transition through the GuardianProver contracts, and
+// alerts when a transition stays below quorum for too long. The approvals are tracked from the time the
+// monitor starts, until the block is verified.
+type Monitor struct {
+	rpc            *rpc.Client
+	contracts      []*guardianContract
+	stuckThreshold time.Duration
+	approvals      map[transitionKey]*approvalState
+	mutex          sync.RWMutex
+	wg             sync.WaitGroup
+}
+
+// New creates a new Monitor instance for the GuardianProver contracts at the given addresses, the minority
+// contract is optional. A transition which stays below quorum for longer than the given threshold is reported
+// as stuck.
+func New(
+	rpc *rpc.Client,
+	guardianProverMajorityAddress common.Address,
+	guardianProverMinorityAddress common.Address,
+	stuckThreshold time.Duration,
+) *Monitor {
+	m := &Monitor{
+		rpc:            rpc,
+		stuckThreshold: stuckThreshold,
+		approvals:      make(map[transitionKey]*approvalState),
+	}
+
+	if rpc.GuardianProverMajority != nil {
+		m.contracts = append(m.contracts, &guardianContract{
+			name:     Majority,
+			address:  guardianProverMajorityAddress,
+			contract: rpc.GuardianProverMajority,
+		})
+	}
+	if rpc.GuardianProverMinority != nil {
+		m.contracts = append(m.contracts, &guardianContract{
+			name:     Minority,
+			address:  guardianProverMinorityAddress,
+			contract: rpc.GuardianProverMinority,
+		})
+	}
+
+	return m
+}
+
+// Init loads the current guardians, and the quorum of each GuardianProver contract.
+func (m *Monitor) Init(ctx context.Context) error {
+	for _, c := range m.contracts {
+		if err := m.refreshGuardians(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start starts the loop which tracks the guardian approvals with the on-chain events, the loop
+// will be stopped when the given context is done.
+func (m *Monitor) Start(ctx context.Context) {
+	m.wg.Add(1)
+	go m.loop(ctx)
+}
+
+// Wait waits until the monitoring loop exits.
+func (m *Monitor) Wait() {
+	m.wg.Wait()
+}
+
+// loop is the main loop of the monitor.
+func (m *Monitor) loop(ctx context.Context) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	approvalCh := make(chan *bindings.GuardianProverGuardianApproval, 128)
+	guardiansUpdatedCh := make(chan *bindings.GuardianProverGuardiansUpdated, 8)
+	blockVerifiedCh := make(chan *bindings.TaikoL1ClientBlockVerified, 128)
+
+	blockVerifiedSub := rpc.SubscribeBlockVerified(m.rpc.TaikoL1, blockVerifiedCh)
+	defer blockVerifiedSub.Unsubscribe()
+	for _, c := range m.contracts {
+		approvalSub := rpc.SubscribeGuardianApproval(c.contract, approvalCh)
+		guardiansUpdatedSub := rpc.SubscribeGuardiansUpdated(c.contract, guardiansUpdatedCh)
+		defer func() {
+			approvalSub.Unsubscribe()
+			guardiansUpdatedSub.Unsubscribe()
+		}()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-approvalCh:
+			if !e.Raw.Removed {
+				m.recordApproval(e, time.Now())
+			}
+		case e := <-guardiansUpdatedCh:
+			if c := m.contractOf(e.Raw.Address); c != nil && !e.Raw.Removed {
+				if err := m.refreshGuardians(ctx, c); err != nil {
+					log.Error("Failed to refresh guardians", "contract", c.name, "error", err)
+				}
+			}
+		case e := <-blockVerifiedCh:
+			if !e.Raw.Removed {
+				m.pruneUntil(e.BlockId.Uint64())
+			}
+		case <-ticker.C:
+			m.checkStuck(time.Now())
+		}
+	}
+}
+
+// refreshGuardians loads the current guardians, and the quorum of the given GuardianProver contract.
+func (m *Monitor) refreshGuardians(ctx context.Context, c *guardianContract) error {
+	opts := &bind.CallOpts{Context: ctx}
+
+	minGuardians, err := c.contract.MinGuardians(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get MinGuardians from %s guardian prover contract: %w", c.name, err)
+	}
+	numGuardians, err := c.contract.NumGuardians(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get NumGuardians from %s guardian prover contract: %w", c.name, err)
+	}
+
+	guardians := make([]*Guardian, 0, numGuardians.Uint64())
+	for i := uint64(0); i < numGuardians.Uint64(); i++ {
+		address, err := c.contract.Guardians(opts, new(big.Int).SetUint64(i))
+		if err != nil {
+			return fmt.Errorf("failed to get guardian %d from %s guardian prover contract: %w", i, c.name, err)
+		}
+		id, err := c.contract.GuardianIds(opts, address)
+		if err != nil {
+			return fmt.Errorf("failed to get guardian ID of %s: %w", address, err)
+		}
+		guardians = append(guardians, &Guardian{ID: id.Uint64(), Address: address})
+	}
+
+	m.mutex.Lock()
+	c.guardians = guardians
+	c.minGuardians = minGuardians
+	m.mutex.Unlock()
+
+	log.Info(
+		"Guardians loaded",
+		"contract", c.name,
+		"address", c.address,
+		"guardians", len(guardians),
+		"minGuardians", minGuardians,
+	)
+
+	return nil
+}
+
+// contractOf returns the monitored GuardianProver contract with the given address.
+func (m *Monitor) contractOf(address common.Address) *guardianContract {
+	for _, c := range m.contracts {
+		if c.address == address {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// recordApproval records the given guardian approval, the transition reaches quorum once the approval
+// event reports it as approved.
+func (m *Monitor) recordApproval(e *bindings.GuardianProverGuardianApproval, now time.Time) {
+	c := m.contractOf(e.Raw.Address)
+	if c == nil {
+		return
+	}
+
+	metrics.ProverGuardianApprovalCounter.Inc()
+
+	m.mutex.Lock()
+	key := transitionKey{contract: c.address, blockID: e.BlockId.Uint64(), blockHash: e.BlockHash}
+	state, ok := m.approvals[key]
+	if !ok {
+		state = &approvalState{firstSeenAt: now}
+		m.approvals[key] = state
+	}
+	if !slices.Contains(state.approvers, e.Addr) {
+		state.approvers = append(state.approvers, e.Addr)
+	}
+	reached := e.Approved && !state.quorumReached
+	state.quorumReached = state.quorumReached || e.Approved
+	m.mutex.Unlock()
+
+	log.Debug(
+		"Guardian approval received",
+		"contract", c.name,
+		"guardian", e.Addr,
+		"blockID", e.BlockId,
+		"blockHash", common.Hash(e.BlockHash),
+		"approvals", len(state.approvers),
+	)
+	if reached {
+		log.Info(
+			"Guardian quorum reached",
+			"contract", c.name,
+			"blockID", e.BlockId,
+			"blockHash", common.Hash(e.BlockHash),
+			"waited", now.Sub(state.firstSeenAt),
+		)
+	}
+
+	m.updateMetrics(now)
+}
+
+// pruneUntil stops tracking the transitions of all the blocks up to the given verified block.
+func (m *Monitor) pruneUntil(verifiedBlockID uint64) {
+	m.mutex.Lock()
+	for key := range m.approvals {
+		if key.blockID <= verifiedBlockID {
+			delete(m.approvals, key)
+		}
+	}
+	m.mutex.Unlock()
+
+	m.updateMetrics(time.Now())
+}
+
+// checkStuck alerts once for each transition which has stayed below quorum for longer than the threshold.
+func (m *Monitor) checkStuck(now time.Time) {
+	var stuck []*Approval
+
+	m.mutex.Lock()
+	for key, state := range m.approvals {
+		if state.quorumReached || state.alerted || now.Sub(state.firstSeenAt) <= m.stuckThreshold {
+			continue
+		}
+		state.alerted = true
+		stuck = append(stuck, m.approvalOf(key, state, now))
+	}
+	m.mutex.Unlock()
+
+	for _, approval := range stuck {
+		missing := make([]common.Address, 0, len(approval.Missing))
+		for _, g := range approval.Missing {
+			missing = append(missing, g.Address)
+		}
+		log.Warn(
+			"Guardian approvals stuck below quorum",
+			"contract", approval.Contract,
+			"blockID", approval.BlockID,
+			"blockHash", approval.BlockHash,
+			"approvals", approval.Approvals,
+			"minGuardians", approval.MinGuardians,
+			"waiting", now.Sub(approval.FirstSeenAt),
+			"missing", missing,
+		)
+	}
+
+	m.updateMetrics(now)
+}
+
+// Approvals returns the approval status of the tracked transitions, of the given block if it is not nil,
+// sorted by block ID.
+func (m *Monitor) Approvals(blockID *uint64) []*Approval {
+	return m.approvalsAt(blockID, time.Now())
+}
+
+// approvalsAt returns the approval status of the tracked transitions at the given time.
+func (m *Monitor) approvalsAt(blockID *uint64, now time.Time) []*Approval {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	approvals := make([]*Approval, 0, len(m.approvals))
+	for key, state := range m.approvals {
+		if blockID == nil || key.blockID == *blockID {
+			approvals = append(approvals, m.approvalOf(key, state, now))
+		}
+	}
+
+	sort.Slice(approvals, func(i, j int) bool {
+		if approvals[i].BlockID != approvals[j].BlockID {
+			return approvals[i].BlockID < approvals[j].BlockID
+		}
+		return approvals[i].Contract < approvals[j].Contract
+	})
+
+	return approvals
+}
+
+// approvalOf returns the approval status of the given tracked transition at the given time, the caller
+// should hold the mutex.
+func (m *Monitor) approvalOf(key transitionKey, state *approvalState, now time.Time) *Approval {
+	c := m.contractOf(key.contract)
+
+	approval := &Approval{
+		Contract:      c.name,
+		BlockID:       key.blockID,
+		BlockHash:     key.blockHash,
+		Approvals:     len(state.approvers),
+		MinGuardians:  c.minGuardians,
+		QuorumReached: state.quorumReached,
+		FirstSeenAt:   state.firstSeenAt,
+		Stuck:         !state.quorumReached && now.Sub(state.firstSeenAt) > m.stuckThreshold,
+	}
+	for _, g := range c.guardians {
+		if slices.Contains(state.approvers, g.Address) {
+			approval.Approved = append(approval.Approved, g)
+		} else {
+			approval.Missing = append(approval.Missing, g)
+		}
+	}
+
+	return approval
+}
+
+// updateMetrics updates the numbers of the transitions below quorum, and stuck below quorum.
+func (m *Monitor) updateMetrics(now time.Time) {
+	var belowQuorum, stuck int
+	for _, approval := range m.approvalsAt(nil, now) {
+		if !approval.QuorumReached {
+			belowQuorum++
+		}
+		if approval.Stuck {
+			stuck++
+		}
+	}
+
+	metrics.ProverGuardianBelowQuorumGauge.Set(float64(belowQuorum))
+	metrics.ProverGuardianStuckGauge.Set(float64(stuck))
+}
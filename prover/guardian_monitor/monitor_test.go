@@ -0,0 +1,117 @@
+package monitor
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+type GuardianMonitorTestSuite struct {
+	suite.Suite
+	monitor   *Monitor
+	majority  common.Address
+	guardians []*Guardian
+	now       time.Time
+}
+
+func (s *GuardianMonitorTestSuite) SetupTest() {
+	s.majority = common.BytesToAddress([]byte{1})
+	s.guardians = []*Guardian{
+		{ID: 1, Address: common.BytesToAddress([]byte{11})},
+		{ID: 2, Address: common.BytesToAddress([]byte{12})},
+		{ID: 3, Address: common.BytesToAddress([]byte{13})},
+	}
+	s.now = time.Unix(1_700_000_000, 0)
+	s.monitor = &Monitor{
+		contracts: []*guardianContract{
+			{name: Majority, address: s.majority, guardians: s.guardians, minGuardians: 2},
+		},
+		stuckThreshold: 10 * time.Minute,
+		approvals:      make(map[transitionKey]*approvalState),
+	}
+}
+
+// approve records an approval of the given block by the given guardian.
+func (s *GuardianMonitorTestSuite) approve(guardian *Guardian, blockID uint64, approved bool, at time.Time) {
+	s.monitor.recordApproval(&bindings.GuardianProverGuardianApproval{
+		Addr:      guardian.Address,
+		BlockId:   new(big.Int).SetUint64(blockID),
+		BlockHash: common.BytesToHash([]byte{byte(blockID)}),
+		Approved:  approved,
+		Raw:       types.Log{Address: s.majority},
+	}, at)
+}
+
+func (s *GuardianMonitorTestSuite) TestApprovals() {
+	s.approve(s.guardians[0], 1, false, s.now)
+	s.approve(s.guardians[0], 1, false, s.now)
+	s.approve(s.guardians[0], 2, false, s.now)
+	s.approve(s.guardians[2], 2, true, s.now)
+
+	approvals := s.monitor.approvalsAt(nil, s.now)
+	s.Len(approvals, 2)
+
+	s.Equal(uint64(1), approvals[0].BlockID)
+	s.Equal(Majority, approvals[0].Contract)
+	s.Equal(1, approvals[0].Approvals)
+	s.Equal(uint32(2), approvals[0].MinGuardians)
+	s.False(approvals[0].QuorumReached)
+	s.Equal([]*Guardian{s.guardians[0]}, approvals[0].Approved)
+	s.Equal([]*Guardian{s.guardians[1], s.guardians[2]}, approvals[0].Missing)
+
+	s.Equal(2, approvals[1].Approvals)
+	s.True(approvals[1].QuorumReached)
+	s.Equal([]*Guardian{s.guardians[1]}, approvals[1].Missing)
+
+	blockID := uint64(2)
+	s.Len(s.monitor.approvalsAt(&blockID, s.now), 1)
+
+	// Approvals through an unknown contract are ignored.
+	s.monitor.recordApproval(&bindings.GuardianProverGuardianApproval{
+		Addr:    s.guardians[0].Address,
+		BlockId: big.NewInt(3),
+		Raw:     types.Log{Address: common.BytesToAddress([]byte{2})},
+	}, s.now)
+	s.Len(s.monitor.approvalsAt(nil, s.now), 2)
+}
+
+func (s *GuardianMonitorTestSuite) TestStuck() {
+	s.approve(s.guardians[0], 1, false, s.now)
+	s.approve(s.guardians[0], 2, false, s.now)
+	s.approve(s.guardians[1], 2, true, s.now)
+
+	s.False(s.monitor.approvalsAt(nil, s.now.Add(5*time.Minute))[0].Stuck)
+
+	later := s.now.Add(11 * time.Minute)
+	approvals := s.monitor.approvalsAt(nil, later)
+	s.True(approvals[0].Stuck)
+	s.False(approvals[1].Stuck)
+
+	// A stuck transition is alerted only once.
+	s.monitor.checkStuck(later)
+	for key, state := range s.monitor.approvals {
+		s.Equal(key.blockID == 1, state.alerted)
+	}
+}
+
+func (s *GuardianMonitorTestSuite) TestPruneUntil() {
+	s.approve(s.guardians[0], 1, false, s.now)
+	s.approve(s.guardians[0], 2, false, s.now)
+	s.approve(s.guardians[0], 3, false, s.now)
+
+	s.monitor.pruneUntil(2)
+
+	approvals := s.monitor.approvalsAt(nil, s.now)
+	s.Len(approvals, 1)
+	s.Equal(uint64(3), approvals[0].BlockID)
+}
+
+func TestGuardianMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(GuardianMonitorTestSuite))
+}
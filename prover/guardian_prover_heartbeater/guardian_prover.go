@@ -3,6 +3,8 @@ package guardianproverheartbeater
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/url"
@@ -42,27 +44,74 @@ type startupReq struct {
 	Signature       []byte `json:"signature"`
 }
 
+// errRejected is returned when the health check server rejects a request, retrying the request won't help.
+var errRejected = errors.New("request rejected by health check server")
+
 // GuardianProverHeartBeater is responsible for signing and sending known blocks to the health check server.
 type GuardianProverHeartBeater struct {
 	privateKey                *ecdsa.PrivateKey
 	healthCheckServerEndpoint *url.URL
 	rpc                       *rpc.Client
 	proverAddress             common.Address
+	outbox                    *outbox
 }
 
-// New creates a new GuardianProverBlockSender instance.
+// New creates a new GuardianProverBlockSender instance. The signed blocks and heartbeats are queued in an
+// outbox of the given size, and persisted to the given file, empty means no persistence.
 func New(
 	privateKey *ecdsa.PrivateKey,
 	healthCheckServerEndpoint *url.URL,
 	rpc *rpc.Client,
 	proverAddress common.Address,
-) *GuardianProverHeartBeater {
-	return &GuardianProverHeartBeater{
+	outboxFile string,
+	outboxSize int,
+) (*GuardianProverHeartBeater, error) {
+	s := &GuardianProverHeartBeater{
 		privateKey:                privateKey,
 		healthCheckServerEndpoint: healthCheckServerEndpoint,
 		rpc:                       rpc,
 		proverAddress:             proverAddress,
 	}
+
+	var err error
+	if s.outbox, err = newOutbox(outboxFile, outboxSize, s.deliver); err != nil {
+		return nil, fmt.Errorf("failed to load guardian prover outbox: %w", err)
+	}
+
+	return s, nil
+}
+
+// Start starts delivering the queued signed blocks and heartbeats to the health check server, the delivery
+// will be stopped when the given context is done.
+func (s *GuardianProverHeartBeater) Start(ctx context.Context) {
+	s.outbox.start(ctx)
+}
+
+// Wait waits until the delivery stops.
+func (s *GuardianProverHeartBeater) Wait() {
+	s.outbox.wait()
+}
+
+// deliver sends the given queued message to the health check server, the message is dropped if it is
+// rejected by the server.
+func (s *GuardianProverHeartBeater) deliver(ctx context.Context, route string, body json.RawMessage) error {
+	err := s.post(ctx, route, body)
+	if errors.Is(err, errRejected) {
+		log.Error("Guardian prover message rejected, dropping it", "route", route, "error", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if route == routeSignedBlock {
+		var req signedBlockReq
+		if err := json.Unmarshal(body, &req); err == nil {
+			log.Info("Guardian prover successfully sent signed block", "blockID", req.BlockID)
+		}
+	}
+
+	return nil
 }
 
 // post sends the given POST request to the health check server.
@@ -77,6 +126,9 @@ func (s *GuardianProverHeartBeater) post(ctx context.Context, route string, req
 		return err
 	}
 
+	if resp.StatusCode() >= 400 && resp.StatusCode() < 500 {
+		return fmt.Errorf("%w, status code: %v", errRejected, resp.StatusCode())
+	}
 	if !resp.IsSuccess() {
 		return fmt.Errorf(
 			"unable to contact health check server endpoint, status code: %v",
@@ -87,22 +139,14 @@ func (s *GuardianProverHeartBeater) post(ctx context.Context, route string, req
 	return nil
 }
 
-// SignAndSendBlock signs the given block and sends it to the health check server.
+// SignAndSendBlock signs the given block and queues it to be sent to the health check server.
 func (s *GuardianProverHeartBeater) SignAndSendBlock(ctx context.Context, blockID *big.Int) error {
 	signed, header, err := s.signBlock(ctx, blockID)
 	if err != nil {
-		return nil
-	}
-
-	if signed == nil {
-		return nil
-	}
-
-	if err := s.sendSignedBlockReq(ctx, signed, header.Hash(), blockID); err != nil {
 		return err
 	}
 
-	return nil
+	return s.sendSignedBlockReq(signed, header.Hash(), blockID)
 }
 
 // SendStartupMessage sends the startup message to the health check server.
@@ -151,9 +195,8 @@ func (s *GuardianProverHeartBeater) SendStartupMessage(
 	return nil
 }
 
-// sendSignedBlockReq is the actual method that sends the signed block to the health check server.
+// sendSignedBlockReq is the actual method that queues the signed block to be sent to the health check server.
 func (s *GuardianProverHeartBeater) sendSignedBlockReq(
-	ctx context.Context,
 	signed []byte,
 	hash common.Hash,
 	blockID *big.Int,
@@ -170,7 +213,7 @@ func (s *GuardianProverHeartBeater) sendSignedBlockReq(
 		Prover:    s.proverAddress,
 	}
 
-	if err := s.post(ctx, "signedBlock", req); err != nil {
+	if err := s.outbox.push(routeSignedBlock, req); err != nil {
 		return err
 	}
 
@@ -224,9 +267,9 @@ func (s *GuardianProverHeartBeater) signBlock(ctx context.Context, blockID *big.
 	return signed, header, nil
 }
 
-// SendHeartbeat sends a heartbeat to the health check server.
+// SendHeartbeat queues a heartbeat to be sent to the health check server, it supersedes the queued heartbeat.
 func (s *GuardianProverHeartBeater) SendHeartbeat(
-	ctx context.Context,
+	_ context.Context,
 	latestL1Block uint64,
 	latestL2Block uint64,
 ) error {
@@ -242,11 +285,11 @@ func (s *GuardianProverHeartBeater) SendHeartbeat(
 		LatestL2Block:      latestL2Block,
 	}
 
-	if err := s.post(ctx, "healthCheck", req); err != nil {
+	if err := s.outbox.push(routeHealthCheck, req); err != nil {
 		return err
 	}
 
-	log.Info("Successfully sent heartbeat", "signature", common.Bytes2Hex(sig))
+	log.Debug("Heartbeat queued", "signature", common.Bytes2Hex(sig))
 
 	return nil
 }
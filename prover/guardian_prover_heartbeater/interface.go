@@ -27,4 +27,7 @@ type Heartbeater interface {
 type BlockSenderHeartbeater interface {
 	BlockSigner
 	Heartbeater
+	// Start starts delivering the signed blocks and heartbeats, until the given context is done.
+	Start(ctx context.Context)
+	Wait()
 }
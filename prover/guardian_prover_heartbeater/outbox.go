@@ -0,0 +1,230 @@
+package guardianproverheartbeater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+)
+
+// Routes of the health check server, which the queued messages are sent to.
+const (
+	routeSignedBlock = "signedBlock"
+	routeHealthCheck = "healthCheck"
+)
+
+// maxDeliveryInterval is the maximum interval of retrying the delivery while the health check server
+// is unreachable.
+var maxDeliveryInterval = 1 * time.Minute
+
+// message is a message queued in the outbox, waiting to be sent to the health check server.
+type message struct {
+	Route     string          `json:"route"`
+	Body      json.RawMessage `json:"body"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// outbox is a bounded queue of the messages to the health check server, which keeps retrying to send the
+// messages in order, so the blocks signed while the server is unreachable are backfilled once it is back.
+// Only the latest heartbeat is kept, and the oldest message is dropped when the queue is full.
+type outbox struct {
+	file     string
+	size     int
+	send     func(ctx context.Context, route string, body json.RawMessage) error
+	messages []*message
+	notifyCh chan struct{}
+	mutex    sync.Mutex
+	wg       sync.WaitGroup
+}
+
+// newOutbox creates a new outbox instance, which keeps at most the given number of messages, and persists
+// them to the given file, empty means no persistence. The given send function sends a message to the health
+// check server.
+func newOutbox(
+	file string,
+	size int,
+	send func(ctx context.Context, route string, body json.RawMessage) error,
+) (*outbox, error) {
+	o := &outbox{file: file, size: size, send: send, notifyCh: make(chan struct{}, 1)}
+	if err := o.load(); err != nil {
+		return nil, err
+	}
+	if len(o.messages) != 0 {
+		log.Info("Guardian prover outbox loaded", "messages", len(o.messages))
+	}
+	o.updateMetrics()
+
+	return o, nil
+}
+
+// push queues the given request to be sent to the given route of the health check server.
+func (o *outbox) push(route string, req interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	o.mutex.Lock()
+	// A newer heartbeat supersedes the queued one.
+	if route == routeHealthCheck {
+		o.remove(func(m *message) bool { return m.Route == routeHealthCheck })
+	}
+	for len(o.messages) >= o.size && len(o.messages) != 0 {
+		dropped := o.messages[0]
+		o.messages = o.messages[1:]
+		metrics.ProverGuardianOutboxDroppedCounter.Inc()
+		log.Warn("Guardian prover outbox full, message dropped", "route", dropped.Route, "createdAt", dropped.CreatedAt)
+	}
+	o.messages = append(o.messages, &message{Route: route, Body: body, CreatedAt: time.Now()})
+	o.persist()
+	o.mutex.Unlock()
+
+	o.updateMetrics()
+
+	select {
+	case o.notifyCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// start starts the loop which delivers the queued messages, the loop will be stopped when the given
+// context is done.
+func (o *outbox) start(ctx context.Context) {
+	o.wg.Add(1)
+	go o.loop(ctx)
+}
+
+// wait waits until the delivery loop exits.
+func (o *outbox) wait() {
+	o.wg.Wait()
+}
+
+// loop is the main loop of the outbox, it retries with an exponential backoff while the health check
+// server is unreachable.
+func (o *outbox) loop(ctx context.Context) {
+	defer o.wg.Done()
+
+	retry := backoff.NewExponentialBackOff()
+	retry.MaxInterval = maxDeliveryInterval
+	retry.MaxElapsedTime = 0
+
+	for {
+		var wait <-chan time.Time
+		if err := o.deliver(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			metrics.ProverGuardianDeliveryFailedCounter.Inc()
+			interval := retry.NextBackOff()
+			log.Warn("Failed to deliver guardian prover messages, retrying", "retryIn", interval, "error", err)
+			wait = time.After(interval)
+		} else {
+			retry.Reset()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wait:
+		case <-o.notifyCh:
+		}
+	}
+}
+
+// deliver sends the queued messages in order, until the queue is empty, or a message fails to be sent.
+func (o *outbox) deliver(ctx context.Context) error {
+	for {
+		o.mutex.Lock()
+		if len(o.messages) == 0 {
+			o.mutex.Unlock()
+			return nil
+		}
+		next := o.messages[0]
+		o.mutex.Unlock()
+
+		if err := o.send(ctx, next.Route, next.Body); err != nil {
+			return err
+		}
+
+		o.mutex.Lock()
+		o.remove(func(m *message) bool { return m == next })
+		o.persist()
+		o.mutex.Unlock()
+
+		metrics.ProverGuardianDeliveryLagGauge.Set(time.Since(next.CreatedAt).Seconds())
+		o.updateMetrics()
+	}
+}
+
+// remove removes the queued messages matching the given function, the caller must hold the mutex.
+func (o *outbox) remove(match func(m *message) bool) {
+	messages := o.messages[:0]
+	for _, m := range o.messages {
+		if !match(m) {
+			messages = append(messages, m)
+		}
+	}
+	o.messages = messages
+}
+
+// len returns the number of the queued messages.
+func (o *outbox) len() int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return len(o.messages)
+}
+
+// updateMetrics updates the number of the queued messages.
+func (o *outbox) updateMetrics() {
+	metrics.ProverGuardianOutboxGauge.Set(float64(o.len()))
+}
+
+// load loads the persisted messages from the outbox file.
+func (o *outbox) load() error {
+	if o.file == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(o.file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	return json.Unmarshal(data, &o.messages)
+}
+
+// persist writes the queued messages to the outbox file, the caller must hold the mutex.
+func (o *outbox) persist() {
+	if o.file == "" {
+		return
+	}
+
+	data, err := json.Marshal(o.messages)
+	if err != nil {
+		log.Error("Failed to marshal guardian prover outbox", "error", err)
+		return
+	}
+
+	// Write to a temporary file at first, so the outbox file will never be partially written.
+	tmp := o.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		log.Error("Failed to write guardian prover outbox", "error", err)
+		return
+	}
+	if err := os.Rename(tmp, o.file); err != nil {
+		log.Error("Failed to write guardian prover outbox", "error", err)
+	}
+}
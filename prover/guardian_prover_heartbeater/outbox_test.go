@@ -0,0 +1,117 @@
+package guardianproverheartbeater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxTestSuite struct {
+	suite.Suite
+	file    string
+	sent    []uint64
+	failing bool
+	mutex   sync.Mutex
+}
+
+func (s *OutboxTestSuite) SetupTest() {
+	s.file = filepath.Join(s.T().TempDir(), "outbox.json")
+	s.sent = nil
+	s.failing = false
+}
+
+// send records the block IDs of the delivered messages, or fails while the server is down.
+func (s *OutboxTestSuite) send(_ context.Context, route string, body json.RawMessage) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failing {
+		return errors.New("server down")
+	}
+	if route == routeSignedBlock {
+		var req signedBlockReq
+		s.Nil(json.Unmarshal(body, &req))
+		s.sent = append(s.sent, req.BlockID)
+	}
+
+	return nil
+}
+
+func (s *OutboxTestSuite) setFailing(failing bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failing = failing
+}
+
+func (s *OutboxTestSuite) sentBlocks() []uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]uint64{}, s.sent...)
+}
+
+func (s *OutboxTestSuite) TestPush() {
+	o, err := newOutbox(s.file, 3, s.send)
+	s.Nil(err)
+
+	s.Nil(o.push(routeHealthCheck, &healthCheckReq{LatestL1Block: 1}))
+	s.Nil(o.push(routeSignedBlock, &signedBlockReq{BlockID: 1}))
+	s.Nil(o.push(routeHealthCheck, &healthCheckReq{LatestL1Block: 2}))
+	s.Equal(2, o.len())
+
+	// The oldest message is dropped once the outbox is full.
+	s.Nil(o.push(routeSignedBlock, &signedBlockReq{BlockID: 2}))
+	s.Nil(o.push(routeSignedBlock, &signedBlockReq{BlockID: 3}))
+	s.Equal(3, o.len())
+	s.Equal(routeHealthCheck, o.messages[0].Route)
+
+	// The queued messages are persisted across restarts.
+	o, err = newOutbox(s.file, 3, s.send)
+	s.Nil(err)
+	s.Equal(3, o.len())
+
+	s.Nil(o.deliver(context.Background()))
+	s.Equal([]uint64{2, 3}, s.sentBlocks())
+	s.Zero(o.len())
+
+	o, err = newOutbox(s.file, 3, s.send)
+	s.Nil(err)
+	s.Zero(o.len())
+}
+
+func (s *OutboxTestSuite) TestBackfill() {
+	defer func(interval time.Duration) { maxDeliveryInterval = interval }(maxDeliveryInterval)
+	maxDeliveryInterval = 10 * time.Millisecond
+
+	o, err := newOutbox("", 10, s.send)
+	s.Nil(err)
+
+	s.setFailing(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	o.start(ctx)
+
+	for i := uint64(1); i <= 3; i++ {
+		s.Nil(o.push(routeSignedBlock, &signedBlockReq{BlockID: i}))
+	}
+	time.Sleep(50 * time.Millisecond)
+	s.Empty(s.sentBlocks())
+	s.Equal(3, o.len())
+
+	// The blocks signed while the server is down are delivered in order once it is back.
+	s.setFailing(false)
+	s.Eventually(func() bool { return o.len() == 0 }, time.Second, 10*time.Millisecond)
+	s.Equal([]uint64{1, 2, 3}, s.sentBlocks())
+
+	cancel()
+	o.wait()
+}
+
+func TestOutboxTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxTestSuite))
+}
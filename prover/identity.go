@@ -0,0 +1,69 @@
+package prover
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
+)
+
+// proverIdentity is an additional prover identity, which proves the blocks assigned to it, and submits
+// their proofs with its own key.
+type proverIdentity struct {
+	txmgr           *txmgr.SimpleTxManager
+	proofSubmitters []proofSubmitter.Submitter
+}
+
+// initIdentities initializes the transaction managers of the additional prover identities, the provers of the
+// additional L2 chains share the ones of the primary chain prover.
+func (p *Prover) initIdentities() error {
+	if p.parent != nil {
+		for _, identity := range p.parent.identities {
+			p.identities = append(p.identities, &proverIdentity{txmgr: identity.txmgr})
+		}
+		return nil
+	}
+
+	for _, identity := range p.cfg.Identities {
+		cfg := *p.cfg.TxmgrConfigs
+		cfg.PrivateKey = common.Bytes2Hex(crypto.FromECDSA(identity.PrivateKey))
+
+		txMgr, err := txmgr.NewSimpleTxManager("prover", log.Root(), &metrics.TxMgrMetrics, cfg)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Prover identity initialized", "address", txMgr.From(), "capacity", identity.Capacity)
+		p.identities = append(p.identities, &proverIdentity{txmgr: txMgr})
+	}
+
+	return nil
+}
+
+// proverAddresses returns the addresses of all the current prover identities, the primary one comes first.
+func (p *Prover) proverAddresses() []common.Address {
+	addresses := []common.Address{p.ProverAddress()}
+	for _, identity := range p.identities {
+		addresses = append(addresses, identity.txmgr.From())
+	}
+
+	return addresses
+}
+
+// submittersOf returns the proof submitters of the given prover identity, the primary identity's submitters
+// are returned if the given address is not a current prover identity.
+func (p *Prover) submittersOf(prover common.Address) []proofSubmitter.Submitter {
+	p.submittersMutex.RLock()
+	defer p.submittersMutex.RUnlock()
+
+	for _, identity := range p.identities {
+		if identity.txmgr.From() == prover {
+			return identity.proofSubmitters
+		}
+	}
+
+	return p.proofSubmitters
+}
@@ -14,16 +14,18 @@ import (
 
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/internal/utils"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
 	handler "github.com/taikoxyz/taiko-client/prover/event_handler"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
 	"github.com/taikoxyz/taiko-client/prover/proof_submitter/transaction"
+	verifier "github.com/taikoxyz/taiko-client/prover/sgx_verifier"
 )
 
 // setApprovalAmount will set the allowance on the TaikoToken contract for the
-// configured proverAddress as owner and the contract as spender,
+// prover identity of the given transactions manager as owner and the contract as spender,
 // if `--prover.allowance` flag is provided for allowance.
-func (p *Prover) setApprovalAmount(ctx context.Context, contract common.Address) error {
+func (p *Prover) setApprovalAmount(ctx context.Context, txMgr *txmgr.SimpleTxManager, contract common.Address) error {
 	// Skip setting approval amount if `--prover.allowance` flag is not set.
 	if p.cfg.Allowance == nil || p.cfg.Allowance.Cmp(common.Big0) != 1 {
 		log.Info("Skipping setting approval, `--prover.allowance` flag not set")
@@ -33,14 +35,19 @@ func (p *Prover) setApprovalAmount(ctx context.Context, contract common.Address)
 	// Check the existing allowance for the contract.
 	allowance, err := p.rpc.TaikoToken.Allowance(
 		&bind.CallOpts{Context: ctx},
-		p.ProverAddress(),
+		txMgr.From(),
 		contract,
 	)
 	if err != nil {
 		return err
 	}
 
-	log.Info("Existing allowance for the contract", "allowance", utils.WeiToEther(allowance), "contract", contract)
+	log.Info(
+		"Existing allowance for the contract",
+		"prover", txMgr.From(),
+		"allowance", utils.WeiToEther(allowance),
+		"contract", contract,
+	)
 
 	// If the existing allowance is greater or equal to the configured allowance, skip setting allowance.
 	if allowance.Cmp(p.cfg.Allowance) >= 0 {
@@ -59,7 +66,7 @@ func (p *Prover) setApprovalAmount(ctx context.Context, contract common.Address)
 		return err
 	}
 
-	receipt, err := p.txmgr.Send(ctx, txmgr.TxCandidate{
+	receipt, err := txMgr.Send(ctx, txmgr.TxCandidate{
 		TxData: data,
 		To:     &p.cfg.TaikoTokenAddress,
 	})
@@ -79,7 +86,7 @@ func (p *Prover) setApprovalAmount(ctx context.Context, contract common.Address)
 	// Check the new allowance for the contract.
 	if allowance, err = p.rpc.TaikoToken.Allowance(
 		&bind.CallOpts{Context: ctx},
-		p.ProverAddress(),
+		txMgr.From(),
 		contract,
 	); err != nil {
 		return err
@@ -90,16 +97,52 @@ func (p *Prover) setApprovalAmount(ctx context.Context, contract common.Address)
 	return nil
 }
 
-// initProofSubmitters initializes the proof submitters from the given tiers in protocol.
+// initProofSubmitters initializes the proof submitters of all prover identities from the given tiers in protocol,
+// the given transactions manager is used by the primary identity. The submitters of all identities and the tiers
+// are swapped in at once, so that they can also be replaced while the prover is running.
 func (p *Prover) initProofSubmitters(
 	txmgr *txmgr.SimpleTxManager,
 	txBuilder *transaction.ProveBlockTxBuilder,
+	tiers []*rpc.TierProviderTierWithID,
 ) error {
-	for _, tier := range p.sharedState.GetTiers() {
+	submitters, err := p.newProofSubmitters(txmgr, txBuilder, tiers)
+	if err != nil {
+		return err
+	}
+
+	identitySubmitters := make([][]proofSubmitter.Submitter, len(p.identities))
+	for i, identity := range p.identities {
+		if identitySubmitters[i], err = p.newProofSubmitters(identity.txmgr, txBuilder, tiers); err != nil {
+			return err
+		}
+	}
+
+	p.submittersMutex.Lock()
+	defer p.submittersMutex.Unlock()
+
+	p.sharedState.SetTiers(tiers)
+	p.proofSubmitters = submitters
+	for i, identity := range p.identities {
+		identity.proofSubmitters = identitySubmitters[i]
+	}
+
+	return nil
+}
+
+// newProofSubmitters creates the proof submitters from the given tiers in protocol, which submit the proofs
+// with the given transactions manager, the unsupported tiers are skipped.
+func (p *Prover) newProofSubmitters(
+	txmgr *txmgr.SimpleTxManager,
+	txBuilder *transaction.ProveBlockTxBuilder,
+	tiers []*rpc.TierProviderTierWithID,
+) ([]proofSubmitter.Submitter, error) {
+	var submitters []proofSubmitter.Submitter
+	for _, tier := range tiers {
 		var (
-			producer  proofProducer.ProofProducer
-			submitter proofSubmitter.Submitter
-			err       error
+			producer    proofProducer.ProofProducer
+			submitter   proofSubmitter.Submitter
+			sgxVerifier *verifier.Verifier
+			err         error
 		)
 		switch tier.ID {
 		case encoding.TierOptimisticID:
@@ -113,6 +156,12 @@ func (p *Prover) initProofSubmitters(
 				ProofType:         proofProducer.ProofTypeSgx,
 				Dummy:             p.cfg.Dummy,
 			}
+			// Verify the SGX proofs locally before submission, dummy proofs can not be verified.
+			if !p.cfg.Dummy {
+				if sgxVerifier, err = p.sgxVerifier(tier.VerifierName); err != nil {
+					return nil, err
+				}
+			}
 		case encoding.TierGuardianMinorityID:
 			producer = proofProducer.NewGuardianProofProducer(&proofProducer.SGXProofProducer{
 				RaikoHostEndpoint: p.cfg.RaikoHostEndpoint,
@@ -132,7 +181,11 @@ func (p *Prover) initProofSubmitters(
 				Dummy:             p.cfg.Dummy,
 			}, encoding.TierGuardianMajorityID, p.cfg.EnableLivenessBondProof)
 		default:
-			return fmt.Errorf("unsupported tier: %d", tier.ID)
+			log.Warn("Unsupported protocol tier, no proof is submitted for it", "id", tier.ID)
+			continue
+		}
+		if p.proofCache != nil {
+			producer = proofProducer.NewDedupProofProducer(producer, p.proofCache)
 		}
 
 		if submitter, err = proofSubmitter.NewProofSubmitter(
@@ -144,14 +197,37 @@ func (p *Prover) initProofSubmitters(
 			p.cfg.ProveBlockGasLimit,
 			txmgr,
 			txBuilder,
+			sgxVerifier,
 		); err != nil {
-			return err
+			return nil, err
 		}
 
-		p.proofSubmitters = append(p.proofSubmitters, submitter)
+		submitters = append(submitters, submitter)
 	}
 
-	return nil
+	return submitters, nil
+}
+
+// sgxVerifier returns the SGX verifier of the given verifier name, which is only created once, and shared by
+// the proof submitters of all prover identities, also after the protocol parameters are reloaded.
+func (p *Prover) sgxVerifier(verifierName [32]byte) (*verifier.Verifier, error) {
+	p.sgxVerifiersMutex.Lock()
+	defer p.sgxVerifiersMutex.Unlock()
+
+	if v, ok := p.sgxVerifiers[verifierName]; ok {
+		return v, nil
+	}
+
+	v, err := verifier.New(p.ctx, p.rpc, verifierName, p.cfg.SgxInstanceExpiryAlert)
+	if err != nil {
+		return nil, err
+	}
+	if p.sgxVerifiers == nil {
+		p.sgxVerifiers = make(map[[32]byte]*verifier.Verifier)
+	}
+	p.sgxVerifiers[verifierName] = v
+
+	return v, nil
 }
 
 // initL1Current initializes prover's L1Current cursor.
@@ -214,17 +290,18 @@ func (p *Prover) initEventHandlers() error {
 	// ------- BlockProposed -------
 	opts := &handler.NewBlockProposedEventHandlerOps{
 		SharedState:           p.sharedState,
-		ProverAddress:         p.ProverAddress(),
+		ProverAddresses:       p.proverAddresses(),
 		GenesisHeightL1:       p.genesisHeightL1,
 		RPC:                   p.rpc,
 		ProofGenerationCh:     p.proofGenerationCh,
-		AssignmentExpiredCh:   p.assignmentExpiredCh,
-		ProofSubmissionCh:     p.proofSubmissionCh,
 		ProofContestCh:        p.proofContestCh,
+		Scheduler:             p.proofScheduler,
 		BackOffRetryInterval:  p.cfg.BackOffRetryInterval,
 		BackOffMaxRetrys:      p.cfg.BackOffMaxRetries,
 		ContesterMode:         p.cfg.ContesterMode,
 		ProveUnassignedBlocks: p.cfg.ProveUnassignedBlocks,
+		ProofCache:            p.proofCache,
+		ContestEngine:         p.contestEngine,
 	}
 	if p.IsGuardianProver() {
 		opts.SubmissionDelay = p.cfg.GuardianProofSubmissionDelay
@@ -242,20 +319,24 @@ func (p *Prover) initEventHandlers() error {
 		p.rpc,
 		p.proofContestCh,
 		p.cfg.ContesterMode,
+		p.contestEngine,
 	)
 	// ------- TransitionContested -------
 	p.transitionContestedHandler = handler.NewTransitionContestedEventHandler(
 		p.rpc,
 		p.proofSubmissionCh,
 		p.cfg.ContesterMode,
+		p.contestEngine,
 	)
 	// ------- AssignmentExpired -------
 	p.assignmentExpiredHandler = handler.NewAssignmentExpiredEventHandler(
 		p.rpc,
-		p.ProverAddress(),
+		p.proverAddresses(),
 		p.proofSubmissionCh,
 		p.proofContestCh,
 		p.cfg.ContesterMode,
+		p.contestEngine,
+		p.proofScheduler,
 	)
 
 	// ------- BlockVerified -------
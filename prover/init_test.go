@@ -35,7 +35,7 @@ func (s *ProverTestSuite) TestSetApprovalAmount() {
 
 	s.p.cfg.Allowance = amt
 
-	s.Nil(s.p.setApprovalAmount(context.Background(), s.p.cfg.AssignmentHookAddress))
+	s.Nil(s.p.setApprovalAmount(context.Background(), s.p.txmgr, s.p.cfg.AssignmentHookAddress))
 
 	allowance, err = s.p.rpc.TaikoToken.Allowance(nil, s.p.ProverAddress(), s.p.cfg.AssignmentHookAddress)
 	s.Nil(err)
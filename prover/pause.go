@@ -0,0 +1,123 @@
+package prover
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+var (
+	// errProvingPaused is returned by the proof submission and contest operations while the proving is paused,
+	// so that they are held until the proving is resumed, instead of sending transactions which would revert.
+	errProvingPaused = errors.New("protocol proving paused")
+)
+
+// onPauseStatusChanged updates the pause metrics, and notifies the submit and contest loops once the proving
+// is resumed.
+func (p *Prover) onPauseStatusChanged(status rpc.PauseStatus) {
+	var paused, provingPaused float64
+	if status.Paused {
+		paused = 1
+	}
+	if status.ProvingPaused {
+		provingPaused = 1
+	}
+	metrics.ProverProtocolPausedGaugeVec.WithLabelValues(p.chainID()).Set(paused)
+	metrics.ProverProvingPausedGaugeVec.WithLabelValues(p.chainID()).Set(provingPaused)
+
+	if !status.CanProve() {
+		return
+	}
+	p.notifyResumed(p.proofsResumeCh)
+	p.notifyResumed(p.contestsResumeCh)
+}
+
+// notifyResumed notifies the given resume channel if the proving is not paused, won't block.
+func (p *Prover) notifyResumed(ch chan struct{}) {
+	if !p.pauseWatcher.CanProve() {
+		return
+	}
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// holdProof holds the given proof until the proving is resumed.
+func (p *Prover) holdProof(proofWithHeader *proofProducer.ProofWithHeader) {
+	p.pausedMutex.Lock()
+	p.pausedProofs = append(p.pausedProofs, proofWithHeader)
+	held := len(p.pausedProofs)
+	p.pausedMutex.Unlock()
+
+	metrics.ProverPausedHeldProofsGaugeVec.WithLabelValues(p.chainID()).Set(float64(held))
+	log.Info(
+		"Proving paused, hold the proof",
+		"blockID", proofWithHeader.BlockID,
+		"tier", proofWithHeader.Tier,
+		"held", held,
+	)
+
+	// The proving might have been resumed before the proof is held, when the pause is found by a failed submission.
+	p.notifyResumed(p.proofsResumeCh)
+}
+
+// resumePausedProofs submits the proofs held while the proving was paused, only called in the submit loop.
+func (p *Prover) resumePausedProofs() {
+	if !p.pauseWatcher.CanProve() {
+		return
+	}
+
+	p.pausedMutex.Lock()
+	proofs := p.pausedProofs
+	p.pausedProofs = nil
+	p.pausedMutex.Unlock()
+	if len(proofs) == 0 {
+		return
+	}
+	metrics.ProverPausedHeldProofsGaugeVec.WithLabelValues(p.chainID()).Set(0)
+
+	log.Info("Proving resumed, submit the held proofs", "count", len(proofs))
+	for _, proofWithHeader := range proofs {
+		p.submitProof(proofWithHeader)
+	}
+}
+
+// holdContest holds the given proof contest request until the proving is resumed.
+func (p *Prover) holdContest(req *proofProducer.ContestRequestBody) {
+	p.pausedMutex.Lock()
+	p.pausedContests = append(p.pausedContests, req)
+	held := len(p.pausedContests)
+	p.pausedMutex.Unlock()
+
+	log.Info("Proving paused, hold the proof contest", "blockID", req.BlockID, "held", held)
+
+	// The proving might have been resumed before the contest is held, when the pause is found by a failed
+	// submission.
+	p.notifyResumed(p.contestsResumeCh)
+}
+
+// resumePausedContests submits the proof contests held while the proving was paused, only called in the
+// contest loop.
+func (p *Prover) resumePausedContests() {
+	if !p.pauseWatcher.CanProve() {
+		return
+	}
+
+	p.pausedMutex.Lock()
+	reqs := p.pausedContests
+	p.pausedContests = nil
+	p.pausedMutex.Unlock()
+	if len(reqs) == 0 {
+		return
+	}
+
+	log.Info("Proving resumed, submit the held proof contests", "count", len(reqs))
+	for _, req := range reqs {
+		p.submitContest(req)
+	}
+}
@@ -0,0 +1,157 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+)
+
+// ErrStageClosed is returned when submitting a job to a closed stage.
+var ErrStageClosed = errors.New("pipeline stage closed")
+
+// Config contains the configurations of a pipeline stage.
+type Config struct {
+	// Name of the stage, used in logs and metrics.
+	Name string
+	// ID of the L2 chain whose jobs are processed, used in metrics, empty if shared by all chains.
+	Chain string
+	// Number of workers processing the jobs concurrently.
+	Workers uint64
+	// Maximum number of jobs waiting for a free worker, submitting more jobs blocks.
+	QueueSize uint64
+	// Each job is retried at this interval, for at most this many times.
+	RetryInterval time.Duration
+	MaxRetries    uint64
+}
+
+// job is a unit of work of a stage.
+type job struct {
+	run func(ctx context.Context) error
+	// onFailed is called once all the retries of run failed, or the job is dropped.
+	onFailed func()
+}
+
+// Stage processes the submitted jobs with a bounded pool of workers, each job is retried with its own
+// backoff policy. Once the queue is full, Submit blocks until a worker is free, so that the upstream
+// slows down instead of piling up goroutines.
+type Stage struct {
+	cfg   *Config
+	queue chan *job
+	// done is closed once the stage is closed, to unblock the pending submissions.
+	done   chan struct{}
+	closed bool
+	// submitting tracks the submissions which passed the closed check, the queue is closed once they return.
+	submitting sync.WaitGroup
+	mutex      sync.RWMutex
+	wg         sync.WaitGroup
+}
+
+// New creates a new Stage instance.
+func New(cfg *Config) *Stage {
+	return &Stage{
+		cfg:   cfg,
+		queue: make(chan *job, cfg.QueueSize),
+		done:  make(chan struct{}),
+	}
+}
+
+// Start starts the workers of the stage, the jobs are run with the given context, so the queued jobs are
+// still processed after the stage is closed, until the context is done.
+func (s *Stage) Start(ctx context.Context) {
+	for i := uint64(0); i < max(s.cfg.Workers, 1); i++ {
+		s.wg.Add(1)
+		go s.work(ctx)
+	}
+}
+
+// Submit queues the given job, blocks while the queue is full, until the given context is done or the stage
+// is closed. onFailed is called once all retries of run failed, it can be nil.
+func (s *Stage) Submit(ctx context.Context, run func(ctx context.Context) error, onFailed func()) error {
+	// The lock is not held while blocking on the queue, so that Close is not blocked by a full queue.
+	s.mutex.RLock()
+	if s.closed {
+		s.mutex.RUnlock()
+		return ErrStageClosed
+	}
+	s.submitting.Add(1)
+	s.mutex.RUnlock()
+	defer s.submitting.Done()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return ErrStageClosed
+	case s.queue <- &job{run: run, onFailed: onFailed}:
+		metrics.ProverPipelineQueueGaugeVec.WithLabelValues(s.cfg.Chain, s.cfg.Name).Set(float64(len(s.queue)))
+		return nil
+	}
+}
+
+// Close stops accepting new jobs, the workers exit once all the queued jobs are done.
+func (s *Stage) Close() {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.done)
+	s.mutex.Unlock()
+
+	// No job can be sent to the queue once all the pending submissions returned.
+	s.submitting.Wait()
+	close(s.queue)
+}
+
+// Wait waits until all the workers exit.
+func (s *Stage) Wait() {
+	s.wg.Wait()
+}
+
+// Len returns the number of the queued jobs.
+func (s *Stage) Len() int {
+	return len(s.queue)
+}
+
+// work runs the queued jobs until the stage is closed and drained.
+func (s *Stage) work(ctx context.Context) {
+	defer s.wg.Done()
+
+	for j := range s.queue {
+		metrics.ProverPipelineQueueGaugeVec.WithLabelValues(s.cfg.Chain, s.cfg.Name).Set(float64(len(s.queue)))
+		s.process(ctx, j)
+	}
+}
+
+// process runs the given job with a backoff policy of its own, since a backoff policy keeps the
+// state of the retries and can't be shared between the workers.
+func (s *Stage) process(ctx context.Context, j *job) {
+	// The jobs left in the queue once the context is done are dropped, the context outlives the stop
+	// signal of the upstream, so that the queued jobs can be drained.
+	if ctx.Err() != nil {
+		if j.onFailed != nil {
+			j.onFailed()
+		}
+		return
+	}
+
+	if err := backoff.Retry(
+		func() error { return j.run(ctx) },
+		backoff.WithContext(
+			backoff.WithMaxRetries(backoff.NewConstantBackOff(s.cfg.RetryInterval), s.cfg.MaxRetries),
+			ctx,
+		),
+	); err != nil {
+		log.Error("Pipeline job failed", "stage", s.cfg.Name, "error", err)
+		if j.onFailed != nil {
+			j.onFailed()
+		}
+	}
+}
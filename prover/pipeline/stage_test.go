@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StageTestSuite struct {
+	suite.Suite
+}
+
+func (s *StageTestSuite) newStage(workers uint64, queueSize uint64) *Stage {
+	return New(&Config{
+		Name:          "test",
+		Workers:       workers,
+		QueueSize:     queueSize,
+		RetryInterval: time.Millisecond,
+		MaxRetries:    3,
+	})
+}
+
+func (s *StageTestSuite) TestRetry() {
+	stage := s.newStage(2, 4)
+	stage.Start(context.Background())
+
+	var attempts, failed atomic.Int32
+	// The jobs are retried concurrently, each one with its own backoff policy.
+	for i := 0; i < 4; i++ {
+		s.Nil(stage.Submit(
+			context.Background(),
+			func(context.Context) error {
+				attempts.Add(1)
+				return errors.New("failed")
+			},
+			func() { failed.Add(1) },
+		))
+	}
+
+	stage.Close()
+	stage.Wait()
+	s.Equal(int32(4*4), attempts.Load())
+	s.Equal(int32(4), failed.Load())
+}
+
+func (s *StageTestSuite) TestBackpressure() {
+	stage := s.newStage(1, 1)
+
+	release := make(chan struct{})
+	run := func(context.Context) error {
+		<-release
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stage.Start(ctx)
+
+	// One job is running, one is queued, the next submission blocks until the context is done.
+	s.Nil(stage.Submit(ctx, run, nil))
+	s.Eventually(func() bool { return stage.Len() == 0 }, time.Second, time.Millisecond)
+	s.Nil(stage.Submit(ctx, run, nil))
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer timeoutCancel()
+	s.ErrorIs(stage.Submit(timeoutCtx, run, nil), context.DeadlineExceeded)
+
+	close(release)
+	stage.Close()
+	stage.Wait()
+}
+
+func (s *StageTestSuite) TestDrainOnClose() {
+	stage := s.newStage(1, 3)
+
+	var done atomic.Int32
+	for i := 0; i < 3; i++ {
+		s.Nil(stage.Submit(context.Background(), func(context.Context) error {
+			done.Add(1)
+			return nil
+		}, nil))
+	}
+
+	// The queued jobs are still processed after the stage is closed.
+	stage.Close()
+	s.ErrorIs(stage.Submit(context.Background(), func(context.Context) error { return nil }, nil), ErrStageClosed)
+	stage.Start(context.Background())
+	stage.Wait()
+	s.Equal(int32(3), done.Load())
+}
+
+func (s *StageTestSuite) TestCloseWithBlockedSubmit() {
+	stage := s.newStage(1, 1)
+
+	release := make(chan struct{})
+	run := func(context.Context) error {
+		<-release
+		return nil
+	}
+	stage.Start(context.Background())
+	s.Nil(stage.Submit(context.Background(), run, nil))
+	s.Eventually(func() bool { return stage.Len() == 0 }, time.Second, time.Millisecond)
+	s.Nil(stage.Submit(context.Background(), run, nil))
+
+	// A submission blocked by the full queue doesn't block closing the stage.
+	errCh := make(chan error)
+	go func() { errCh <- stage.Submit(context.Background(), run, nil) }()
+	time.Sleep(50 * time.Millisecond)
+	stage.Close()
+	s.ErrorIs(<-errCh, ErrStageClosed)
+
+	close(release)
+	stage.Wait()
+}
+
+func (s *StageTestSuite) TestDropOnContextDone() {
+	stage := s.newStage(1, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var ran, dropped atomic.Int32
+	for i := 0; i < 2; i++ {
+		s.Nil(stage.Submit(ctx, func(context.Context) error {
+			ran.Add(1)
+			return nil
+		}, func() { dropped.Add(1) }))
+	}
+
+	cancel()
+	stage.Start(ctx)
+	stage.Close()
+	stage.Wait()
+	s.Equal(int32(0), ran.Load())
+	s.Equal(int32(2), dropped.Load())
+}
+
+func TestStageTestSuite(t *testing.T) {
+	suite.Run(t, new(StageTestSuite))
+}
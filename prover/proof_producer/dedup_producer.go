@@ -0,0 +1,249 @@
+package producer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+)
+
+// proofKey identifies a proof, the same block proven in the same tier for the same prover always gets
+// the same proof.
+type proofKey struct {
+	blockHash common.Hash
+	tier      uint16
+	prover    common.Address
+}
+
+// proofCall is an in-flight proof request, which the duplicate requests wait for, its proof is generated
+// on its own context, which is only cancelled once all the waiting requests are gone.
+type proofCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	proof   *ProofWithHeader
+	err     error
+}
+
+// GenerationObserver is notified of the generation time of each proof actually generated, the proofs
+// found in the cache or merged into an in-flight request are not observed.
+type GenerationObserver func(ctx context.Context, tier uint16, blockID *big.Int, duration time.Duration)
+
+// ProofCache caches the generated proofs until their blocks are verified or reorged, and merges the
+// concurrent requests of the same proof into one, it's shared by the proof producers of all tiers.
+type ProofCache struct {
+	mutex    sync.Mutex
+	proofs   map[proofKey]*ProofWithHeader
+	inflight map[proofKey]*proofCall
+	maxSize  int
+	observe  GenerationObserver
+}
+
+// NewProofCache creates a new ProofCache instance, which keeps at most the given number of proofs, the
+// proofs of the lowest blocks are dropped once it's full. The given observer can be nil.
+func NewProofCache(maxSize int, observe GenerationObserver) *ProofCache {
+	return &ProofCache{
+		proofs:   make(map[proofKey]*ProofWithHeader),
+		inflight: make(map[proofKey]*proofCall),
+		maxSize:  maxSize,
+		observe:  observe,
+	}
+}
+
+// request returns the cached proof of the given key, or waits for the in-flight request of it, otherwise
+// generates it with the given function.
+func (c *ProofCache) request(
+	ctx context.Context,
+	key proofKey,
+	generate func(ctx context.Context) (*ProofWithHeader, error),
+) (*ProofWithHeader, error) {
+	c.mutex.Lock()
+	if proof, ok := c.proofs[key]; ok {
+		c.mutex.Unlock()
+		metrics.ProverProofCacheHitCounter.Add(1)
+		log.Info("Proof found in cache", "blockID", proof.BlockID, "hash", key.blockHash, "tier", key.tier)
+		return proof, nil
+	}
+	call, ok := c.inflight[key]
+	if ok {
+		call.waiters++
+		metrics.ProverProofRequestMergedCounter.Add(1)
+		log.Info("Wait for the same in-flight proof request", "hash", key.blockHash, "tier", key.tier)
+	} else {
+		call = c.generate(ctx, key, generate)
+	}
+	c.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		c.leave(key, call)
+		return nil, ctx.Err()
+	case <-call.done:
+		return call.proof, call.err
+	}
+}
+
+// generate starts generating the proof of the given key in background, the generation outlives the
+// context of the request starting it, so that the other requests waiting for it are not failed when
+// that request is cancelled. The caller must hold the mutex.
+func (c *ProofCache) generate(
+	ctx context.Context,
+	key proofKey,
+	generate func(ctx context.Context) (*ProofWithHeader, error),
+) *proofCall {
+	callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	call := &proofCall{done: make(chan struct{}), cancel: cancel, waiters: 1}
+	c.inflight[key] = call
+
+	go func() {
+		defer cancel()
+		start := time.Now()
+		proof, err := generate(callCtx)
+		if err == nil && c.observe != nil {
+			c.observe(callCtx, key.tier, proof.BlockID, time.Since(start))
+		}
+
+		c.mutex.Lock()
+		if c.inflight[key] == call {
+			delete(c.inflight, key)
+		}
+		if err == nil {
+			c.add(key, proof)
+		}
+		c.mutex.Unlock()
+
+		call.proof, call.err = proof, err
+		close(call.done)
+	}()
+
+	return call
+}
+
+// leave removes a waiting request of the given in-flight call, the generation is cancelled once no request
+// is waiting for it anymore, and the next request of the same proof starts a new one.
+func (c *ProofCache) leave(key proofKey, call *proofCall) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+	}
+	call.cancel()
+}
+
+// add caches the given proof, the proofs of the same block with another block hash, which has been
+// reorged out, are dropped.
+func (c *ProofCache) add(key proofKey, proof *ProofWithHeader) {
+	var (
+		lowest proofKey
+		found  bool
+	)
+	for k, p := range c.proofs {
+		if p.BlockID.Cmp(proof.BlockID) == 0 && k.blockHash != key.blockHash {
+			delete(c.proofs, k)
+			continue
+		}
+		if !found || p.BlockID.Cmp(c.proofs[lowest].BlockID) < 0 {
+			lowest, found = k, true
+		}
+	}
+	if len(c.proofs) >= c.maxSize && found {
+		delete(c.proofs, lowest)
+	}
+	c.proofs[key] = proof
+}
+
+// ForgetUntil drops the cached proofs of the blocks up to the given verified block.
+func (c *ProofCache) ForgetUntil(blockID *big.Int) {
+	c.forget(func(p *ProofWithHeader) bool { return p.BlockID.Cmp(blockID) <= 0 })
+}
+
+// ForgetFrom drops the cached proofs of the blocks from the given one, once they are reorged out.
+func (c *ProofCache) ForgetFrom(blockID *big.Int) {
+	c.forget(func(p *ProofWithHeader) bool { return p.BlockID.Cmp(blockID) >= 0 })
+}
+
+// forget drops the cached proofs matching the given function.
+func (c *ProofCache) forget(match func(p *ProofWithHeader) bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for k, p := range c.proofs {
+		if match(p) {
+			delete(c.proofs, k)
+		}
+	}
+}
+
+// invalidate drops the cached proof of the given key.
+func (c *ProofCache) invalidate(key proofKey) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.proofs, key)
+}
+
+// ProofInvalidator is implemented by the proof producers caching the proofs, so that a proof found invalid
+// is generated again for the next request.
+type ProofInvalidator interface {
+	Invalidate(proof *ProofWithHeader)
+}
+
+// DedupProofProducer requests the proofs from the given proof producer through a proof cache, so that a
+// block is only proven once in a tier, no matter how many times its proof is requested.
+type DedupProofProducer struct {
+	ProofProducer
+	cache *ProofCache
+}
+
+// NewDedupProofProducer creates a new DedupProofProducer instance.
+func NewDedupProofProducer(producer ProofProducer, cache *ProofCache) *DedupProofProducer {
+	return &DedupProofProducer{ProofProducer: producer, cache: cache}
+}
+
+// RequestProof implements the ProofProducer interface.
+func (d *DedupProofProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+) (*ProofWithHeader, error) {
+	proof, err := d.cache.request(ctx, d.key(opts), func(ctx context.Context) (*ProofWithHeader, error) {
+		return d.ProofProducer.RequestProof(ctx, opts, blockID, meta, header)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The shared proof is returned with the request of the current caller.
+	return &ProofWithHeader{
+		BlockID: blockID,
+		Meta:    meta,
+		Header:  header,
+		Proof:   proof.Proof,
+		Opts:    opts,
+		Tier:    proof.Tier,
+	}, nil
+}
+
+// Invalidate implements the ProofInvalidator interface.
+func (d *DedupProofProducer) Invalidate(proof *ProofWithHeader) {
+	d.cache.invalidate(d.key(proof.Opts))
+}
+
+// key returns the proof cache key of the given request.
+func (d *DedupProofProducer) key(opts *ProofRequestOptions) proofKey {
+	return proofKey{blockHash: opts.BlockHash, tier: d.Tier(), prover: opts.ProverAddress}
+}
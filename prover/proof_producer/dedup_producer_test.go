@@ -0,0 +1,211 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// countingProofProducer counts the proof requests, and blocks them until released or cancelled.
+type countingProofProducer struct {
+	requests  atomic.Int32
+	cancelled atomic.Int32
+	release   chan struct{}
+	err       error
+}
+
+func (p *countingProofProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+) (*ProofWithHeader, error) {
+	p.requests.Add(1)
+	select {
+	case <-ctx.Done():
+		p.cancelled.Add(1)
+		return nil, ctx.Err()
+	case <-p.release:
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &ProofWithHeader{BlockID: blockID, Meta: meta, Header: header, Proof: randHash().Bytes(), Opts: opts}, nil
+}
+
+func (p *countingProofProducer) Tier() uint16 {
+	return 100
+}
+
+func requestDedupProof(
+	producer *DedupProofProducer,
+	blockID uint64,
+	blockHash common.Hash,
+) (*ProofWithHeader, error) {
+	return requestDedupProofWithContext(context.Background(), producer, blockID, blockHash)
+}
+
+func requestDedupProofWithContext(
+	ctx context.Context,
+	producer *DedupProofProducer,
+	blockID uint64,
+	blockHash common.Hash,
+) (*ProofWithHeader, error) {
+	return producer.RequestProof(
+		ctx,
+		&ProofRequestOptions{BlockHash: blockHash},
+		new(big.Int).SetUint64(blockID),
+		&bindings.TaikoDataBlockMetadata{},
+		&types.Header{},
+	)
+}
+
+func TestDedupProducerMergeRequests(t *testing.T) {
+	var observed atomic.Int32
+	inner := &countingProofProducer{release: make(chan struct{})}
+	producer := NewDedupProofProducer(inner, NewProofCache(16, func(context.Context, uint16, *big.Int, time.Duration) {
+		observed.Add(1)
+	}))
+	blockHash := randHash()
+
+	var (
+		wg     sync.WaitGroup
+		proofs = make([][]byte, 4)
+	)
+	for i := range proofs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			proof, err := requestDedupProof(producer, 1, blockHash)
+			require.Nil(t, err)
+			proofs[i] = proof.Proof
+		}(i)
+	}
+	close(inner.release)
+	wg.Wait()
+
+	// The later requests are either merged into the in-flight one, or served from the cache, and only
+	// the generated proof is observed.
+	require.Equal(t, int32(1), inner.requests.Load())
+	require.Equal(t, int32(1), observed.Load())
+	for _, proof := range proofs {
+		require.Equal(t, proofs[0], proof)
+	}
+
+	// Another block hash is requested again.
+	_, err := requestDedupProof(producer, 1, randHash())
+	require.Nil(t, err)
+	require.Equal(t, int32(2), inner.requests.Load())
+	require.Equal(t, int32(2), observed.Load())
+}
+
+func TestDedupProducerForget(t *testing.T) {
+	inner := &countingProofProducer{release: make(chan struct{})}
+	close(inner.release)
+	cache := NewProofCache(2, nil)
+	producer := NewDedupProofProducer(inner, cache)
+	hashes := []common.Hash{randHash(), randHash(), randHash()}
+
+	for i, hash := range hashes {
+		_, err := requestDedupProof(producer, uint64(i+1), hash)
+		require.Nil(t, err)
+	}
+	// The proof of the lowest block is dropped once the cache is full.
+	require.Len(t, cache.proofs, 2)
+	_, err := requestDedupProof(producer, 1, hashes[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(4), inner.requests.Load())
+
+	cache.ForgetUntil(common.Big1)
+	require.Len(t, cache.proofs, 1)
+	cache.ForgetFrom(common.Big3)
+	require.Empty(t, cache.proofs)
+
+	proof, err := requestDedupProof(producer, 2, hashes[1])
+	require.Nil(t, err)
+	producer.Invalidate(proof)
+	require.Empty(t, cache.proofs)
+}
+
+func TestDedupProducerNoErrorCached(t *testing.T) {
+	inner := &countingProofProducer{release: make(chan struct{}), err: errors.New("raiko unavailable")}
+	close(inner.release)
+	producer := NewDedupProofProducer(inner, NewProofCache(16, nil))
+	blockHash := randHash()
+
+	_, err := requestDedupProof(producer, 1, blockHash)
+	require.NotNil(t, err)
+	_, err = requestDedupProof(producer, 1, blockHash)
+	require.NotNil(t, err)
+	require.Equal(t, int32(2), inner.requests.Load())
+}
+
+func TestDedupProducerCancelWaiters(t *testing.T) {
+	inner := &countingProofProducer{release: make(chan struct{})}
+	cache := NewProofCache(16, nil)
+	producer := NewDedupProofProducer(inner, cache)
+	blockHash := randHash()
+
+	waitInflight := func(waiters int) {
+		require.Eventually(t, func() bool {
+			cache.mutex.Lock()
+			defer cache.mutex.Unlock()
+			call, ok := cache.inflight[proofKey{blockHash: blockHash, tier: inner.Tier()}]
+			return ok && call.waiters == waiters
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// The first request is cancelled, while the merged one still gets the proof.
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := requestDedupProofWithContext(ctx, producer, 1, blockHash)
+		firstErr <- err
+	}()
+	waitInflight(1)
+	secondErr := make(chan error)
+	go func() {
+		_, err := requestDedupProof(producer, 1, blockHash)
+		secondErr <- err
+	}()
+	waitInflight(2)
+
+	cancel()
+	require.ErrorIs(t, <-firstErr, context.Canceled)
+	close(inner.release)
+	require.Nil(t, <-secondErr)
+	require.Equal(t, int32(1), inner.requests.Load())
+	require.Equal(t, int32(0), inner.cancelled.Load())
+
+	// The generation is cancelled once all its requests are cancelled.
+	inner.release = make(chan struct{})
+	blockHash = randHash()
+	ctxs := make([]context.CancelFunc, 2)
+	errs := make(chan error, len(ctxs))
+	for i := range ctxs {
+		ctx, cancel := context.WithCancel(context.Background())
+		ctxs[i] = cancel
+		go func() {
+			_, err := requestDedupProofWithContext(ctx, producer, 2, blockHash)
+			errs <- err
+		}()
+		waitInflight(i + 1)
+	}
+	for _, cancel := range ctxs {
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+	}
+	require.Eventually(t, func() bool { return inner.cancelled.Load() == 1 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(2), inner.requests.Load())
+}
@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+var (
+	// maxSamplesPerTier is the maximum number of the latest samples kept for each tier.
+	maxSamplesPerTier = 256
+	// minSamplesForRegression is the minimum number of samples to fit the linear model of a tier,
+	// the average generation time is used below it.
+	minSamplesForRegression = 8
+	// DefaultGenerationTimes are the proof generation times used for the tiers without any sample.
+	DefaultGenerationTimes = map[uint16]time.Duration{
+		encoding.TierOptimisticID:       0,
+		encoding.TierSgxID:              2 * time.Minute,
+		encoding.TierSgxAndZkVMID:       30 * time.Minute,
+		encoding.TierGuardianMinorityID: 2 * time.Minute,
+		encoding.TierGuardianMajorityID: 2 * time.Minute,
+	}
+)
+
+// Sample is a historical proof generation record.
+type Sample struct {
+	Tier     uint16        `json:"tier"`
+	GasUsed  uint64        `json:"gasUsed"`
+	TxCount  uint64        `json:"txCount"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Predictor learns the proof generation time of each tier, against the gas used and
+// the transactions count of the proven blocks.
+type Predictor struct {
+	samples map[uint16][]*Sample
+	mutex   sync.RWMutex
+}
+
+// NewPredictor creates a new Predictor instance with the given historical samples.
+func NewPredictor(samples []*Sample) *Predictor {
+	p := &Predictor{samples: make(map[uint16][]*Sample)}
+	for _, s := range samples {
+		p.Record(s)
+	}
+	return p
+}
+
+// Record records a new proof generation sample.
+func (p *Predictor) Record(s *Sample) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	samples := append(p.samples[s.Tier], s)
+	if len(samples) > maxSamplesPerTier {
+		samples = samples[len(samples)-maxSamplesPerTier:]
+	}
+	p.samples[s.Tier] = samples
+}
+
+// Samples returns all the recorded samples.
+func (p *Predictor) Samples() []*Sample {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var samples []*Sample
+	for _, s := range p.samples {
+		samples = append(samples, s...)
+	}
+	return samples
+}
+
+// Predict predicts the proof generation time of the given tier, for a block with the given gas used
+// and transactions count. A linear model is fitted with the samples of the tier, and the average
+// generation time is used if there are not enough samples to fit it.
+func (p *Predictor) Predict(tier uint16, gasUsed uint64, txCount uint64) time.Duration {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	samples := p.samples[tier]
+	if len(samples) == 0 {
+		return DefaultGenerationTimes[tier]
+	}
+
+	if len(samples) >= minSamplesForRegression {
+		if coefficients, ok := fit(samples); ok {
+			predicted := coefficients[0] +
+				coefficients[1]*float64(gasUsed) +
+				coefficients[2]*float64(txCount)
+			if predicted > 0 && !math.IsNaN(predicted) && !math.IsInf(predicted, 0) {
+				return time.Duration(predicted)
+			}
+		}
+	}
+
+	var total time.Duration
+	for _, s := range samples {
+		total += s.Duration
+	}
+	return total / time.Duration(len(samples))
+}
+
+// TxCountForGas estimates the transactions count of a block with the given gas used, based on
+// the average gas used per transaction of the tier samples.
+func (p *Predictor) TxCountForGas(tier uint16, gasUsed uint64) uint64 {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var totalGas, totalTxs uint64
+	for _, s := range p.samples[tier] {
+		totalGas += s.GasUsed
+		totalTxs += s.TxCount
+	}
+	if totalGas == 0 {
+		return 0
+	}
+
+	return uint64(float64(gasUsed) * float64(totalTxs) / float64(totalGas))
+}
+
+// fit fits the linear model `duration = c0 + c1 * gasUsed + c2 * txCount` with the given samples,
+// using the ordinary least squares method.
+func fit(samples []*Sample) ([3]float64, bool) {
+	var (
+		a [3][4]float64
+		x [3]float64
+	)
+	// Build the normal equations (XᵀX)c = Xᵀy, as an augmented matrix.
+	for _, s := range samples {
+		features := [3]float64{1, float64(s.GasUsed), float64(s.TxCount)}
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				a[i][j] += features[i] * features[j]
+			}
+			a[i][3] += features[i] * float64(s.Duration)
+		}
+	}
+
+	// Gaussian elimination with partial pivoting, a pivot which is tiny relative to its column
+	// means the samples can not determine the model, e.g. all blocks have the same size.
+	var scales [3]float64
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			scales[col] = math.Max(scales[col], math.Abs(a[row][col]))
+		}
+	}
+	for col := 0; col < 3; col++ {
+		pivot := col
+		for row := col + 1; row < 3; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) <= 1e-9*scales[col] {
+			return x, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		for row := col + 1; row < 3; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < 4; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+		}
+	}
+	for row := 2; row >= 0; row-- {
+		sum := a[row][3]
+		for k := row + 1; k < 3; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+
+	return x, true
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+type PredictorTestSuite struct {
+	suite.Suite
+	predictor *Predictor
+}
+
+func (s *PredictorTestSuite) SetupTest() {
+	s.predictor = NewPredictor(nil)
+}
+
+func (s *PredictorTestSuite) TestPredictDefault() {
+	s.Equal(DefaultGenerationTimes[encoding.TierSgxID], s.predictor.Predict(encoding.TierSgxID, 1_000_000, 10))
+}
+
+func (s *PredictorTestSuite) TestPredictAverage() {
+	s.predictor.Record(&Sample{Tier: encoding.TierSgxID, GasUsed: 1_000_000, TxCount: 10, Duration: time.Minute})
+	s.predictor.Record(&Sample{Tier: encoding.TierSgxID, GasUsed: 2_000_000, TxCount: 20, Duration: 3 * time.Minute})
+
+	s.Equal(2*time.Minute, s.predictor.Predict(encoding.TierSgxID, 5_000_000, 50))
+	s.Equal(uint64(30), s.predictor.TxCountForGas(encoding.TierSgxID, 3_000_000))
+}
+
+func (s *PredictorTestSuite) TestPredictRegression() {
+	// duration = 10s + 1s per 100k gas + 2s per transaction
+	for i := 1; i <= 16; i++ {
+		gasUsed, txCount := uint64(i*100_000), uint64(i*i%7)
+		s.predictor.Record(&Sample{
+			Tier:     encoding.TierSgxID,
+			GasUsed:  gasUsed,
+			TxCount:  txCount,
+			Duration: 10*time.Second + time.Duration(gasUsed/100_000)*time.Second + time.Duration(txCount)*2*time.Second,
+		})
+	}
+
+	s.InDelta(
+		float64(10*time.Second+30*time.Second+20*time.Second),
+		float64(s.predictor.Predict(encoding.TierSgxID, 3_000_000, 10)),
+		float64(time.Millisecond),
+	)
+}
+
+func (s *PredictorTestSuite) TestPredictSingularSamples() {
+	// All blocks have the same size, so only the average can be used.
+	for i := 0; i < minSamplesForRegression; i++ {
+		s.predictor.Record(&Sample{
+			Tier:     encoding.TierSgxID,
+			GasUsed:  1_000_000,
+			TxCount:  10,
+			Duration: time.Duration(i+1) * time.Second,
+		})
+	}
+
+	s.Equal(4500*time.Millisecond, s.predictor.Predict(encoding.TierSgxID, 5_000_000, 50))
+}
+
+func (s *PredictorTestSuite) TestRecordLimit() {
+	for i := 0; i < maxSamplesPerTier+10; i++ {
+		s.predictor.Record(&Sample{Tier: encoding.TierSgxID, Duration: time.Second})
+	}
+	s.Len(s.predictor.Samples(), maxSamplesPerTier)
+}
+
+func TestPredictorTestSuite(t *testing.T) {
+	suite.Run(t, new(PredictorTestSuite))
+}
@@ -0,0 +1,495 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+var (
+	// dispatchInterval is the interval of checking the ready jobs.
+	dispatchInterval = 1 * time.Second
+	// proofSubmissionTime is the expected time to get a proof submission transaction mined in L1.
+	proofSubmissionTime = 2 * 12 * time.Second
+)
+
+// JobKind represents the kind of a scheduled job.
+type JobKind string
+
+// Job kinds.
+const (
+	// JobProve requests a proof for the block.
+	JobProve JobKind = "prove"
+	// JobAssignmentExpired notifies the expiration of the block's proving window, so that the block
+	// can be proven opportunistically.
+	JobAssignmentExpired JobKind = "assignmentExpired"
+)
+
+// Job represents a scheduled job for a proposed block.
+type Job struct {
+	Kind  JobKind                              `json:"kind"`
+	Tier  uint16                               `json:"tier"`
+	Event *bindings.TaikoL1ClientBlockProposed `json:"event"`
+	// The job will not be dispatched before this time.
+	ReadyAt time.Time `json:"readyAt"`
+	// The proof should be submitted before this time, zero means no deadline.
+	Deadline time.Time `json:"deadline"`
+	GasUsed  uint64    `json:"gasUsed"`
+	TxCount  uint64    `json:"txCount"`
+	// Whether the job has been downgraded to best effort, since it can not finish before its deadline.
+	Downgraded bool `json:"downgraded"`
+}
+
+// Config contains the configurations of the proof scheduler.
+type Config struct {
+	// ID of the L2 chain whose proofs are scheduled, used in metrics.
+	Chain string
+	// File to persist the scheduled jobs and the proof generation samples, empty means no persistence.
+	StateFile string
+	// Whether to drop the proofs which can not finish before their deadlines, instead of
+	// downgrading them to best effort.
+	DropLateProofs bool
+	// Maximum number of proofs generating at the same time.
+	Capacity uint64
+	// Maximum number of expired blocks proving opportunistically at the same time, zero means no limit.
+	MaxOpportunisticProofs uint64
+	// Estimated cost in wei of generating and submitting a proof of each tier.
+	ProofCosts map[uint16]*big.Int
+	// Price of one bond token in wei, zero means the expired blocks are only ranked by their liveness
+	// bonds, and the proof costs are ignored.
+	BondTokenPrice *big.Int
+}
+
+// state is the persisted state of the scheduler.
+type state struct {
+	Jobs    []*Job    `json:"jobs"`
+	Samples []*Sample `json:"samples"`
+}
+
+// jobKey is the unique key of a scheduled job.
+type jobKey struct {
+	kind    JobKind
+	blockID uint64
+}
+
+// Scheduler queues the proof requests by the earliest deadline first, and predicts whether the
+// proofs can be generated before the proving windows close, using the historical proof generation time.
+// The expired blocks are proven opportunistically when no assigned proof is waiting, ranked by their
+// expected rewards.
+type Scheduler struct {
+	cfg                 *Config
+	rpc                 *rpc.Client
+	predictor           *Predictor
+	proofSubmissionCh   chan<- *proofProducer.ProofRequestBody
+	assignmentExpiredCh chan<- *bindings.TaikoL1ClientBlockProposed
+	inflightProofs      func() uint64
+	jobs                map[jobKey]*Job
+	dispatched          map[uint64]*Job
+	mutex               sync.Mutex
+	wg                  sync.WaitGroup
+}
+
+// New creates a new Scheduler instance, the jobs and samples persisted in the state file are restored.
+func New(
+	cfg *Config,
+	rpc *rpc.Client,
+	proofSubmissionCh chan<- *proofProducer.ProofRequestBody,
+	assignmentExpiredCh chan<- *bindings.TaikoL1ClientBlockProposed,
+	inflightProofs func() uint64,
+) (*Scheduler, error) {
+	s := &Scheduler{
+		cfg:                 cfg,
+		rpc:                 rpc,
+		proofSubmissionCh:   proofSubmissionCh,
+		assignmentExpiredCh: assignmentExpiredCh,
+		inflightProofs:      inflightProofs,
+		jobs:                make(map[jobKey]*Job),
+		dispatched:          make(map[uint64]*Job),
+	}
+
+	st, err := s.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proof scheduler state: %w", err)
+	}
+
+	s.predictor = NewPredictor(st.Samples)
+	for _, job := range st.Jobs {
+		if job.Event == nil || job.Event.BlockId == nil {
+			continue
+		}
+		s.jobs[jobKey{job.Kind, job.Event.BlockId.Uint64()}] = job
+	}
+	if len(st.Jobs) != 0 {
+		log.Info("Restored scheduled jobs", "count", len(st.Jobs), "samples", len(st.Samples))
+	}
+
+	return s, nil
+}
+
+// Start starts the dispatching loop, which will be stopped when the given context is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go s.loop(ctx)
+}
+
+// Wait waits until the dispatching loop exits.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// loop is the main loop of the scheduler.
+func (s *Scheduler) loop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.dispatch()
+		}
+	}
+}
+
+// Schedule adds a new job to the queue, a job with the same kind for the same block will be replaced.
+func (s *Scheduler) Schedule(ctx context.Context, job *Job) {
+	if job.Kind == JobProve && s.rpc != nil {
+		if block, err := s.rpc.L2.BlockByNumber(ctx, job.Event.BlockId); err != nil {
+			log.Warn("Failed to get L2 block for proof scheduling", "blockID", job.Event.BlockId, "error", err)
+		} else {
+			job.GasUsed = block.GasUsed()
+			job.TxCount = uint64(block.Transactions().Len())
+		}
+	}
+
+	s.mutex.Lock()
+	s.jobs[jobKey{job.Kind, job.Event.BlockId.Uint64()}] = job
+	s.persist()
+	s.mutex.Unlock()
+
+	log.Debug(
+		"Job scheduled",
+		"kind", job.Kind,
+		"blockID", job.Event.BlockId,
+		"tier", job.Tier,
+		"readyAt", job.ReadyAt,
+		"deadline", job.Deadline,
+	)
+
+	s.dispatch()
+}
+
+// Observe records the generation time of a proof.
+func (s *Scheduler) Observe(ctx context.Context, tier uint16, blockID *big.Int, duration time.Duration) {
+	s.mutex.Lock()
+	job, ok := s.dispatched[blockID.Uint64()]
+	delete(s.dispatched, blockID.Uint64())
+	s.mutex.Unlock()
+
+	sample := &Sample{Tier: tier, Duration: duration}
+	if ok && job.Kind == JobProve {
+		sample.GasUsed, sample.TxCount = job.GasUsed, job.TxCount
+	} else if s.rpc != nil {
+		block, err := s.rpc.L2.BlockByNumber(ctx, blockID)
+		if err != nil {
+			log.Warn("Failed to get L2 block for proof generation sample", "blockID", blockID, "error", err)
+			return
+		}
+		sample.GasUsed, sample.TxCount = block.GasUsed(), uint64(block.Transactions().Len())
+	}
+
+	s.predictor.Record(sample)
+	metrics.ProverProofGenerationTimeGaugeVec.WithLabelValues(s.cfg.Chain).Set(duration.Seconds())
+
+	s.mutex.Lock()
+	s.persist()
+	s.mutex.Unlock()
+}
+
+// Forget forgets the dispatched job of the given block, whose proof request has failed, which turns out
+// not to need a proof, or whose proof is served without being generated.
+func (s *Scheduler) Forget(blockID *big.Int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if job, ok := s.dispatched[blockID.Uint64()]; ok {
+		delete(s.dispatched, blockID.Uint64())
+		if job.Kind == JobAssignmentExpired {
+			s.persist()
+		}
+	}
+}
+
+// EstimateCompletion estimates the time to generate and submit a proof of the given tier, for a new
+// block with the given gas used, including the time to finish the queued jobs.
+func (s *Scheduler) EstimateCompletion(tier uint16, gasUsed uint64) time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var backlog time.Duration
+	for _, job := range s.jobs {
+		if job.Kind == JobProve {
+			backlog += s.predictor.Predict(job.Tier, job.GasUsed, job.TxCount)
+		}
+	}
+	for _, job := range s.dispatched {
+		if job.Kind == JobProve {
+			backlog += s.predictor.Predict(job.Tier, job.GasUsed, job.TxCount)
+		}
+	}
+	if s.cfg.Capacity > 1 {
+		backlog /= time.Duration(s.cfg.Capacity)
+	}
+
+	return backlog + s.predictor.Predict(tier, gasUsed, s.predictor.TxCountForGas(tier, gasUsed)) + proofSubmissionTime
+}
+
+// dispatch sends the ready jobs by the earliest deadline first, until the prover is at its capacity,
+// the expired blocks are only sent when all the ready assigned proofs have been sent.
+func (s *Scheduler) dispatch() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var (
+		now     = time.Now()
+		changed = false
+		ready   []*Job
+	)
+	for key, job := range s.jobs {
+		if job.ReadyAt.After(now) {
+			continue
+		}
+
+		if job.Kind == JobProve && !job.Deadline.IsZero() && !job.Downgraded {
+			finishAt := now.Add(s.predictor.Predict(job.Tier, job.GasUsed, job.TxCount) + proofSubmissionTime)
+			if finishAt.After(job.Deadline) {
+				if s.cfg.DropLateProofs {
+					log.Warn(
+						"Drop the proof which can not finish before its deadline",
+						"blockID", job.Event.BlockId,
+						"tier", job.Tier,
+						"deadline", job.Deadline,
+						"predictedFinish", finishAt,
+					)
+					metrics.ProverProofDroppedCounter.Add(1)
+					delete(s.jobs, key)
+					changed = true
+					continue
+				}
+
+				log.Warn(
+					"Downgrade the proof which can not finish before its deadline",
+					"blockID", job.Event.BlockId,
+					"tier", job.Tier,
+					"deadline", job.Deadline,
+					"predictedFinish", finishAt,
+				)
+				metrics.ProverProofDowngradedCounter.Add(1)
+				job.Downgraded = true
+				changed = true
+			}
+		}
+
+		if job.Kind == JobAssignmentExpired && s.costsKnown() && s.expectedReward(job).Sign() <= 0 {
+			log.Info(
+				"Skip the expired block whose proof costs more than its liveness bond",
+				"blockID", job.Event.BlockId,
+				"minTier", job.Event.Meta.MinTier,
+				"livenessBond", job.Event.LivenessBond,
+			)
+			metrics.ProverOpportunisticSkippedCounter.Add(1)
+			delete(s.jobs, key)
+			changed = true
+			continue
+		}
+
+		ready = append(ready, job)
+	}
+
+	// The proofs with the earliest deadlines go first, then the best effort proofs by their ready time,
+	// and the expired blocks by their expected rewards at last.
+	sort.SliceStable(ready, func(i, j int) bool { return s.less(ready[i], ready[j]) })
+
+	var (
+		inflight      = uint64(0)
+		opportunistic = s.opportunisticProofs()
+		// Whether an assigned proof is still waiting for the proof generation.
+		waiting = false
+	)
+	if s.inflightProofs != nil {
+		inflight = s.inflightProofs()
+	}
+
+	for _, job := range ready {
+		if s.cfg.Capacity != 0 && inflight >= s.cfg.Capacity {
+			break
+		}
+
+		if job.Kind == JobAssignmentExpired {
+			if waiting || (s.cfg.MaxOpportunisticProofs != 0 && opportunistic >= s.cfg.MaxOpportunisticProofs) {
+				break
+			}
+
+			select {
+			case s.assignmentExpiredCh <- job.Event:
+				delete(s.jobs, jobKey{job.Kind, job.Event.BlockId.Uint64()})
+				s.dispatched[job.Event.BlockId.Uint64()] = job
+				changed = true
+				inflight++
+				opportunistic++
+			default:
+			}
+			continue
+		}
+
+		select {
+		case s.proofSubmissionCh <- &proofProducer.ProofRequestBody{Tier: job.Tier, Event: job.Event}:
+			delete(s.jobs, jobKey{job.Kind, job.Event.BlockId.Uint64()})
+			s.dispatched[job.Event.BlockId.Uint64()] = job
+			changed = true
+			inflight++
+		default:
+			waiting = true
+		}
+	}
+
+	metrics.ProverScheduledJobsGaugeVec.WithLabelValues(s.cfg.Chain).Set(float64(len(s.jobs)))
+	metrics.ProverOpportunisticProofsGaugeVec.WithLabelValues(s.cfg.Chain).Set(float64(opportunistic))
+	if changed {
+		s.persist()
+	}
+}
+
+// less reports whether the job a should be dispatched before the job b.
+func (s *Scheduler) less(a, b *Job) bool {
+	if a.Kind != b.Kind {
+		return a.Kind == JobProve
+	}
+	if a.Kind == JobAssignmentExpired {
+		if c := s.expectedReward(a).Cmp(s.expectedReward(b)); c != 0 {
+			return c > 0
+		}
+		return a.ReadyAt.Before(b.ReadyAt)
+	}
+
+	aBestEffort, bBestEffort := a.Deadline.IsZero() || a.Downgraded, b.Deadline.IsZero() || b.Downgraded
+	if aBestEffort != bBestEffort {
+		return !aBestEffort
+	}
+	if !aBestEffort && !a.Deadline.Equal(b.Deadline) {
+		return a.Deadline.Before(b.Deadline)
+	}
+
+	return a.ReadyAt.Before(b.ReadyAt)
+}
+
+// expectedReward returns the expected reward of proving the given expired block, which is the liveness bond
+// of the assigned prover minus the proof cost, in wei if the bond token price is set, or in bond tokens.
+func (s *Scheduler) expectedReward(job *Job) *big.Int {
+	livenessBond := job.Event.LivenessBond
+	if livenessBond == nil {
+		livenessBond = common.Big0
+	}
+	if !s.costsKnown() {
+		return livenessBond
+	}
+
+	reward := new(big.Int).Mul(livenessBond, s.cfg.BondTokenPrice)
+	reward.Div(reward, big.NewInt(params.Ether))
+	if cost, ok := s.cfg.ProofCosts[job.Event.Meta.MinTier]; ok && cost != nil {
+		reward.Sub(reward, cost)
+	}
+
+	return reward
+}
+
+// costsKnown checks whether the proof costs can be compared with the liveness bonds.
+func (s *Scheduler) costsKnown() bool {
+	return s.cfg.BondTokenPrice != nil && s.cfg.BondTokenPrice.Sign() > 0
+}
+
+// opportunisticProofs returns the number of expired blocks proving opportunistically, the caller must
+// hold the mutex.
+func (s *Scheduler) opportunisticProofs() uint64 {
+	var count uint64
+	for _, job := range s.dispatched {
+		if job.Kind == JobAssignmentExpired {
+			count++
+		}
+	}
+	return count
+}
+
+// load loads the persisted state from the state file.
+func (s *Scheduler) load() (*state, error) {
+	st := new(state)
+	if s.cfg.StateFile == "" {
+		return st, nil
+	}
+
+	data, err := os.ReadFile(s.cfg.StateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return st, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, err
+	}
+
+	return st, nil
+}
+
+// persist writes the current state to the state file, the caller must hold the mutex.
+func (s *Scheduler) persist() {
+	if s.cfg.StateFile == "" {
+		return
+	}
+
+	st := &state{Samples: s.predictor.Samples()}
+	for _, job := range s.jobs {
+		st.Jobs = append(st.Jobs, job)
+	}
+	// The expired blocks proving opportunistically are queued again after a restart.
+	for _, job := range s.dispatched {
+		if job.Kind == JobAssignmentExpired {
+			st.Jobs = append(st.Jobs, job)
+		}
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		log.Error("Failed to marshal proof scheduler state", "error", err)
+		return
+	}
+
+	// Write to a temporary file at first, so the state file will never be partially written.
+	tmp := s.cfg.StateFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		log.Error("Failed to write proof scheduler state", "error", err)
+		return
+	}
+	if err := os.Rename(tmp, s.cfg.StateFile); err != nil {
+		log.Error("Failed to write proof scheduler state", "error", err)
+	}
+}
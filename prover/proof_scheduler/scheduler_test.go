@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	scheduler           *Scheduler
+	proofSubmissionCh   chan *proofProducer.ProofRequestBody
+	assignmentExpiredCh chan *bindings.TaikoL1ClientBlockProposed
+	inflight            uint64
+}
+
+func (s *SchedulerTestSuite) SetupTest() {
+	s.scheduler = s.newScheduler(&Config{Capacity: 1})
+}
+
+func (s *SchedulerTestSuite) newScheduler(cfg *Config) *Scheduler {
+	s.proofSubmissionCh = make(chan *proofProducer.ProofRequestBody, 16)
+	s.assignmentExpiredCh = make(chan *bindings.TaikoL1ClientBlockProposed, 16)
+	s.inflight = 0
+
+	scheduler, err := New(cfg, nil, s.proofSubmissionCh, s.assignmentExpiredCh, func() uint64 { return s.inflight })
+	s.Nil(err)
+	return scheduler
+}
+
+func testEvent(id int64) *bindings.TaikoL1ClientBlockProposed {
+	e := &bindings.TaikoL1ClientBlockProposed{BlockId: big.NewInt(id)}
+	e.Raw.Topics = []common.Hash{}
+	return e
+}
+
+func testExpiredEvent(id int64, livenessBond int64) *bindings.TaikoL1ClientBlockProposed {
+	e := testEvent(id)
+	e.LivenessBond = big.NewInt(livenessBond)
+	e.Meta.MinTier = encoding.TierSgxID
+	return e
+}
+
+func (s *SchedulerTestSuite) TestEarliestDeadlineFirst() {
+	// Keep the prover at its capacity while scheduling.
+	s.inflight = 1
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobProve, Tier: encoding.TierOptimisticID, Event: testEvent(1),
+	})
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobProve, Tier: encoding.TierOptimisticID, Event: testEvent(2), Deadline: time.Now().Add(2 * time.Hour),
+	})
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobProve, Tier: encoding.TierOptimisticID, Event: testEvent(3), Deadline: time.Now().Add(time.Hour),
+	})
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobAssignmentExpired, Event: testEvent(4),
+	})
+	s.Empty(s.proofSubmissionCh)
+	s.Empty(s.assignmentExpiredCh)
+
+	s.inflight = 0
+	for _, id := range []uint64{3, 2, 1} {
+		s.scheduler.dispatch()
+		s.Len(s.proofSubmissionCh, 1)
+		s.Equal(id, (<-s.proofSubmissionCh).Event.BlockId.Uint64())
+	}
+
+	// The expired block is proven after all the assigned blocks.
+	s.Empty(s.assignmentExpiredCh)
+	s.scheduler.dispatch()
+	s.Equal(uint64(4), (<-s.assignmentExpiredCh).BlockId.Uint64())
+}
+
+func (s *SchedulerTestSuite) TestOpportunisticProofs() {
+	s.scheduler = s.newScheduler(&Config{Capacity: 4, MaxOpportunisticProofs: 2})
+	s.inflight = 4
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobAssignmentExpired, Event: testExpiredEvent(1, 100)})
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobAssignmentExpired, Event: testExpiredEvent(2, 300)})
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobAssignmentExpired, Event: testExpiredEvent(3, 200)})
+	s.Empty(s.assignmentExpiredCh)
+
+	// The expired blocks are ranked by their liveness bonds, up to the limit.
+	s.inflight = 0
+	s.scheduler.dispatch()
+	s.Len(s.assignmentExpiredCh, 2)
+	s.Equal(uint64(2), (<-s.assignmentExpiredCh).BlockId.Uint64())
+	s.Equal(uint64(3), (<-s.assignmentExpiredCh).BlockId.Uint64())
+	s.Equal(uint64(2), s.scheduler.opportunisticProofs())
+
+	s.scheduler.Forget(big.NewInt(2))
+	s.scheduler.dispatch()
+	s.Equal(uint64(1), (<-s.assignmentExpiredCh).BlockId.Uint64())
+}
+
+func (s *SchedulerTestSuite) TestAssignedProofsFirst() {
+	// The proof submission channel is full, so the assigned proof keeps waiting.
+	s.proofSubmissionCh <- &proofProducer.ProofRequestBody{Event: testEvent(0)}
+	for len(s.proofSubmissionCh) < cap(s.proofSubmissionCh) {
+		s.proofSubmissionCh <- &proofProducer.ProofRequestBody{Event: testEvent(0)}
+	}
+	s.scheduler.cfg.Capacity = 0
+
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobProve, Tier: encoding.TierSgxID, Event: testEvent(1)})
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobAssignmentExpired, Event: testExpiredEvent(2, 100)})
+	s.Empty(s.assignmentExpiredCh)
+	s.Len(s.scheduler.jobs, 2)
+}
+
+func (s *SchedulerTestSuite) TestSkipUnprofitableBlocks() {
+	s.scheduler = s.newScheduler(&Config{
+		Capacity:       4,
+		ProofCosts:     map[uint16]*big.Int{encoding.TierSgxID: big.NewInt(150)},
+		BondTokenPrice: big.NewInt(params.Ether),
+	})
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobAssignmentExpired, Event: testExpiredEvent(1, 100)})
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobAssignmentExpired, Event: testExpiredEvent(2, 200)})
+
+	s.Len(s.assignmentExpiredCh, 1)
+	s.Equal(uint64(2), (<-s.assignmentExpiredCh).BlockId.Uint64())
+	s.Empty(s.scheduler.jobs)
+}
+
+func (s *SchedulerTestSuite) TestDowngradeLateProofs() {
+	s.inflight = 1
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobProve, Tier: encoding.TierSgxID, Event: testEvent(1), Deadline: time.Now().Add(time.Minute),
+	})
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobProve, Tier: encoding.TierSgxID, Event: testEvent(2), Deadline: time.Now().Add(time.Hour),
+	})
+
+	// The late proof is dispatched after the ones which can still finish in time.
+	s.inflight = 0
+	s.scheduler.dispatch()
+	s.Equal(uint64(2), (<-s.proofSubmissionCh).Event.BlockId.Uint64())
+	s.scheduler.dispatch()
+	s.Equal(uint64(1), (<-s.proofSubmissionCh).Event.BlockId.Uint64())
+}
+
+func (s *SchedulerTestSuite) TestDropLateProofs() {
+	s.scheduler = s.newScheduler(&Config{Capacity: 1, DropLateProofs: true})
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobProve, Tier: encoding.TierSgxID, Event: testEvent(1), Deadline: time.Now().Add(time.Minute),
+	})
+	s.Empty(s.proofSubmissionCh)
+	s.Empty(s.scheduler.jobs)
+}
+
+func (s *SchedulerTestSuite) TestEstimateCompletion() {
+	estimated := s.scheduler.EstimateCompletion(encoding.TierSgxID, 1_000_000)
+	s.Equal(DefaultGenerationTimes[encoding.TierSgxID]+proofSubmissionTime, estimated)
+
+	s.inflight = 1
+	s.scheduler.Schedule(context.Background(), &Job{Kind: JobProve, Tier: encoding.TierSgxID, Event: testEvent(1)})
+	s.Equal(estimated+DefaultGenerationTimes[encoding.TierSgxID], s.scheduler.EstimateCompletion(encoding.TierSgxID, 1_000_000))
+}
+
+func (s *SchedulerTestSuite) TestObserve() {
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobProve, Tier: encoding.TierSgxID, Event: testEvent(1), GasUsed: 1_000_000, TxCount: 10,
+	})
+	s.Len(s.scheduler.dispatched, 1)
+
+	s.scheduler.Observe(context.Background(), encoding.TierSgxID, common.Big1, time.Minute)
+	s.Empty(s.scheduler.dispatched)
+	s.Equal(
+		[]*Sample{{Tier: encoding.TierSgxID, GasUsed: 1_000_000, TxCount: 10, Duration: time.Minute}},
+		s.scheduler.predictor.Samples(),
+	)
+}
+
+func (s *SchedulerTestSuite) TestPersistence() {
+	cfg := &Config{StateFile: filepath.Join(s.T().TempDir(), "scheduler.json"), Capacity: 1}
+	s.scheduler = s.newScheduler(cfg)
+
+	readyAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	s.scheduler.Schedule(context.Background(), &Job{
+		Kind: JobAssignmentExpired, Event: testEvent(1), ReadyAt: readyAt,
+	})
+	s.scheduler.Observe(context.Background(), encoding.TierSgxID, common.Big2, time.Minute)
+
+	restored := s.newScheduler(cfg)
+	s.Len(restored.jobs, 1)
+	job := restored.jobs[jobKey{JobAssignmentExpired, 1}]
+	s.NotNil(job)
+	s.True(readyAt.Equal(job.ReadyAt))
+	s.Len(restored.predictor.Samples(), 1)
+
+	// The expired blocks proving opportunistically are queued again after a restart.
+	restored.Schedule(context.Background(), &Job{Kind: JobAssignmentExpired, Event: testExpiredEvent(2, 100)})
+	s.Equal(uint64(2), (<-s.assignmentExpiredCh).BlockId.Uint64())
+	s.Len(restored.jobs, 1)
+
+	restored = s.newScheduler(cfg)
+	s.Len(restored.jobs, 2)
+	s.NotNil(restored.jobs[jobKey{JobAssignmentExpired, 2}])
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
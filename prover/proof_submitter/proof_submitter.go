@@ -16,6 +16,7 @@ import (
 	validator "github.com/taikoxyz/taiko-client/prover/anchor_tx_validator"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 	"github.com/taikoxyz/taiko-client/prover/proof_submitter/transaction"
+	verifier "github.com/taikoxyz/taiko-client/prover/sgx_verifier"
 )
 
 var _ Submitter = (*ProofSubmitter)(nil)
@@ -29,6 +30,7 @@ type ProofSubmitter struct {
 	anchorValidator *validator.AnchorTxValidator
 	txBuilder       *transaction.ProveBlockTxBuilder
 	sender          *transaction.Sender
+	sgxVerifier     *verifier.Verifier
 	proverAddress   common.Address
 	taikoL2Address  common.Address
 	graffiti        [32]byte
@@ -44,6 +46,7 @@ func NewProofSubmitter(
 	gasLimit uint64,
 	txmgr *txmgr.SimpleTxManager,
 	builder *transaction.ProveBlockTxBuilder,
+	sgxVerifier *verifier.Verifier,
 ) (*ProofSubmitter, error) {
 	anchorValidator, err := validator.New(taikoL2Address, rpcClient.L2.ChainID, rpcClient)
 	if err != nil {
@@ -57,6 +60,7 @@ func NewProofSubmitter(
 		anchorValidator: anchorValidator,
 		txBuilder:       builder,
 		sender:          transaction.NewSender(rpcClient, txmgr, gasLimit),
+		sgxVerifier:     sgxVerifier,
 		proverAddress:   txmgr.From(),
 		taikoL2Address:  taikoL2Address,
 		graffiti:        rpc.StringToBytes32(graffiti),
@@ -111,7 +115,11 @@ func (s *ProofSubmitter) RequestProof(ctx context.Context, event *bindings.Taiko
 	if err != nil {
 		return fmt.Errorf("failed to request proof (id: %d): %w", event.BlockId, err)
 	}
-	s.resultCh <- result
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.resultCh <- result:
+	}
 
 	metrics.ProverQueuedProofCounter.Add(1)
 
@@ -136,6 +144,34 @@ func (s *ProofSubmitter) SubmitProof(
 
 	metrics.ProverReceivedProofCounter.Add(1)
 
+	if err := s.validateAnchorTx(ctx, proofWithHeader); err != nil {
+		return err
+	}
+
+	if err := s.verifyProof(ctx, proofWithHeader); err != nil {
+		return err
+	}
+
+	// Build the TaikoL1.proveBlock transaction and send it to the L1 node.
+	if err = s.sender.Send(ctx, proofWithHeader, s.buildTx(proofWithHeader)); err != nil {
+		if err.Error() == transaction.ErrUnretryableSubmission.Error() {
+			return nil
+		}
+		metrics.ProverSubmissionErrorCounter.Add(1)
+		return err
+	}
+
+	metrics.ProverSentProofCounter.Add(1)
+	metrics.ProverLatestProvenBlockIDGauge.Set(float64(proofWithHeader.BlockID.Uint64()))
+	metrics.ProverChainLatestProvenIDGaugeVec.WithLabelValues(s.rpc.L2.ChainID.String()).Set(
+		float64(proofWithHeader.BlockID.Uint64()),
+	)
+
+	return nil
+}
+
+// validateAnchorTx checks the TaikoL2.anchor transaction inside the L2 block of the given proof.
+func (s *ProofSubmitter) validateAnchorTx(ctx context.Context, proofWithHeader *proofProducer.ProofWithHeader) error {
 	// Get the corresponding L2 block.
 	block, err := s.rpc.L2.BlockByHash(ctx, proofWithHeader.Header.Hash())
 	if err != nil {
@@ -152,39 +188,57 @@ func (s *ProofSubmitter) SubmitProof(
 		return fmt.Errorf("invalid anchor transaction: %w", err)
 	}
 
-	// Build the TaikoL1.proveBlock transaction and send it to the L1 node.
-	if err = s.sender.Send(
+	return nil
+}
+
+// verifyProof verifies the SGX proof locally, if the current proof submitter has an SGX verifier.
+func (s *ProofSubmitter) verifyProof(ctx context.Context, proofWithHeader *proofProducer.ProofWithHeader) error {
+	if s.sgxVerifier == nil {
+		return nil
+	}
+
+	if err := s.sgxVerifier.Verify(
 		ctx,
-		proofWithHeader,
-		s.txBuilder.Build(
-			proofWithHeader.BlockID,
-			proofWithHeader.Meta,
-			&bindings.TaikoDataTransition{
-				ParentHash: proofWithHeader.Header.ParentHash,
-				BlockHash:  proofWithHeader.Opts.BlockHash,
-				StateRoot:  proofWithHeader.Opts.StateRoot,
-				Graffiti:   s.graffiti,
-			},
-			&bindings.TaikoDataTierProof{
-				Tier: proofWithHeader.Tier,
-				Data: proofWithHeader.Proof,
-			},
-			proofWithHeader.Tier,
-		),
+		s.transition(proofWithHeader),
+		proofWithHeader.Opts.ProverAddress,
+		proofWithHeader.Opts.MetaHash,
+		proofWithHeader.Proof,
 	); err != nil {
-		if err.Error() == transaction.ErrUnretryableSubmission.Error() {
-			return nil
+		metrics.ProverSgxProofInvalidCounter.Add(1)
+		// Generate the proof again for the next request, instead of the cached invalid one.
+		if invalidator, ok := s.proofProducer.(proofProducer.ProofInvalidator); ok {
+			invalidator.Invalidate(proofWithHeader)
 		}
-		metrics.ProverSubmissionErrorCounter.Add(1)
-		return err
+		return fmt.Errorf("SGX proof verification failed (id: %d): %w", proofWithHeader.BlockID, err)
 	}
 
-	metrics.ProverSentProofCounter.Add(1)
-	metrics.ProverLatestProvenBlockIDGauge.Set(float64(proofWithHeader.BlockID.Uint64()))
-
 	return nil
 }
 
+// transition returns the block transition proven by the given proof.
+func (s *ProofSubmitter) transition(proofWithHeader *proofProducer.ProofWithHeader) *bindings.TaikoDataTransition {
+	return &bindings.TaikoDataTransition{
+		ParentHash: proofWithHeader.Header.ParentHash,
+		BlockHash:  proofWithHeader.Opts.BlockHash,
+		StateRoot:  proofWithHeader.Opts.StateRoot,
+		Graffiti:   s.graffiti,
+	}
+}
+
+// buildTx returns a TaikoL1.proveBlock transaction builder for the given proof.
+func (s *ProofSubmitter) buildTx(proofWithHeader *proofProducer.ProofWithHeader) transaction.TxBuilder {
+	return s.txBuilder.Build(
+		proofWithHeader.BlockID,
+		proofWithHeader.Meta,
+		s.transition(proofWithHeader),
+		&bindings.TaikoDataTierProof{
+			Tier: proofWithHeader.Tier,
+			Data: proofWithHeader.Proof,
+		},
+		proofWithHeader.Tier,
+	)
+}
+
 // Producer returns the inner proof producer.
 func (s *ProofSubmitter) Producer() proofProducer.ProofProducer {
 	return s.proofProducer
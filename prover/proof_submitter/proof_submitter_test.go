@@ -82,6 +82,7 @@ func (s *ProofSubmitterTestSuite) SetupTest() {
 		0,
 		txMgr,
 		builder,
+		nil,
 	)
 	s.Nil(err)
 	s.contester = NewProofContester(
@@ -17,7 +17,9 @@ import (
 	producer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
 
-// Sender is responsible for sending proof submission transactions with a backoff policy.
+// Sender is responsible for sending proof submission transactions with a backoff policy, each proof is sent
+// in its own TaikoL1.proveBlock transaction, since the protocol only accepts a proof from the assigned prover
+// itself, so the proofs can not be aggregated through a multicall contract.
 type Sender struct {
 	rpc      *rpc.Client
 	txmgr    *txmgr.SimpleTxManager
@@ -71,14 +73,19 @@ func (s *Sender) Send(
 	}
 
 	if receipt.Status != types.ReceiptStatusSuccessful {
+		err := encoding.TryParsingCustomErrorFromReceipt(ctx, s.rpc.L1, s.txmgr.From(), receipt)
 		log.Error(
 			"Failed to submit proof",
 			"blockID", proofWithHeader.BlockID,
 			"tier", proofWithHeader.Tier,
 			"txHash", receipt.TxHash,
-			"error", encoding.TryParsingCustomErrorFromReceipt(ctx, s.rpc.L1, s.txmgr.From(), receipt),
+			"error", err,
 		)
 		metrics.ProverSubmissionRevertedCounter.Add(1)
+		// The proof can be submitted again once the protocol is unpaused.
+		if IsProvingPausedError(err) {
+			return err
+		}
 		return ErrUnretryableSubmission
 	}
 
@@ -146,6 +153,12 @@ func (s *Sender) validateProof(ctx context.Context, proofWithHeader *producer.Pr
 	return true, nil
 }
 
+// IsProvingPausedError checks whether the given proof submission error is caused by the protocol pause, or
+// the proving pause.
+func IsProvingPausedError(err error) bool {
+	return err != nil && strings.Contains(encoding.TryParsingCustomError(err).Error(), "L1_INVALID_PAUSE_STATUS")
+}
+
 // isSubmitProofTxErrorRetryable checks whether the error returned by a proof submission transaction
 // is retryable.
 func isSubmitProofTxErrorRetryable(err error, blockID *big.Int) bool {
@@ -153,8 +166,7 @@ func isSubmitProofTxErrorRetryable(err error, blockID *big.Int) bool {
 		return true
 	}
 
-	if strings.HasPrefix(err.Error(), "L1_NOT_ASSIGNED_PROVER") ||
-		strings.HasPrefix(err.Error(), "L1_INVALID_PAUSE_STATUS") {
+	if strings.HasPrefix(err.Error(), "L1_NOT_ASSIGNED_PROVER") || IsProvingPausedError(err) {
 		return true
 	}
 
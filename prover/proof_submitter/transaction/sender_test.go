@@ -75,6 +75,9 @@ func (s *TransactionTestSuite) TestIsSubmitProofTxErrorRetryable() {
 	s.True(isSubmitProofTxErrorRetryable(errors.New(testAddr.String()), common.Big0))
 	s.False(isSubmitProofTxErrorRetryable(errors.New("L1_NOT_SPECIAL_PROVER"), common.Big0))
 	s.False(isSubmitProofTxErrorRetryable(errors.New("L1_DUP_PROVERS"), common.Big0))
+	s.True(isSubmitProofTxErrorRetryable(errors.New("L1_INVALID_PAUSE_STATUS"), common.Big0))
+	s.True(IsProvingPausedError(errors.New("execution reverted: L1_INVALID_PAUSE_STATUS")))
+	s.False(IsProvingPausedError(errors.New("L1_DUP_PROVERS")))
 	s.False(isSubmitProofTxErrorRetryable(errors.New("L1_"+testAddr.String()), common.Big0))
 }
 
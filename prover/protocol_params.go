@@ -0,0 +1,30 @@
+package prover
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// onProtocolParamsChanged swaps in the proof submitters of the changed proof tiers for all prover identities,
+// and the changed liveness bond and tiers of the bond exposure tracker and the prover server. The proofs being
+// generated are finished by the submitters which requested them, and submitted by the new submitters of their
+// tiers, the finished proofs of a removed tier are dropped.
+func (p *Prover) onProtocolParamsChanged(params *rpc.ProtocolParams) error {
+	if err := p.initProofSubmitters(p.txmgr, p.txBuilder, params.Tiers); err != nil {
+		return fmt.Errorf("failed to swap in the proof submitters of the changed protocol tiers: %w", err)
+	}
+	p.bondExposure.SetLivenessBond(params.Config.LivenessBond)
+	p.server.SetProtocolParams(params.Config, params.Config.LivenessBond, params.Tiers)
+
+	log.Info(
+		"Changed protocol parameters applied",
+		"chainID", p.chainID(),
+		"livenessBond", params.Config.LivenessBond,
+		"tiers", len(params.Tiers),
+	)
+
+	return nil
+}
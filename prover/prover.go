@@ -10,7 +10,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -24,20 +23,36 @@ import (
 	"github.com/taikoxyz/taiko-client/internal/version"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/prover/accounting"
+	ledger "github.com/taikoxyz/taiko-client/prover/assignment_ledger"
+	exposure "github.com/taikoxyz/taiko-client/prover/bond_exposure"
+	engine "github.com/taikoxyz/taiko-client/prover/contest_engine"
 	handler "github.com/taikoxyz/taiko-client/prover/event_handler"
+	monitor "github.com/taikoxyz/taiko-client/prover/guardian_monitor"
 	guardianProverHeartbeater "github.com/taikoxyz/taiko-client/prover/guardian_prover_heartbeater"
+	"github.com/taikoxyz/taiko-client/prover/pipeline"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	scheduler "github.com/taikoxyz/taiko-client/prover/proof_scheduler"
 	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
 	"github.com/taikoxyz/taiko-client/prover/proof_submitter/transaction"
 	"github.com/taikoxyz/taiko-client/prover/server"
+	verifier "github.com/taikoxyz/taiko-client/prover/sgx_verifier"
 	state "github.com/taikoxyz/taiko-client/prover/shared_state"
+	gate "github.com/taikoxyz/taiko-client/prover/submission_gate"
+)
+
+var (
+	// pendingProofsShutdownTimeout is the maximum time to wait for submitting the pending proofs on shutdown.
+	pendingProofsShutdownTimeout = 1 * time.Minute
+	// pipelineDrainTimeout is the maximum time to wait for the pipeline stages to finish their queued jobs
+	// on shutdown.
+	pipelineDrainTimeout = 1 * time.Minute
 )
 
 // Prover keeps trying to prove newly proposed blocks.
 type Prover struct {
 	// Configurations
-	cfg     *Config
-	backoff backoff.BackOffContext
+	cfg *Config
 
 	// Clients
 	rpc *rpc.Client
@@ -45,9 +60,22 @@ type Prover struct {
 	// Guardian prover related
 	server                    *server.ProverServer
 	guardianProverHeartbeater guardianProverHeartbeater.BlockSenderHeartbeater
+	guardianMonitor           *monitor.Monitor
+
+	// Assignments signed by the prover server
+	assignmentLedger     *ledger.Ledger
+	assignmentReconciler *ledger.Reconciler
+
+	// Liveness bonds at risk
+	bondExposure *exposure.Tracker
 
-	// Contract configurations
+	// Fees, bonds and gas costs of the prover identities
+	accountingRecorder *accounting.Recorder
+
+	// Contract configurations at startup, the current ones and the proof tiers are kept by the config watcher,
+	// and swapped in once they are changed
 	protocolConfig *bindings.TaikoDataConfig
+	configWatcher  *rpc.ConfigWatcher
 
 	// States
 	sharedState     *state.SharedState
@@ -60,9 +88,19 @@ type Prover struct {
 	transitionProvedHandler    handler.TransitionProvedHandler
 	assignmentExpiredHandler   handler.AssignmentExpiredHandler
 
-	// Proof submitters
+	// Proof submitters, the submitters of all prover identities are swapped with the mutex held
 	proofSubmitters []proofSubmitter.Submitter
+	submittersMutex sync.RWMutex
+	txBuilder       *transaction.ProveBlockTxBuilder
 	proofContester  proofSubmitter.Contester
+	contestEngine   *engine.Engine
+
+	// SGX verifiers shared by the proof submitters of all prover identities, keyed by the verifier names
+	sgxVerifiers      map[[32]byte]*verifier.Verifier
+	sgxVerifiersMutex sync.Mutex
+
+	// Generated proofs shared by the proof submitters, so that each proof is only requested once
+	proofCache *proofProducer.ProofCache
 
 	assignmentExpiredCh chan *bindings.TaikoL1ClientBlockProposed
 	proveNotify         chan struct{}
@@ -71,12 +109,53 @@ type Prover struct {
 	proofSubmissionCh chan *proofProducer.ProofRequestBody
 	proofContestCh    chan *proofProducer.ContestRequestBody
 	proofGenerationCh chan *proofProducer.ProofWithHeader
+	proofReleasedCh   chan *proofProducer.ProofWithHeader
+
+	// Proof requests waiting to be dispatched by the earliest deadline first
+	proofScheduler *scheduler.Scheduler
+
+	// Finished proofs held until the L1 base fee drops, nil if disabled
+	submissionGate *gate.Gate
+
+	// Pause status of the protocol, the proofs and contests are held while the proving is paused, and
+	// resumed once the resume channels are notified
+	pauseWatcher     *rpc.PauseWatcher
+	proofsResumeCh   chan struct{}
+	contestsResumeCh chan struct{}
+
+	// Proofs and contests held while the proving is paused
+	pausedProofs   []*proofProducer.ProofWithHeader
+	pausedContests []*proofProducer.ContestRequestBody
+	pausedMutex    sync.Mutex
+
+	// Pipeline stages, the proof requests are scheduled by the proof scheduler in between the discover
+	// and the generate stages
+	discoverStage *pipeline.Stage
+	generateStage *pipeline.Stage
+	submitStage   *pipeline.Stage
+	contestStage  *pipeline.Stage
+	// Closed once the generate stage is drained on shutdown, the submit loop keeps collecting the generated
+	// proofs until then
+	generateDrainedCh chan struct{}
 
 	// Transactions manager
 	txmgr *txmgr.SimpleTxManager
 
+	// Additional prover identities
+	identities []*proverIdentity
+
+	// Provers of the additional L2 chains, and the prover of the primary chain which they share the L1
+	// connection, the transactions managers and the generate stage with, nil for the primary chain
+	chains []*Prover
+	parent *Prover
+
 	ctx context.Context
-	wg  sync.WaitGroup
+	// The pipeline jobs are run with the drain context, which is only cancelled once the stages are drained
+	// on shutdown, or the drain timeout expires.
+	drainCtx    context.Context
+	drainCancel context.CancelFunc
+	wg          sync.WaitGroup
+	submitWg    sync.WaitGroup
 }
 
 // InitFromCli initializes the given prover instance based on the command line flags.
@@ -93,64 +172,91 @@ func (p *Prover) InitFromCli(ctx context.Context, c *cli.Context) error {
 func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 	p.cfg = cfg
 	p.ctx = ctx
+	p.drainCtx, p.drainCancel = context.WithCancel(context.WithoutCancel(ctx))
 	// Initialize state which will be shared by event handlers.
 	p.sharedState = state.New()
-	p.backoff = backoff.WithContext(
-		backoff.WithMaxRetries(
-			backoff.NewConstantBackOff(p.cfg.BackOffRetryInterval),
-			p.cfg.BackOffMaxRetries,
-		),
-		p.ctx,
-	)
 
 	// Clients
-	if p.rpc, err = rpc.NewClient(p.ctx, &rpc.ClientConfig{
-		L1Endpoint:                    cfg.L1WsEndpoint,
-		L2Endpoint:                    cfg.L2WsEndpoint,
-		TaikoL1Address:                cfg.TaikoL1Address,
-		TaikoL2Address:                cfg.TaikoL2Address,
-		TaikoTokenAddress:             cfg.TaikoTokenAddress,
-		GuardianProverMinorityAddress: cfg.GuardianProverMinorityAddress,
-		GuardianProverMajorityAddress: cfg.GuardianProverMajorityAddress,
-		Timeout:                       cfg.RPCTimeout,
-	}); err != nil {
+	var shared *rpc.Client
+	if p.parent != nil {
+		shared = p.parent.rpc
+	}
+	if p.rpc, err = newRPCClient(p.ctx, cfg, shared); err != nil {
 		return err
 	}
 
 	// Configs
-	protocolConfigs, err := p.rpc.TaikoL1.GetConfig(&bind.CallOpts{Context: ctx})
-	if err != nil {
+	p.configWatcher = rpc.NewConfigWatcher(p.rpc, p.onProtocolParamsChanged)
+	if err := p.configWatcher.Init(ctx); err != nil {
 		return fmt.Errorf("failed to get protocol configs: %w", err)
 	}
-	p.protocolConfig = &protocolConfigs
+	p.protocolConfig = p.configWatcher.Params().Config
 
 	log.Info("Protocol configs", "configs", p.protocolConfig)
 
 	chBufferSize := p.protocolConfig.BlockMaxProposals
 	p.proofGenerationCh = make(chan *proofProducer.ProofWithHeader, chBufferSize)
+	p.proofReleasedCh = make(chan *proofProducer.ProofWithHeader, chBufferSize)
 	p.assignmentExpiredCh = make(chan *bindings.TaikoL1ClientBlockProposed, chBufferSize)
 	p.proofSubmissionCh = make(chan *proofProducer.ProofRequestBody, p.cfg.Capacity)
 	p.proofContestCh = make(chan *proofProducer.ContestRequestBody, p.cfg.Capacity)
 	p.proveNotify = make(chan struct{}, 1)
+	p.proofsResumeCh = make(chan struct{}, 1)
+	p.contestsResumeCh = make(chan struct{}, 1)
+
+	// Protocol pause watcher
+	p.pauseWatcher = rpc.NewPauseWatcher(p.rpc, p.onPauseStatusChanged)
+	if err := p.pauseWatcher.Init(ctx); err != nil {
+		return fmt.Errorf("failed to read protocol pause status: %w", err)
+	}
+
+	// Pipeline stages
+	p.discoverStage = p.newStage(p.chainID(), "discover", p.cfg.PipelineWorkers, chBufferSize)
+	p.submitStage = p.newStage(p.chainID(), "submit", p.cfg.PipelineWorkers, chBufferSize)
+	p.contestStage = p.newStage(p.chainID(), "contest", p.cfg.PipelineWorkers, p.cfg.Capacity)
+	if p.parent != nil {
+		p.generateStage = p.parent.generateStage
+		p.generateDrainedCh = p.parent.generateDrainedCh
+	} else {
+		p.generateStage = p.newStage("", "generate", p.cfg.Capacity, p.cfg.Capacity)
+		p.generateDrainedCh = make(chan struct{})
+	}
+
+	if p.proofScheduler, err = scheduler.New(
+		&scheduler.Config{
+			Chain:                  p.chainID(),
+			StateFile:              p.cfg.SchedulerStateFile,
+			DropLateProofs:         p.cfg.DropLateProofs,
+			Capacity:               p.cfg.Capacity,
+			MaxOpportunisticProofs: p.cfg.MaxUnassignedProofs,
+			ProofCosts:             p.cfg.UnassignedProofCosts,
+			BondTokenPrice:         p.cfg.UnassignedBondTokenPrice,
+		},
+		p.rpc,
+		p.proofSubmissionCh,
+		p.assignmentExpiredCh,
+		p.sharedState.GetInflightProofs,
+	); err != nil {
+		return err
+	}
 
 	if err := p.initL1Current(cfg.StartingBlockID); err != nil {
 		return fmt.Errorf("initialize L1 current cursor error: %w", err)
 	}
 
 	// Protocol proof tiers
-	tiers, err := p.rpc.GetTiers(ctx)
-	if err != nil {
-		return err
-	}
+	tiers := p.configWatcher.Params().Tiers
 	p.sharedState.SetTiers(tiers)
 
-	txBuilder := transaction.NewProveBlockTxBuilder(
+	p.txBuilder = transaction.NewProveBlockTxBuilder(
 		p.rpc, p.cfg.TaikoL1Address,
 		p.cfg.GuardianProverMajorityAddress,
 		p.cfg.GuardianProverMinorityAddress,
 	)
 
-	if p.txmgr, err = txmgr.NewSimpleTxManager(
+	if p.parent != nil {
+		p.txmgr = p.parent.txmgr
+	} else if p.txmgr, err = txmgr.NewSimpleTxManager(
 		"prover",
 		log.Root(),
 		&metrics.TxMgrMetrics,
@@ -158,9 +264,14 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 	); err != nil {
 		return err
 	}
+	if err := p.initIdentities(); err != nil {
+		return err
+	}
 
 	// Proof submitters
-	if err := p.initProofSubmitters(p.txmgr, txBuilder); err != nil {
+	// Only the proofs actually generated are sampled by the scheduler, not the ones served from the cache.
+	p.proofCache = proofProducer.NewProofCache(int(cfg.ProofCacheSize), p.proofScheduler.Observe)
+	if err := p.initProofSubmitters(p.txmgr, p.txBuilder, tiers); err != nil {
 		return err
 	}
 
@@ -170,8 +281,72 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 		p.cfg.ProveBlockGasLimit,
 		p.txmgr,
 		p.cfg.Graffiti,
-		txBuilder,
+		p.txBuilder,
+	)
+
+	// Contest decision engine
+	if p.cfg.ContesterMode && p.cfg.Contest != nil {
+		p.contestEngine = engine.New(p.cfg.Contest, p.ProverAddress(), p.sharedState.GetTiers, p.provableTier)
+	}
+
+	// Proof submission gate
+	if p.cfg.SubmissionGate != nil {
+		p.submissionGate = gate.New(
+			p.cfg.SubmissionGate,
+			p.rpc,
+			p.chainID(),
+			p.proverAddresses(),
+			p.sharedState.GetTiers,
+			p.proofReleasedCh,
+		)
+	}
+
+	// Assignment ledger
+	if p.assignmentLedger, err = ledger.New(ledger.DefaultRetention, p.cfg.AssignmentLedgerStateFile, p.chainID()); err != nil {
+		return err
+	}
+	p.assignmentReconciler = ledger.NewReconciler(p.rpc, p.assignmentLedger, p.proverAddresses())
+
+	// Liveness bond exposure tracker
+	p.bondExposure = exposure.New(
+		p.rpc,
+		p.chainID(),
+		p.proverAddresses(),
+		p.protocolConfig.LivenessBond,
+		p.cfg.MaxBondExposureRatio,
+		p.assignmentLedger.Pending,
 	)
+	if err := p.bondExposure.Init(ctx); err != nil {
+		return err
+	}
+
+	// Prover accounting
+	book, err := accounting.NewBook(p.cfg.AccountingDir, p.cfg.AccountingPeriod, p.chainID())
+	if err != nil {
+		return fmt.Errorf("failed to initialize prover accounting: %w", err)
+	}
+	if p.accountingRecorder, err = accounting.NewRecorder(
+		p.rpc,
+		book,
+		p.cfg.AssignmentHookAddress,
+		p.proverAddresses(),
+		p.sharedState.GetTiers,
+	); err != nil {
+		return err
+	}
+
+	// Guardian approval monitor
+	if p.rpc.GuardianProverMajority != nil {
+		p.guardianMonitor = monitor.New(
+			p.rpc,
+			p.cfg.GuardianProverMajorityAddress,
+			p.cfg.GuardianProverMinorityAddress,
+			p.cfg.GuardianQuorumStuckThreshold,
+		)
+		if err := p.guardianMonitor.Init(ctx); err != nil {
+			return err
+		}
+	}
 
 	// Prover server
 	if p.server, err = server.New(&server.NewProverServerOpts{
@@ -186,8 +361,19 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 		TaikoL1Address:        p.cfg.TaikoL1Address,
 		AssignmentHookAddress: p.cfg.AssignmentHookAddress,
 		RPC:                   p.rpc,
-		ProtocolConfigs:       &protocolConfigs,
-		LivenessBond:          protocolConfigs.LivenessBond,
+		ProtocolConfigs:       p.protocolConfig,
+		LivenessBond:          p.protocolConfig.LivenessBond,
+		Tiers:                 tiers,
+		Pricing:               p.cfg.Pricing,
+		Capacity:              p.cfg.Capacity,
+		InflightProofs:        p.sharedState.GetInflightProofs,
+		Auth:                  p.cfg.Auth,
+		AssignmentLedger:      p.assignmentLedger,
+		BondExposure:          p.bondExposure,
+		ProofScheduler:        p.proofScheduler,
+		GuardianMonitor:       p.guardianMonitor,
+		PauseWatcher:          p.pauseWatcher,
+		Identities:            p.cfg.Identities,
 	}); err != nil {
 		return err
 	}
@@ -205,12 +391,16 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 			}
 		}
 
-		p.guardianProverHeartbeater = guardianProverHeartbeater.New(
+		if p.guardianProverHeartbeater, err = guardianProverHeartbeater.New(
 			p.cfg.L1ProverPrivKey,
 			p.cfg.GuardianProverHealthCheckServerEndpoint,
 			p.rpc,
 			p.ProverAddress(),
-		)
+			p.cfg.GuardianOutboxFile,
+			p.cfg.GuardianOutboxSize,
+		); err != nil {
+			return err
+		}
 	}
 
 	// Initialize event handlers.
@@ -218,26 +408,78 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 		return err
 	}
 
+	// Provers of the additional L2 chains
+	if p.parent == nil {
+		if err := p.initChains(ctx); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// newRPCClient creates a new RPC client from the given prover configurations, the L1 clients of the given
+// shared client are used if it is not nil.
+func newRPCClient(ctx context.Context, cfg *Config, shared *rpc.Client) (*rpc.Client, error) {
+	rpcConfig := &rpc.ClientConfig{
+		L1Endpoint:                    cfg.L1WsEndpoint,
+		L2Endpoint:                    cfg.L2WsEndpoint,
+		TaikoL1Address:                cfg.TaikoL1Address,
+		TaikoL2Address:                cfg.TaikoL2Address,
+		TaikoTokenAddress:             cfg.TaikoTokenAddress,
+		GuardianProverMinorityAddress: cfg.GuardianProverMinorityAddress,
+		GuardianProverMajorityAddress: cfg.GuardianProverMajorityAddress,
+		Timeout:                       cfg.RPCTimeout,
+	}
+	if shared != nil {
+		rpcConfig.L1Client, rpcConfig.L1BeaconClient = shared.L1, shared.L1Beacon
+	}
+
+	return rpc.NewClient(ctx, rpcConfig)
+}
+
 // Start starts the main loop of the L2 block prover.
 func (p *Prover) Start() error {
-	// 1. Set approval amount for the contracts.
-	for _, contract := range []common.Address{p.cfg.TaikoL1Address, p.cfg.AssignmentHookAddress} {
-		if err := p.setApprovalAmount(p.ctx, contract); err != nil {
-			log.Crit("Failed to set approval amount", "contract", contract, "error", err)
+	// 1. Set approval amount for the contracts, for all prover identities.
+	txMgrs := []*txmgr.SimpleTxManager{p.txmgr}
+	for _, identity := range p.identities {
+		txMgrs = append(txMgrs, identity.txmgr)
+	}
+	for _, txMgr := range txMgrs {
+		for _, contract := range []common.Address{p.cfg.TaikoL1Address, p.cfg.AssignmentHookAddress} {
+			if err := p.setApprovalAmount(p.ctx, txMgr, contract); err != nil {
+				log.Crit("Failed to set approval amount", "prover", txMgr.From(), "contract", contract, "error", err)
+			}
 		}
 	}
 
-	// 2. Start the prover server.
-	go func() {
-		if err := p.server.Start(fmt.Sprintf(":%v", p.cfg.HTTPServerPort)); !errors.Is(err, http.ErrServerClosed) {
-			log.Crit("Failed to start http server", "error", err)
-		}
-	}()
+	// 2. Start the prover server, the prover servers of the additional L2 chains are routed by it.
+	if p.parent == nil {
+		go func() {
+			if err := p.server.Start(fmt.Sprintf(":%v", p.cfg.HTTPServerPort)); !errors.Is(err, http.ErrServerClosed) {
+				log.Crit("Failed to start http server", "error", err)
+			}
+		}()
+	}
+
+	// 3. Start the assignment ledger reconciler, the liveness bond exposure tracker and the accounting recorder.
+	p.assignmentReconciler.Start(p.ctx)
+	p.bondExposure.Start(p.ctx)
+	p.accountingRecorder.Start(p.ctx)
+
+	// 4. Start the proof scheduler, the proof submission gate, the protocol pause and config watchers.
+	p.proofScheduler.Start(p.ctx)
+	if p.submissionGate != nil {
+		p.submissionGate.Start(p.ctx)
+	}
+	p.pauseWatcher.Start(p.ctx)
+	p.configWatcher.Start(p.ctx)
 
-	// 3. Start the guardian prover heartbeat sender if the current prover is a guardian prover.
+	// 5. Start the guardian approval monitor, and the guardian prover heartbeat sender if the current prover
+	// is a guardian prover.
+	if p.guardianMonitor != nil {
+		p.guardianMonitor.Start(p.ctx)
+	}
 	if p.IsGuardianProver() && p.cfg.GuardianProverHealthCheckServerEndpoint != nil {
 		// Send the startup message to the guardian prover health check server.
 		if err := p.guardianProverHeartbeater.SendStartupMessage(
@@ -250,17 +492,54 @@ func (p *Prover) Start() error {
 			log.Error("Failed to send guardian prover startup message", "error", err)
 		}
 
-		// Start the guardian prover heartbeat loop.
+		// Start delivering the signed blocks and heartbeats, and the guardian prover heartbeat loop.
+		p.guardianProverHeartbeater.Start(p.ctx)
 		go p.guardianProverHeartbeatLoop(p.ctx)
 	}
 
-	// 4. Start the main event loop of the prover.
+	// 6. Start the pipeline stages, and the main event loop of the prover.
+	p.startStages()
+	p.wg.Add(2)
+	p.submitWg.Add(1)
+	go p.generateLoop()
+	go p.submitLoop()
+	go p.contestLoop()
 	go p.eventLoop()
 
+	// 7. Start the provers of the additional L2 chains.
+	for _, chain := range p.chains {
+		if err := chain.Start(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// eventLoop starts the main loop of Taiko prover.
+// newStage creates a new pipeline stage of the given L2 chain with the prover backoff policy, an empty
+// chain means the stage is shared by all chains.
+func (p *Prover) newStage(chain string, name string, workers uint64, queueSize uint64) *pipeline.Stage {
+	return pipeline.New(&pipeline.Config{
+		Name:          name,
+		Chain:         chain,
+		Workers:       workers,
+		QueueSize:     queueSize,
+		RetryInterval: p.cfg.BackOffRetryInterval,
+		MaxRetries:    p.cfg.BackOffMaxRetries,
+	})
+}
+
+// stages returns the pipeline stages owned by the prover in the order of the proving flow, the generate
+// stage is owned by the prover of the primary chain.
+func (p *Prover) stages() []*pipeline.Stage {
+	if p.parent != nil {
+		return []*pipeline.Stage{p.discoverStage, p.submitStage, p.contestStage}
+	}
+	return []*pipeline.Stage{p.discoverStage, p.generateStage, p.submitStage, p.contestStage}
+}
+
+// eventLoop starts the main loop of Taiko prover, which discovers the blocks to prove and the
+// transitions to contest.
 func (p *Prover) eventLoop() {
 	p.wg.Add(1)
 	defer p.wg.Done()
@@ -304,40 +583,190 @@ func (p *Prover) eventLoop() {
 		select {
 		case <-p.ctx.Done():
 			return
-		case req := <-p.proofContestCh:
-			p.withRetry(func() error { return p.contestProofOp(req) })
-		case proofWithHeader := <-p.proofGenerationCh:
-			p.withRetry(func() error { return p.submitProofOp(proofWithHeader) })
-		case req := <-p.proofSubmissionCh:
-			p.withRetry(func() error { return p.requestProofOp(req.Event, req.Tier) })
 		case <-p.proveNotify:
 			if err := p.proveOp(); err != nil {
 				log.Error("Prove new blocks error", "error", err)
 			}
 		case e := <-blockVerifiedCh:
 			p.blockVerifiedHandler.Handle(e)
+			metrics.ProverChainLatestVerifiedIDGaugeVec.WithLabelValues(p.chainID()).Set(float64(e.BlockId.Uint64()))
+			if p.contestEngine != nil {
+				p.contestEngine.ReleaseUntil(e.BlockId)
+			}
+			p.proofCache.ForgetUntil(e.BlockId)
 		case e := <-transitionProvedCh:
-			p.withRetry(func() error { return p.transitionProvedHandler.Handle(p.ctx, e) })
+			p.withRetry(p.discoverStage, func(ctx context.Context) error {
+				return p.transitionProvedHandler.Handle(ctx, e)
+			})
 		case e := <-transitionContestedCh:
-			p.withRetry(func() error { return p.transitionContestedHandler.Handle(p.ctx, e) })
+			p.withRetry(p.discoverStage, func(ctx context.Context) error {
+				return p.transitionContestedHandler.Handle(ctx, e)
+			})
 		case e := <-p.assignmentExpiredCh:
-			p.withRetry(func() error { return p.assignmentExpiredHandler.Handle(p.ctx, e) })
+			p.withRetryOrElse(
+				p.discoverStage,
+				func(ctx context.Context) error { return p.assignmentExpiredHandler.Handle(ctx, e) },
+				func() { p.proofScheduler.Forget(e.BlockId) },
+			)
 		case <-blockProposedCh:
 			reqProving()
 		case <-forceProvingTicker.C:
+			metrics.ProverChainInflightProofsGaugeVec.WithLabelValues(p.chainID()).Set(
+				float64(p.sharedState.GetInflightProofs()),
+			)
 			reqProving()
 		}
 	}
 }
 
+// generateLoop passes the scheduled proof requests to the generate stage.
+func (p *Prover) generateLoop() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case req := <-p.proofSubmissionCh:
+			p.sharedState.IncInflightProofs()
+			p.withRetryOrElse(
+				p.generateStage,
+				func(ctx context.Context) error { return p.requestProofOp(ctx, req.Event, req.Tier) },
+				func() {
+					p.sharedState.DecInflightProofs(1)
+					p.proofScheduler.Forget(req.Event.BlockId)
+				},
+			)
+		}
+	}
+}
+
+// submitLoop passes the generated proofs to the submit stage, through the submission gate if enabled.
+func (p *Prover) submitLoop() {
+	defer p.submitWg.Done()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			p.submitPendingProofsOnShutdown(p.collectGeneratedProofs())
+			return
+		case proofWithHeader := <-p.proofGenerationCh:
+			if p.submissionGate != nil {
+				p.submissionGate.Submit(proofWithHeader)
+				continue
+			}
+			p.submitProof(proofWithHeader)
+		case proofWithHeader := <-p.proofReleasedCh:
+			p.submitProof(proofWithHeader)
+		case <-p.proofsResumeCh:
+			p.resumePausedProofs()
+		}
+	}
+}
+
+// contestLoop passes the proof contest requests to the contest stage.
+func (p *Prover) contestLoop() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case req := <-p.proofContestCh:
+			p.submitContest(req)
+		case <-p.contestsResumeCh:
+			p.resumePausedContests()
+		}
+	}
+}
+
+// collectGeneratedProofs collects the proofs generated while the generate stage is drained on shutdown, until
+// it's drained.
+func (p *Prover) collectGeneratedProofs() []*proofProducer.ProofWithHeader {
+	var proofs []*proofProducer.ProofWithHeader
+	for {
+		select {
+		case proofWithHeader := <-p.proofGenerationCh:
+			proofs = append(proofs, proofWithHeader)
+		case <-p.generateDrainedCh:
+			return proofs
+		}
+	}
+}
+
 // Close closes the prover instance.
 func (p *Prover) Close(ctx context.Context) {
 	if err := p.server.Shutdown(ctx); err != nil {
 		log.Error("Failed to shut down prover server", "error", err)
 	}
+	p.wait()
+
+	// No more jobs are submitted once the loops exit, let each stage finish its queued jobs.
+	p.drainStages()
+}
+
+// wait waits for the background services and the loops of the prover and the additional L2 chain provers
+// to exit, except the submit loops, which keep collecting the generated proofs until the generate stage
+// is drained.
+func (p *Prover) wait() {
+	for _, chain := range p.chains {
+		chain.wait()
+	}
+	p.assignmentReconciler.Wait()
+	p.assignmentLedger.Close()
+	p.bondExposure.Wait()
+	p.accountingRecorder.Wait()
+	p.proofScheduler.Wait()
+	if p.submissionGate != nil {
+		p.submissionGate.Wait()
+	}
+	p.pauseWatcher.Wait()
+	p.configWatcher.Wait()
+	if p.guardianMonitor != nil {
+		p.guardianMonitor.Wait()
+	}
+	if p.guardianProverHeartbeater != nil {
+		p.guardianProverHeartbeater.Wait()
+	}
 	p.wg.Wait()
 }
 
+// startStages starts the pipeline stages with the drain context.
+func (p *Prover) startStages() {
+	for _, stage := range p.stages() {
+		stage.Start(p.drainCtx)
+	}
+}
+
+// drainStages closes the pipeline stages of the prover and the additional L2 chain provers in the order of
+// the proving flow, and waits for each stage to finish its queued jobs, the jobs still running once the drain
+// timeout expires are cancelled. The pending proofs are submitted once all proofs have been generated, before
+// the submit stages are drained.
+func (p *Prover) drainStages() {
+	provers := append([]*Prover{p}, p.chains...)
+	for _, prover := range provers {
+		timer := time.AfterFunc(pipelineDrainTimeout, prover.drainCancel)
+		defer timer.Stop()
+		defer prover.drainCancel()
+	}
+
+	for _, prover := range provers {
+		prover.discoverStage.Close()
+		prover.discoverStage.Wait()
+	}
+	p.generateStage.Close()
+	p.generateStage.Wait()
+	close(p.generateDrainedCh)
+
+	for _, prover := range provers {
+		prover.submitWg.Wait()
+		for _, stage := range []*pipeline.Stage{prover.submitStage, prover.contestStage} {
+			stage.Close()
+			stage.Wait()
+		}
+	}
+}
+
 // proveOp iterates through BlockProposed events.
 func (p *Prover) proveOp() error {
 	iter, err := eventIterator.NewBlockProposedIterator(p.ctx, &eventIterator.BlockProposedIteratorConfig{
@@ -355,16 +784,43 @@ func (p *Prover) proveOp() error {
 	return iter.Iter()
 }
 
+// submitContest submits the given proof contest request to the contest stage, or holds it while the proving
+// is paused.
+func (p *Prover) submitContest(req *proofProducer.ContestRequestBody) {
+	if !p.pauseWatcher.CanProve() {
+		p.holdContest(req)
+		return
+	}
+	p.withRetryOrElse(p.contestStage, func(ctx context.Context) error {
+		// The contest is submitted again once the proving is resumed, instead of being retried and dropped.
+		if err := p.contestProofOp(ctx, req); errors.Is(err, errProvingPaused) {
+			p.holdContest(req)
+		} else if err != nil {
+			return err
+		}
+		return nil
+	}, func() {
+		p.releaseContestBonds(req.BlockID)
+	})
+}
+
 // contestProofOp performs a proof contest operation.
-func (p *Prover) contestProofOp(req *proofProducer.ContestRequestBody) error {
+func (p *Prover) contestProofOp(ctx context.Context, req *proofProducer.ContestRequestBody) error {
+	if !p.pauseWatcher.CanProve() {
+		return errProvingPaused
+	}
+
 	if err := p.proofContester.SubmitContest(
-		p.ctx,
+		ctx,
 		req.BlockID,
 		req.ProposedIn,
 		req.ParentHash,
 		req.Meta,
 		req.Tier,
 	); err != nil {
+		if transaction.IsProvingPausedError(err) {
+			return errProvingPaused
+		}
 		if strings.Contains(err.Error(), vm.ErrExecutionReverted.Error()) {
 			log.Error(
 				"Proof contest submission reverted",
@@ -372,6 +828,7 @@ func (p *Prover) contestProofOp(req *proofProducer.ContestRequestBody) error {
 				"minTier", req.Meta.MinTier,
 				"error", err,
 			)
+			p.releaseContestBonds(req.BlockID)
 			return nil
 		}
 		log.Error(
@@ -386,36 +843,77 @@ func (p *Prover) contestProofOp(req *proofProducer.ContestRequestBody) error {
 	return nil
 }
 
-// requestProofOp requests a new proof generation operation.
-func (p *Prover) requestProofOp(e *bindings.TaikoL1ClientBlockProposed, minTier uint16) error {
-	if p.IsGuardianProver() {
-		if minTier > encoding.TierGuardianMinorityID {
-			minTier = encoding.TierGuardianMajorityID
-		} else {
-			minTier = encoding.TierGuardianMinorityID
-		}
+// releaseContestBonds releases the bonds reserved for contesting the given block, once the contest failed.
+func (p *Prover) releaseContestBonds(blockID *big.Int) {
+	if p.contestEngine != nil {
+		p.contestEngine.Release(blockID)
 	}
-	if submitter := p.selectSubmitter(minTier); submitter != nil {
-		if err := submitter.RequestProof(p.ctx, e); err != nil {
+}
+
+// requestProofOp requests a new proof generation operation.
+func (p *Prover) requestProofOp(ctx context.Context, e *bindings.TaikoL1ClientBlockProposed, minTier uint16) error {
+	minTier = p.proofTier(minTier)
+	if submitter := p.selectSubmitterOf(e.AssignedProver, minTier); submitter != nil {
+		if err := submitter.RequestProof(ctx, e); err != nil {
 			log.Error("Request new proof error", "blockID", e.BlockId, "minTier", e.Meta.MinTier, "error", err)
 			return err
 		}
+		// The generation time has been observed through the proof cache, if the proof was not cached.
+		p.proofScheduler.Forget(e.BlockId)
 
 		return nil
 	}
 
 	log.Error("Failed to find proof submitter", "blockID", e.BlockId, "minTier", minTier)
+	p.sharedState.DecInflightProofs(1)
+	p.proofScheduler.Forget(e.BlockId)
 	return nil
 }
 
+// submitProof submits the given proof to the submit stage, or holds it while the proving is paused.
+func (p *Prover) submitProof(proofWithHeader *proofProducer.ProofWithHeader) {
+	if !p.pauseWatcher.CanProve() {
+		p.holdProof(proofWithHeader)
+		return
+	}
+	p.withRetryOrElse(
+		p.submitStage,
+		func(ctx context.Context) error {
+			// The proof is submitted again once the proving is resumed, instead of being retried and dropped,
+			// so it is still in flight.
+			if err := p.submitProofOp(ctx, proofWithHeader); errors.Is(err, errProvingPaused) {
+				p.holdProof(proofWithHeader)
+				return nil
+			} else if err != nil {
+				return err
+			}
+			p.sharedState.DecInflightProofs(1)
+			return nil
+		},
+		func() { p.sharedState.DecInflightProofs(1) },
+	)
+}
+
 // submitProofOp performs a proof submission operation.
-func (p *Prover) submitProofOp(proofWithHeader *proofProducer.ProofWithHeader) error {
-	submitter := p.getSubmitterByTier(proofWithHeader.Tier)
+func (p *Prover) submitProofOp(ctx context.Context, proofWithHeader *proofProducer.ProofWithHeader) error {
+	if !p.pauseWatcher.CanProve() {
+		return errProvingPaused
+	}
+
+	submitter := p.getSubmitterOf(proofWithHeader.Opts.ProverAddress, proofWithHeader.Tier)
 	if submitter == nil {
+		log.Warn(
+			"No proof submitter of the tier, drop the proof",
+			"blockID", proofWithHeader.BlockID,
+			"tier", proofWithHeader.Tier,
+		)
 		return nil
 	}
 
-	if err := submitter.SubmitProof(p.ctx, proofWithHeader); err != nil {
+	if err := submitter.SubmitProof(ctx, proofWithHeader); err != nil {
+		if transaction.IsProvingPausedError(err) {
+			return errProvingPaused
+		}
 		if strings.Contains(err.Error(), vm.ErrExecutionReverted.Error()) {
 			log.Error(
 				"Proof submission reverted",
@@ -433,18 +931,79 @@ func (p *Prover) submitProofOp(proofWithHeader *proofProducer.ProofWithHeader) e
 		)
 		return err
 	}
+	metrics.ProverChainProofsSubmittedCounterVec.WithLabelValues(p.chainID()).Inc()
 
 	return nil
 }
 
+// submitPendingProofsOnShutdown submits the given proofs generated on shutdown, and all proofs held by the
+// submission gate, buffered in the channels, or held while the proving was paused before the prover exits,
+// since the prover context has already been cancelled, a separate context with a timeout is used. The pending
+// proofs are dropped if the proving is still paused.
+func (p *Prover) submitPendingProofsOnShutdown(generated []*proofProducer.ProofWithHeader) {
+	if !p.pauseWatcher.CanProve() {
+		p.pausedMutex.Lock()
+		log.Warn(
+			"Proving paused, drop the pending proofs on shutdown",
+			"held", len(p.pausedProofs),
+			"generated", len(generated),
+		)
+		p.pausedMutex.Unlock()
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pendingProofsShutdownTimeout)
+	defer cancel()
+
+	// The proofs held while the proving was paused might not be resumed yet.
+	p.pausedMutex.Lock()
+	held := append(generated, p.pausedProofs...)
+	p.pausedProofs = nil
+	p.pausedMutex.Unlock()
+	if p.submissionGate != nil {
+		// Wait for the gate loop to exit first, so that no proof is in flight between the gate and this loop.
+		p.submissionGate.Wait()
+		held = append(held, p.submissionGate.Drain()...)
+	}
+	// The proofs which are buffered and not received by this loop yet.
+	for {
+		select {
+		case proofWithHeader := <-p.proofGenerationCh:
+			held = append(held, proofWithHeader)
+			continue
+		case proofWithHeader := <-p.proofReleasedCh:
+			held = append(held, proofWithHeader)
+			continue
+		default:
+		}
+		break
+	}
+	for _, proofWithHeader := range held {
+		submitter := p.getSubmitterOf(proofWithHeader.Opts.ProverAddress, proofWithHeader.Tier)
+		if submitter == nil {
+			continue
+		}
+
+		log.Info("Submit held proof before shutdown", "blockID", proofWithHeader.BlockID, "tier", proofWithHeader.Tier)
+		if err := submitter.SubmitProof(ctx, proofWithHeader); err != nil {
+			log.Error("Failed to submit held proof before shutdown", "blockID", proofWithHeader.BlockID, "error", err)
+		}
+	}
+}
+
 // Name returns the application name.
 func (p *Prover) Name() string {
 	return "prover"
 }
 
-// selectSubmitter returns the proof submitter with the given minTier.
+// selectSubmitter returns the proof submitter of the primary prover identity with the given minTier.
 func (p *Prover) selectSubmitter(minTier uint16) proofSubmitter.Submitter {
-	for _, s := range p.proofSubmitters {
+	return p.selectSubmitterOf(p.ProverAddress(), minTier)
+}
+
+// selectSubmitterOf returns the proof submitter of the given prover identity with the given minTier.
+func (p *Prover) selectSubmitterOf(prover common.Address, minTier uint16) proofSubmitter.Submitter {
+	for _, s := range p.submittersOf(prover) {
 		if s.Tier() >= minTier {
 			log.Debug("Proof submitter selected", "tier", s.Tier(), "minTier", minTier)
 			return s
@@ -456,9 +1015,36 @@ func (p *Prover) selectSubmitter(minTier uint16) proofSubmitter.Submitter {
 	return nil
 }
 
-// getSubmitterByTier returns the proof submitter with the given tier.
+// proofTier returns the minimum tier of the proofs generated by the current prover for the given minTier,
+// a guardian prover only generates guardian proofs.
+func (p *Prover) proofTier(minTier uint16) uint16 {
+	if !p.IsGuardianProver() {
+		return minTier
+	}
+	if minTier > encoding.TierGuardianMinorityID {
+		return encoding.TierGuardianMajorityID
+	}
+
+	return encoding.TierGuardianMinorityID
+}
+
+// provableTier returns the lowest tier no lower than the given tier, which the current prover can prove.
+func (p *Prover) provableTier(minTier uint16) (uint16, bool) {
+	if s := p.selectSubmitter(minTier); s != nil {
+		return s.Tier(), true
+	}
+
+	return 0, false
+}
+
+// getSubmitterByTier returns the proof submitter of the primary prover identity with the given tier.
 func (p *Prover) getSubmitterByTier(tier uint16) proofSubmitter.Submitter {
-	for _, s := range p.proofSubmitters {
+	return p.getSubmitterOf(p.ProverAddress(), tier)
+}
+
+// getSubmitterOf returns the proof submitter of the given prover identity with the given tier.
+func (p *Prover) getSubmitterOf(prover common.Address, tier uint16) proofSubmitter.Submitter {
+	for _, s := range p.submittersOf(prover) {
 		if s.Tier() == tier {
 			return s
 		}
@@ -479,13 +1065,20 @@ func (p *Prover) ProverAddress() common.Address {
 	return p.txmgr.From()
 }
 
-// withRetry retries the given function with prover backoff policy.
-func (p *Prover) withRetry(f func() error) {
-	p.wg.Add(1)
-	go func() {
-		defer p.wg.Done()
-		if err := backoff.Retry(f, p.backoff); err != nil {
-			log.Error("Operation failed", "error", err)
+// withRetry submits the given function to the given pipeline stage, which retries it with the prover
+// backoff policy, blocks while the stage queue is full. The function is called with the drain context,
+// so that it can still be run while the stages are drained on shutdown.
+func (p *Prover) withRetry(stage *pipeline.Stage, f func(ctx context.Context) error) {
+	p.withRetryOrElse(stage, f, nil)
+}
+
+// withRetryOrElse submits the given function to the given pipeline stage, which retries it with the prover
+// backoff policy, and calls onFailed if all retries failed, or the function can't be submitted.
+func (p *Prover) withRetryOrElse(stage *pipeline.Stage, f func(ctx context.Context) error, onFailed func()) {
+	if err := stage.Submit(p.ctx, f, onFailed); err != nil {
+		log.Warn("Failed to submit pipeline job", "error", err)
+		if onFailed != nil {
+			onFailed()
 		}
-	}()
+	}
 }
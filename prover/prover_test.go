@@ -3,10 +3,12 @@ package prover
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"net/url"
 	"os"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,7 +28,9 @@ import (
 	"github.com/taikoxyz/taiko-client/proposer"
 	guardianProverHeartbeater "github.com/taikoxyz/taiko-client/prover/guardian_prover_heartbeater"
 	producer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	proofSubmitter "github.com/taikoxyz/taiko-client/prover/proof_submitter"
 	"github.com/taikoxyz/taiko-client/prover/proof_submitter/transaction"
+	state "github.com/taikoxyz/taiko-client/prover/shared_state"
 )
 
 type ProverTestSuite struct {
@@ -167,7 +171,7 @@ func (s *ProverTestSuite) TestOnBlockProposed() {
 	e := s.ProposeAndInsertValidBlock(s.proposer, s.d.ChainSyncer().BlobSyncer())
 	s.Nil(s.p.blockProposedHandler.Handle(context.Background(), e, func() {}))
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 	s.Nil(s.p.selectSubmitter(e.Meta.MinTier).SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 
 	// Empty blocks
@@ -177,7 +181,7 @@ func (s *ProverTestSuite) TestOnBlockProposed() {
 	) {
 		s.Nil(s.p.blockProposedHandler.Handle(context.Background(), e, func() {}))
 		req := <-s.p.proofSubmissionCh
-		s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+		s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 		s.Nil(s.p.selectSubmitter(e.Meta.MinTier).SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 	}
 }
@@ -191,10 +195,156 @@ func (s *ProverTestSuite) TestOnBlockVerifiedEmptyBlockHash() {
 	})
 }
 
+func (s *ProverTestSuite) TestDrainStagesOnClose() {
+	p := &Prover{cfg: &Config{BackOffRetryInterval: time.Millisecond, BackOffMaxRetries: 1}}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.ctx = ctx
+	p.drainCtx, p.drainCancel = context.WithCancel(context.WithoutCancel(ctx))
+	p.discoverStage = p.newStage("", "discover", 1, 4)
+	p.generateStage = p.newStage("", "generate", 1, 4)
+	p.submitStage = p.newStage("", "submit", 1, 4)
+	p.contestStage = p.newStage("", "contest", 1, 4)
+	p.generateDrainedCh = make(chan struct{})
+	p.startStages()
+
+	// Block the only worker, so that the following jobs stay queued.
+	var (
+		release = make(chan struct{})
+		ran     atomic.Int32
+	)
+	p.withRetry(p.submitStage, func(context.Context) error {
+		<-release
+		ran.Add(1)
+		return nil
+	})
+	for i := 0; i < 3; i++ {
+		p.withRetry(p.submitStage, func(ctx context.Context) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			ran.Add(1)
+			return nil
+		})
+	}
+
+	// The queued jobs are still run after the prover is stopped.
+	cancel()
+	close(release)
+	p.drainStages()
+	s.Equal(int32(4), ran.Load())
+	s.ErrorIs(p.drainCtx.Err(), context.Canceled)
+}
+
+func (s *ProverTestSuite) TestSubmitProofsGeneratedOnClose() {
+	submitter := &pausedSubmitter{}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Prover{
+		cfg:               &Config{BackOffRetryInterval: time.Millisecond, BackOffMaxRetries: 1},
+		rpc:               &rpc.Client{L2: &rpc.EthClient{ChainID: common.Big1}},
+		sharedState:       state.New(),
+		pauseWatcher:      rpc.NewPauseWatcher(nil, nil),
+		proofSubmitters:   []proofSubmitter.Submitter{submitter},
+		proofGenerationCh: make(chan *producer.ProofWithHeader),
+		proofReleasedCh:   make(chan *producer.ProofWithHeader),
+		proofsResumeCh:    make(chan struct{}, 1),
+		generateDrainedCh: make(chan struct{}),
+		ctx:               ctx,
+	}
+	p.drainCtx, p.drainCancel = context.WithCancel(context.WithoutCancel(ctx))
+	p.discoverStage = p.newStage("", "discover", 1, 4)
+	p.generateStage = p.newStage("", "generate", 1, 4)
+	p.submitStage = p.newStage("", "submit", 1, 4)
+	p.contestStage = p.newStage("", "contest", 1, 4)
+	p.startStages()
+	p.submitWg.Add(1)
+	go p.submitLoop()
+
+	// The proof is only generated once the prover is stopped.
+	release := make(chan struct{})
+	p.withRetry(p.generateStage, func(ctx context.Context) error {
+		<-release
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case p.proofGenerationCh <- &producer.ProofWithHeader{
+			BlockID: common.Big1,
+			Meta:    &bindings.TaikoDataBlockMetadata{},
+			Tier:    encoding.TierOptimisticID,
+			Opts:    &producer.ProofRequestOptions{},
+		}:
+		}
+		return nil
+	})
+
+	cancel()
+	close(release)
+	p.drainStages()
+	s.Equal(int32(1), submitter.submitted.Load())
+}
+
+// pausedSubmitter is a proof submitter whose first submissions fail since the protocol is paused.
+type pausedSubmitter struct {
+	proofSubmitter.Submitter
+	paused    int32
+	submitted atomic.Int32
+}
+
+func (s *pausedSubmitter) Tier() uint16 { return encoding.TierOptimisticID }
+
+func (s *pausedSubmitter) SubmitProof(context.Context, *producer.ProofWithHeader) error {
+	if s.paused > 0 {
+		s.paused--
+		return errors.New("execution reverted: L1_INVALID_PAUSE_STATUS")
+	}
+	s.submitted.Add(1)
+	return nil
+}
+
+func (s *ProverTestSuite) TestResumeProofQueuedBeforePause() {
+	submitter := &pausedSubmitter{paused: 1}
+	p := &Prover{
+		cfg:             &Config{BackOffRetryInterval: time.Millisecond, BackOffMaxRetries: 3},
+		rpc:             &rpc.Client{L2: &rpc.EthClient{ChainID: common.Big1}},
+		sharedState:     state.New(),
+		pauseWatcher:    rpc.NewPauseWatcher(nil, nil),
+		proofSubmitters: []proofSubmitter.Submitter{submitter},
+		proofsResumeCh:  make(chan struct{}, 1),
+		ctx:             context.Background(),
+	}
+	p.drainCtx, p.drainCancel = context.WithCancel(context.Background())
+	p.submitStage = p.newStage("", "submit", 1, 4)
+	p.submitStage.Start(p.drainCtx)
+	defer p.drainCancel()
+
+	// The proof is queued while the proving is still allowed, but the protocol is paused before its
+	// submission, so it is held instead of being retried and dropped.
+	p.sharedState.IncInflightProofs()
+	p.submitProof(&producer.ProofWithHeader{
+		BlockID: common.Big1,
+		Meta:    &bindings.TaikoDataBlockMetadata{},
+		Tier:    encoding.TierOptimisticID,
+		Opts:    &producer.ProofRequestOptions{},
+	})
+	s.Eventually(func() bool {
+		p.pausedMutex.Lock()
+		defer p.pausedMutex.Unlock()
+		return len(p.pausedProofs) == 1
+	}, time.Second, time.Millisecond)
+	s.Zero(submitter.submitted.Load())
+	s.Equal(uint64(1), p.sharedState.GetInflightProofs())
+
+	// The held proof is submitted once the proving is resumed.
+	<-p.proofsResumeCh
+	p.resumePausedProofs()
+	s.Eventually(func() bool { return submitter.submitted.Load() == 1 }, time.Second, time.Millisecond)
+	s.Eventually(func() bool { return p.sharedState.GetInflightProofs() == 0 }, time.Second, time.Millisecond)
+	s.Empty(p.pausedProofs)
+}
+
 func (s *ProverTestSuite) TestSubmitProofOp() {
 	s.NotPanics(func() {
-		s.p.withRetry(func() error {
-			return s.p.submitProofOp(&producer.ProofWithHeader{
+		s.p.withRetry(s.p.submitStage, func(ctx context.Context) error {
+			return s.p.submitProofOp(ctx, &producer.ProofWithHeader{
 				BlockID: common.Big1,
 				Meta:    &bindings.TaikoDataBlockMetadata{},
 				Header:  &types.Header{},
@@ -205,8 +355,8 @@ func (s *ProverTestSuite) TestSubmitProofOp() {
 		})
 	})
 	s.NotPanics(func() {
-		s.p.withRetry(func() error {
-			return s.p.submitProofOp(&producer.ProofWithHeader{
+		s.p.withRetry(s.p.submitStage, func(ctx context.Context) error {
+			return s.p.submitProofOp(ctx, &producer.ProofWithHeader{
 				BlockID: common.Big1,
 				Meta:    &bindings.TaikoDataBlockMetadata{},
 				Header:  &types.Header{},
@@ -257,7 +407,7 @@ func (s *ProverTestSuite) TestContestWrongBlocks() {
 
 	s.Nil(s.p.proveOp())
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 	proofWithHeader := <-s.p.proofGenerationCh
 	proofWithHeader.Opts.BlockHash = testutils.RandomHash()
 	s.Nil(s.p.selectSubmitter(e.Meta.MinTier).SubmitProof(context.Background(), proofWithHeader))
@@ -289,7 +439,7 @@ func (s *ProverTestSuite) TestContestWrongBlocks() {
 	s.Greater(header.Number.Uint64(), uint64(0))
 	s.Nil(s.p.transitionProvedHandler.Handle(context.Background(), event))
 	contestReq := <-s.p.proofContestCh
-	s.Nil(s.p.contestProofOp(contestReq))
+	s.Nil(s.p.contestProofOp(context.Background(), contestReq))
 
 	contestedEvent := <-contestedSink
 	s.Equal(header.Number.Uint64(), contestedEvent.BlockId.Uint64())
@@ -308,7 +458,7 @@ func (s *ProverTestSuite) TestContestWrongBlocks() {
 		s.p.cfg.GuardianProverMinorityAddress,
 	)
 	s.p.proofSubmitters = nil
-	s.Nil(s.p.initProofSubmitters(s.p.txmgr, txBuilder))
+	s.Nil(s.p.initProofSubmitters(s.p.txmgr, txBuilder, s.p.sharedState.GetTiers()))
 
 	s.p.rpc.GuardianProverMajority, err = bindings.NewGuardianProver(s.p.cfg.GuardianProverMajorityAddress, s.p.rpc.L1)
 	s.Nil(err)
@@ -323,7 +473,7 @@ func (s *ProverTestSuite) TestContestWrongBlocks() {
 		close(approvedSink)
 	}()
 	req = <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 	s.Nil(s.p.selectSubmitter(encoding.TierGuardianMajorityID).SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 	approvedEvent := <-approvedSink
 
@@ -348,7 +498,7 @@ func (s *ProverTestSuite) TestProveExpiredUnassignedBlock() {
 	s.p.cfg.GuardianProverMajorityAddress = common.Address{}
 	s.Nil(s.p.assignmentExpiredHandler.Handle(context.Background(), e))
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 	s.Nil(s.p.selectSubmitter(e.Meta.MinTier).SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 
 	event := <-sink
@@ -391,7 +541,7 @@ func (s *ProverTestSuite) TestProveOp() {
 
 	s.Nil(s.p.proveOp())
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 	s.Nil(s.p.selectSubmitter(e.Meta.MinTier).SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 
 	event := <-sink
@@ -423,7 +573,7 @@ func (s *ProverTestSuite) TestGetBlockProofStatus() {
 
 	s.Nil(s.p.proveOp())
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 	s.Nil(s.p.selectSubmitter(e.Meta.MinTier).SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 
 	status, err = rpc.GetBlockProofStatus(context.Background(), s.p.rpc, e.BlockId, s.p.ProverAddress())
@@ -446,7 +596,7 @@ func (s *ProverTestSuite) TestGetBlockProofStatus() {
 
 	s.Nil(s.p.proveOp())
 	req = <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Event, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Event, req.Tier))
 
 	proofWithHeader := <-s.p.proofGenerationCh
 	proofWithHeader.Opts.BlockHash = testutils.RandomHash()
@@ -468,7 +618,7 @@ func (s *ProverTestSuite) TestSetApprovalAlreadySetHigher() {
 	amt := common.Big1
 	s.p.cfg.Allowance = amt
 
-	s.Nil(s.p.setApprovalAmount(context.Background(), s.p.cfg.TaikoL1Address))
+	s.Nil(s.p.setApprovalAmount(context.Background(), s.p.txmgr, s.p.cfg.TaikoL1Address))
 
 	allowance, err := s.p.rpc.TaikoToken.Allowance(&bind.CallOpts{}, s.p.ProverAddress(), s.p.cfg.TaikoL1Address)
 	s.Nil(err)
@@ -541,12 +691,16 @@ func (s *ProverTestSuite) initProver(
 		proverServerURL,
 	)
 
-	p.guardianProverHeartbeater = guardianProverHeartbeater.New(
+	heartbeater, err := guardianProverHeartbeater.New(
 		key,
 		p.cfg.GuardianProverHealthCheckServerEndpoint,
 		p.rpc,
 		p.ProverAddress(),
+		"",
+		100,
 	)
+	s.Nil(err)
+	p.guardianProverHeartbeater = heartbeater
 	s.p = p
 
 	return proverServerURL
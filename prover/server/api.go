@@ -2,8 +2,9 @@ package server
 
 import (
 	"context"
-	"math/big"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -13,12 +14,16 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
 	"github.com/taikoxyz/taiko-client/internal/utils"
-	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	ledger "github.com/taikoxyz/taiko-client/prover/assignment_ledger"
 )
 
 const (
 	rpcTimeout = 1 * time.Minute
+	// defaultPageLimit and maxPageLimit are the default and maximum numbers of the items in a page.
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
 )
 
 // @title Taiko Prover Server API
@@ -34,11 +39,13 @@ const (
 
 // CreateAssignmentRequestBody represents a request body when handling assignment creation request.
 type CreateAssignmentRequestBody struct {
-	Proposer common.Address     `json:"proposer"`
-	FeeToken common.Address     `json:"feeToken"`
-	TierFees []encoding.TierFee `json:"tierFees"`
-	Expiry   uint64             `json:"expiry"`
-	BlobHash common.Hash        `json:"blobHash"`
+	Proposer  common.Address     `json:"proposer"`
+	FeeToken  common.Address     `json:"feeToken"`
+	TierFees  []encoding.TierFee `json:"tierFees"`
+	Expiry    uint64             `json:"expiry"`
+	BlobHash  common.Hash        `json:"blobHash"`
+	Nonce     uint64             `json:"nonce"`
+	Signature []byte             `json:"signature"`
 }
 
 // Status represents the current prover server status.
@@ -68,6 +75,31 @@ func (s *ProverServer) GetStatus(c echo.Context) error {
 	})
 }
 
+// CounterQuoteResponse represents the JSON response which will be returned by the
+// CreateAssignment request handler, when the offered proof fees are too low.
+type CounterQuoteResponse struct {
+	Message  string             `json:"message"`
+	TierFees []encoding.TierFee `json:"tierFees"`
+}
+
+// GetQuote handles a query to the current proof fees offered by the prover, nothing will be signed.
+//
+//	@Summary		Get current proof fees quote
+//	@ID			   	get-quote
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object} Quote
+//	@Router			/quote [get]
+func (s *ProverServer) GetQuote(c echo.Context) error {
+	quote, err := s.quote(c.Request().Context())
+	if err != nil {
+		log.Error("Failed to get current proof fees quote", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get proof fees quote")
+	}
+
+	return c.JSON(http.StatusOK, quote)
+}
+
 // ProposeBlockResponse represents the JSON response which will be returned by
 // the ProposeBlock request handler.
 type ProposeBlockResponse struct {
@@ -86,12 +118,21 @@ type ProposeBlockResponse struct {
 //	@Accept			json
 //	@Produce		json
 //	@Success		200		{object} ProposeBlockResponse
+//	@Failure		401		{string} string	"invalid API key"
+//	@Failure		401		{string} string	"invalid proposer signature"
+//	@Failure		403		{string} string	"proposer not allowed"
+//	@Failure		429		{string} string	"proposer rate limit exceeded"
+//	@Failure		429		{string} string	"too many outstanding assignments for proposer"
 //	@Failure		422		{string} string	"empty blob hash"
 //	@Failure		422		{string} string	"only receive ETH"
 //	@Failure		422		{string} string	"insufficient prover balance"
+//	@Failure		422		{string} string	"bond exposure too high"
 //	@Failure		422		{string} string	"proof fee too low"
+//	@Failure		422		{object} CounterQuoteResponse
 //	@Failure		422		{string} string "expiry too long"
 //	@Failure		422		{string} string "prover does not have capacity"
+//	@Failure		422		{string} string "cannot finish proof in time"
+//	@Failure		503		{string} string "protocol paused"
 //	@Router			/assignment [post]
 func (s *ProverServer) CreateAssignment(c echo.Context) error {
 	req := new(CreateAssignmentRequestBody)
@@ -105,10 +146,16 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		"expiry", req.Expiry,
 		"tierFees", req.TierFees,
 		"blobHash", req.BlobHash,
+		"proposer", req.Proposer,
 		"currentUsedCapacity", len(s.proofSubmissionCh),
 	)
 
-	// 1. Check if the request body is valid.
+	// 1. Check if the protocol is paused, and if the request body is valid.
+	if s.pauseWatcher != nil && !s.pauseWatcher.CanProve() {
+		log.Warn("Protocol paused, reject the assignment", "prover", s.proverAddress, "proposer", req.Proposer)
+		metrics.ProverPausedRejectedAssignmentsCounterVec.WithLabelValues(s.chain).Inc()
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "protocol paused")
+	}
 	if req.BlobHash == (common.Hash{}) {
 		log.Warn("Empty blob hash", "prover", s.proverAddress)
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "empty blob hash")
@@ -118,70 +165,31 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "only receive ETH")
 	}
 
-	// 2. Check if the prover has the required minimum on-chain ETH and Taiko token balance.
-	ok, err := s.checkMinEthAndToken(c.Request().Context())
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	// 2. Authenticate the proposer, and reserve an outstanding assignment within its rate limit and cap,
+	// the reservation is released if no assignment is signed.
+	if err := s.proposerGuard.authenticate(c, req, s.assignmentDomain()); err != nil {
+		log.Warn("Unauthorized proposer", "proposer", req.Proposer, "reason", err.Message, "proposerIP", c.RealIP())
+		return err
 	}
-
-	if !ok {
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, "insufficient prover balance")
+	if err := s.proposerGuard.reserve(req); err != nil {
+		log.Warn("Proposer request rejected", "proposer", req.Proposer, "reason", err.Message, "proposerIP", c.RealIP())
+		return err
 	}
-
-	// 3. Check if the prover's token balance is enough to cover the bonds.
-	if ok, err = rpc.CheckProverBalance(
-		c.Request().Context(),
-		s.rpc,
-		s.proverAddress,
-		s.assignmentHookAddress,
-		s.livenessBond,
-	); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
-	}
-	if !ok {
-		log.Warn(
-			"Insufficient prover token balance, please get more tokens or wait for verification of the blocks you proved",
-			"prover", s.proverAddress,
-		)
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, "insufficient prover balance")
-	}
-
-	// 4. Check if the proof fee meets prover's minimum requirement for each tier.
-	for _, tier := range req.TierFees {
-		if tier.Tier == encoding.TierGuardianMajorityID {
-			continue
+	assigned := false
+	defer func() {
+		if !assigned {
+			s.proposerGuard.release(req)
 		}
+	}()
 
-		if tier.Tier == encoding.TierGuardianMinorityID {
-			continue
-		}
-
-		var minTierFee *big.Int
-		switch tier.Tier {
-		case encoding.TierOptimisticID:
-			minTierFee = s.minOptimisticTierFee
-		case encoding.TierSgxID:
-			minTierFee = s.minSgxTierFee
-		case encoding.TierSgxAndZkVMID:
-			minTierFee = s.minSgxAndZkVMTierFee
-		default:
-			log.Warn("Unknown tier", "tier", tier.Tier, "fee", tier.Fee, "proposerIP", c.RealIP())
-			return echo.NewHTTPError(http.StatusUnprocessableEntity, "unknown tier")
-		}
-
-		if tier.Fee.Cmp(minTierFee) < 0 {
-			log.Warn(
-				"Proof fee too low",
-				"tier", tier.Tier,
-				"fee", tier.Fee,
-				"minTierFee", minTierFee,
-				"proposerIP", c.RealIP(),
-			)
-			return echo.NewHTTPError(http.StatusUnprocessableEntity, "proof fee too low")
-		}
+	// 3. Select the prover identity to sign the assignment, which has enough balance, bond exposure
+	// headroom and capacity, and accepts the proof fees.
+	identity, err := s.selectIdentity(c, req)
+	if err != nil {
+		return err
 	}
 
-	// 5. Check if the expiry is too long.
+	// 4. Check if the expiry is too long.
 	if req.Expiry > uint64(time.Now().Add(s.maxExpiry).Unix()) {
 		log.Warn(
 			"Expiry too long",
@@ -192,12 +200,39 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "expiry too long")
 	}
 
-	// 6. Check if the prover has any capacity now.
+	// 5. Check if the prover has any capacity now.
 	if s.proofSubmissionCh != nil && len(s.proofSubmissionCh) == cap(s.proofSubmissionCh) {
 		log.Warn("Prover does not have capacity", "capacity", cap(s.proofSubmissionCh))
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "prover does not have capacity")
 	}
 
+	// 6. Check if the prover can finish the proof of each tier before its proving window closes.
+	protocolConfigs, _, tiers := s.protocolParams()
+	if s.proofScheduler != nil {
+		for _, tier := range tiers {
+			if !isPricedTier(tier.ID) || !slices.ContainsFunc(req.TierFees, func(f encoding.TierFee) bool {
+				return f.Tier == tier.ID
+			}) {
+				continue
+			}
+
+			var (
+				estimated     = s.proofScheduler.EstimateCompletion(tier.ID, uint64(protocolConfigs.BlockMaxGasLimit))
+				provingWindow = time.Duration(tier.ProvingWindow) * time.Minute
+			)
+			if estimated > provingWindow {
+				log.Warn(
+					"Cannot finish proof in time",
+					"tier", tier.ID,
+					"estimated", estimated,
+					"provingWindow", provingWindow,
+					"proposerIP", c.RealIP(),
+				)
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "cannot finish proof in time")
+			}
+		}
+	}
+
 	// 7. Encode and sign the prover assignment payload.
 	l1Head, err := s.rpc.L1.BlockNumber(c.Request().Context())
 	if err != nil {
@@ -205,11 +240,11 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
 	}
 	encoded, err := encoding.EncodeProverAssignmentPayload(
-		s.protocolConfigs.ChainId,
+		protocolConfigs.ChainId,
 		s.taikoL1Address,
 		s.assignmentHookAddress,
 		req.Proposer,
-		s.proverAddress,
+		identity.Address(),
 		req.BlobHash,
 		req.FeeToken,
 		req.Expiry,
@@ -222,27 +257,169 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
 	}
 
-	signed, err := crypto.Sign(crypto.Keccak256Hash(encoded).Bytes(), s.proverPrivateKey)
+	signed, err := crypto.Sign(crypto.Keccak256Hash(encoded).Bytes(), identity.PrivateKey)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
+	assigned = true
+	if s.assignmentLedger != nil {
+		s.assignmentLedger.Add(&ledger.Assignment{
+			RequestID:     c.Response().Header().Get(echo.HeaderXRequestID),
+			Prover:        identity.Address(),
+			Proposer:      req.Proposer,
+			BlobHash:      req.BlobHash,
+			TierFees:      req.TierFees,
+			Expiry:        req.Expiry,
+			MaxBlockID:    l1Head + s.maxSlippage,
+			MaxProposedIn: s.maxProposedIn,
+		})
+	}
+
+	metrics.ProverChainAssignmentsCounterVec.WithLabelValues(s.chain).Inc()
+
 	// 8. Return the signed payload.
 	return c.JSON(http.StatusOK, &ProposeBlockResponse{
 		SignedPayload: signed,
-		Prover:        s.proverAddress,
+		Prover:        identity.Address(),
 		MaxBlockID:    l1Head + s.maxSlippage,
 		MaxProposedIn: s.maxProposedIn,
 	})
 }
 
-// checkMinEthAndToken checks if the prover has the required minimum on-chain ETH and Taiko token balance.
-func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
+// AssignmentsResponse represents the JSON response which will be returned by the GetAssignments
+// request handler.
+type AssignmentsResponse struct {
+	Assignments []*ledger.Assignment `json:"assignments"`
+	// Cursor to query the next page with, omitted if there are no more assignments.
+	NextCursor uint64 `json:"nextCursor,omitempty"`
+}
+
+// GetAssignments handles a query to the assignments signed by the prover, and their on-chain status.
+//
+//	@Summary		Get signed assignments
+//	@ID			   	get-assignments
+//	@Param          status	query	string	false	"filter by status: pending, used, expired_unused or proven"
+//	@Param          cursor	query	integer	false	"return the assignments after the given cursor"
+//	@Param          limit	query	integer	false	"maximum number of the returned assignments, 100 by default"
+//	@Produce		json
+//	@Success		200	{object} AssignmentsResponse
+//	@Failure		400	{string} string "invalid cursor or limit"
+//	@Failure		401	{string} string "invalid API key"
+//	@Failure		404	{string} string "assignment ledger not enabled"
+//	@Router			/assignments [get]
+func (s *ProverServer) GetAssignments(c echo.Context) error {
+	if s.assignmentLedger == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "assignment ledger not enabled")
+	}
+
+	var cursor uint64
+	if param := c.QueryParam("cursor"); param != "" {
+		var err error
+		if cursor, err = strconv.ParseUint(param, 10, 64); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid cursor")
+		}
+	}
+	limit, err := parsePageLimit(c)
+	if err != nil {
+		return err
+	}
+
+	assignments, next := s.assignmentLedger.Assignments(ledger.Status(c.QueryParam("status")), cursor, limit)
+	return c.JSON(http.StatusOK, &AssignmentsResponse{Assignments: assignments, NextCursor: next})
+}
+
+// AssignmentStatsResponse represents the JSON response which will be returned by the GetAssignmentStats
+// request handler.
+type AssignmentStatsResponse struct {
+	Stats map[common.Address]ledger.ProposerStats `json:"stats"`
+	// Cursor to query the next page with, omitted if there are no more proposers.
+	NextCursor *common.Address `json:"nextCursor,omitempty"`
+}
+
+// GetAssignmentStats handles a query to the assignment statistics of each proposer.
+//
+//	@Summary		Get assignment statistics of each proposer
+//	@ID			   	get-assignment-stats
+//	@Param          cursor	query	string	false	"return the proposers after the given proposer address"
+//	@Param          limit	query	integer	false	"maximum number of the returned proposers, 100 by default"
+//	@Produce		json
+//	@Success		200	{object} AssignmentStatsResponse
+//	@Failure		400	{string} string "invalid cursor or limit"
+//	@Failure		401	{string} string "invalid API key"
+//	@Failure		404	{string} string "assignment ledger not enabled"
+//	@Router			/assignments/stats [get]
+func (s *ProverServer) GetAssignmentStats(c echo.Context) error {
+	if s.assignmentLedger == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "assignment ledger not enabled")
+	}
+
+	var cursor common.Address
+	if param := c.QueryParam("cursor"); param != "" {
+		if !common.IsHexAddress(param) {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid cursor")
+		}
+		cursor = common.HexToAddress(param)
+	}
+	limit, err := parsePageLimit(c)
+	if err != nil {
+		return err
+	}
+
+	stats, next := s.assignmentLedger.Stats(cursor, limit)
+	return c.JSON(http.StatusOK, &AssignmentStatsResponse{Stats: stats, NextCursor: next})
+}
+
+// parsePageLimit parses the `limit` query parameter of a paginated query.
+func parsePageLimit(c echo.Context) (int, error) {
+	param := c.QueryParam("limit")
+	if param == "" {
+		return defaultPageLimit, nil
+	}
+
+	limit, err := strconv.Atoi(param)
+	if err != nil || limit <= 0 || limit > maxPageLimit {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "invalid limit")
+	}
+
+	return limit, nil
+}
+
+// GetGuardianApprovals handles a query to the guardian approvals of the tracked transitions.
+//
+//	@Summary		Get guardian approvals of the tracked transitions
+//	@ID			   	get-guardian-approvals
+//	@Param          blockID	query	integer	false	"filter by block ID"
+//	@Produce		json
+//	@Success		200	{array} monitor.Approval
+//	@Failure		400	{string} string "invalid block ID"
+//	@Failure		404	{string} string "guardian monitor not enabled"
+//	@Router			/guardian/approvals [get]
+func (s *ProverServer) GetGuardianApprovals(c echo.Context) error {
+	if s.guardianMonitor == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "guardian monitor not enabled")
+	}
+
+	var blockID *uint64
+	if param := c.QueryParam("blockID"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid block ID")
+		}
+		blockID = &id
+	}
+
+	return c.JSON(http.StatusOK, s.guardianMonitor.Approvals(blockID))
+}
+
+// checkMinEthAndToken checks if the given prover identity has the required minimum on-chain ETH and
+// Taiko token balance.
+func (s *ProverServer) checkMinEthAndToken(ctx context.Context, proverAddress common.Address) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
 	defer cancel()
 
 	// 1. Check prover's ETH balance.
-	ethBalance, err := s.rpc.L1.BalanceAt(ctx, s.proverAddress, nil)
+	ethBalance, err := s.rpc.L1.BalanceAt(ctx, proverAddress, nil)
 	if err != nil {
 		return false, err
 	}
@@ -250,13 +427,13 @@ func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
 	log.Info(
 		"Prover's ETH balance",
 		"balance", utils.WeiToEther(ethBalance),
-		"address", s.proverAddress.Hex(),
+		"address", proverAddress.Hex(),
 	)
 
 	if ethBalance.Cmp(s.minEthBalance) <= 0 {
 		log.Warn(
 			"Prover does not have required minimum on-chain ETH balance",
-			"providedProver", s.proverAddress.Hex(),
+			"providedProver", proverAddress.Hex(),
 			"ethBalance", utils.WeiToEther(ethBalance),
 			"minEthBalance", utils.WeiToEther(s.minEthBalance),
 		)
@@ -264,7 +441,7 @@ func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
 	}
 
 	// 2. Check prover's Taiko token balance.
-	balance, err := s.rpc.TaikoToken.BalanceOf(&bind.CallOpts{Context: ctx}, s.proverAddress)
+	balance, err := s.rpc.TaikoToken.BalanceOf(&bind.CallOpts{Context: ctx}, proverAddress)
 	if err != nil {
 		return false, err
 	}
@@ -272,13 +449,13 @@ func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
 	log.Info(
 		"Prover's Taiko token balance",
 		"balance", utils.WeiToEther(balance),
-		"address", s.proverAddress.Hex(),
+		"address", proverAddress.Hex(),
 	)
 
 	if balance.Cmp(s.minTaikoTokenBalance) <= 0 {
 		log.Warn(
 			"Prover does not have required on-chain Taiko token balance",
-			"providedProver", s.proverAddress.Hex(),
+			"providedProver", proverAddress.Hex(),
 			"taikoTokenBalance", utils.WeiToEther(balance),
 			"minTaikoTokenBalance", utils.WeiToEther(s.minTaikoTokenBalance),
 		)
@@ -287,3 +464,29 @@ func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
 
 	return true, nil
 }
+
+// RouteChain forwards a request under `/chains/{chainID}` to the prover server of the given L2 chain,
+// e.g. `POST /chains/{chainID}/assignment` requests a proof assignment for a block of that chain.
+//
+//	@Summary		Request a proof assignment for a block of the given L2 chain
+//	@ID			   	route-chain-assignment
+//	@Accept			json
+//	@Produce		json
+//	@Param          chainID	path	string	true	"L2 chain ID"
+//	@Param          body	body	CreateAssignmentRequestBody	true	"assignment request body"
+//	@Success		200	{object} ProposeBlockResponse
+//	@Failure		404	{string} string "unknown chain"
+//	@Router			/chains/{chainID}/assignment [post]
+func (s *ProverServer) RouteChain(c echo.Context) error {
+	srv, ok := s.chains[c.Param("chainID")]
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "unknown chain")
+	}
+
+	req := c.Request().Clone(c.Request().Context())
+	req.URL.Path = "/" + c.Param("*")
+	req.URL.RawPath = ""
+	srv.echo.ServeHTTP(c.Response(), req)
+
+	return nil
+}
@@ -2,14 +2,19 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	ledger "github.com/taikoxyz/taiko-client/prover/assignment_ledger"
 )
 
 func (s *ProverServerTestSuite) TestGetStatusSuccess() {
@@ -48,3 +53,193 @@ func (s *ProverServerTestSuite) TestProposeBlockSuccess() {
 	s.Nil(err)
 	s.Contains(string(b), "signedPayload")
 }
+
+func (s *ProverServerTestSuite) TestProposeBlockMultipleIdentities() {
+	// The new identity has no balance, so the assignment should be signed by the funded identity.
+	key, err := crypto.GenerateKey()
+	s.Nil(err)
+	s.s.identities = append(s.s.identities, &Identity{
+		PrivateKey:           key,
+		MinOptimisticTierFee: common.Big1,
+		MinSgxTierFee:        common.Big1,
+		MinSgxAndZkVMTierFee: common.Big1,
+	})
+
+	data, err := json.Marshal(CreateAssignmentRequestBody{
+		FeeToken: (common.Address{}),
+		TierFees: []encoding.TierFee{
+			{Tier: encoding.TierOptimisticID, Fee: common.Big256},
+			{Tier: encoding.TierSgxID, Fee: common.Big256},
+		},
+		Expiry:   uint64(time.Now().Add(time.Minute).Unix()),
+		BlobHash: common.BigToHash(common.Big1),
+	})
+	s.Nil(err)
+	res, err := http.Post(s.testServer.URL+"/assignment", "application/json", strings.NewReader(string(data)))
+	s.Nil(err)
+	s.Equal(http.StatusOK, res.StatusCode)
+	defer res.Body.Close()
+
+	resp := new(ProposeBlockResponse)
+	b, err := io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, resp))
+	s.Equal(s.s.proverAddress, resp.Prover)
+	s.Equal(1, s.s.assignmentLedger.Pending(s.s.proverAddress))
+}
+
+func (s *ProverServerTestSuite) TestGetQuoteSuccess() {
+	res := s.sendReq("/quote")
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	quote := new(Quote)
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, &quote))
+
+	s.Equal(len(pricedTiers), len(quote.TierFees))
+	for _, tierFee := range quote.TierFees {
+		s.Equal(s.s.minTierFee(tierFee.Tier), tierFee.Fee)
+	}
+	s.NotEmpty(quote.Prover)
+}
+
+func (s *ProverServerTestSuite) TestGetQuoteWithDynamicPricing() {
+	s.s.pricing = &PricingConfig{
+		ProveBlockGas:   1_000_000,
+		ExpectedL2Gas:   1_000_000,
+		RaikoCostPerGas: map[uint16]*big.Int{encoding.TierSgxID: big.NewInt(params.GWei)},
+	}
+	defer func() { s.s.pricing = nil }()
+
+	res := s.sendReq("/quote")
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	quote := new(Quote)
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, &quote))
+
+	s.NotNil(quote.L1GasPrice)
+	for _, tierFee := range quote.TierFees {
+		s.Equal(s.s.tierFee(tierFee.Tier, quote.L1GasPrice, quote.Utilization), tierFee.Fee)
+	}
+	s.Equal(1, quote.tierFee(encoding.TierSgxID).Cmp(s.s.minSgxTierFee))
+}
+
+func (s *ProverServerTestSuite) TestProposeBlockCounterQuote() {
+	s.s.pricing = &PricingConfig{
+		ExpectedL2Gas:   1_000_000,
+		RaikoCostPerGas: map[uint16]*big.Int{encoding.TierSgxID: big.NewInt(params.GWei)},
+	}
+	defer func() { s.s.pricing = nil }()
+
+	data, err := json.Marshal(CreateAssignmentRequestBody{
+		FeeToken: (common.Address{}),
+		TierFees: []encoding.TierFee{
+			{Tier: encoding.TierOptimisticID, Fee: common.Big256},
+			{Tier: encoding.TierSgxID, Fee: common.Big256},
+		},
+		Expiry:   uint64(time.Now().Add(time.Minute).Unix()),
+		BlobHash: common.BigToHash(common.Big1),
+	})
+	s.Nil(err)
+	res, err := http.Post(s.testServer.URL+"/assignment", "application/json", strings.NewReader(string(data)))
+	s.Nil(err)
+	s.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	s.Nil(err)
+
+	counterQuote := new(CounterQuoteResponse)
+	s.Nil(json.Unmarshal(b, &counterQuote))
+	s.Equal("proof fee too low", counterQuote.Message)
+	s.Equal(len(pricedTiers), len(counterQuote.TierFees))
+	for _, tierFee := range counterQuote.TierFees {
+		if tierFee.Tier == encoding.TierSgxID {
+			s.Zero(tierFee.Fee.Cmp(new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(1_000_000))))
+		}
+	}
+}
+
+func (s *ProverServerTestSuite) TestGetAssignments() {
+	var (
+		prover   = s.s.proverAddress
+		proposer = common.BytesToAddress([]byte{1})
+	)
+	s.s.assignmentLedger.Add(&ledger.Assignment{RequestID: "1", Prover: prover, Proposer: proposer, MaxBlockID: 1})
+	s.s.assignmentLedger.Add(&ledger.Assignment{RequestID: "2", Prover: prover, Proposer: proposer, MaxBlockID: 1})
+	s.True(s.s.assignmentLedger.MarkUsed(prover, proposer, common.Hash{}, common.Big1, 1))
+
+	res := s.sendReq("/assignments?status=pending")
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	assignments := new(AssignmentsResponse)
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, assignments))
+	s.Equal(1, len(assignments.Assignments))
+	s.Equal("1", assignments.Assignments[0].RequestID)
+	s.Zero(assignments.NextCursor)
+
+	res = s.sendReq("/assignments?limit=1")
+	s.Equal(http.StatusOK, res.StatusCode)
+	defer res.Body.Close()
+	b, err = io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, assignments))
+	s.Equal(1, len(assignments.Assignments))
+	s.NotZero(assignments.NextCursor)
+
+	res = s.sendReq(fmt.Sprintf("/assignments?limit=1&cursor=%d", assignments.NextCursor))
+	s.Equal(http.StatusOK, res.StatusCode)
+	defer res.Body.Close()
+	b, err = io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, assignments))
+	s.Equal("2", assignments.Assignments[0].RequestID)
+
+	for _, path := range []string{"/assignments?limit=0", "/assignments?cursor=x", "/assignments/stats?cursor=x"} {
+		res = s.sendReq(path)
+		defer res.Body.Close()
+		s.Equal(http.StatusBadRequest, res.StatusCode)
+	}
+
+	res = s.sendReq("/assignments/stats")
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	stats := new(AssignmentStatsResponse)
+	defer res.Body.Close()
+	b, err = io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, stats))
+	s.Equal(ledger.ProposerStats{Signed: 2, Used: 1}, stats.Stats[proposer])
+	s.Nil(stats.NextCursor)
+
+	// The assignments are only exposed to the API key holders, once API keys are configured.
+	s.s.proposerGuard = newProposerGuard(&AuthConfig{APIKeys: []string{"key"}})
+	for _, path := range []string{"/assignments", "/assignments/stats"} {
+		res = s.sendReq(path)
+		defer res.Body.Close()
+		s.Equal(http.StatusUnauthorized, res.StatusCode)
+
+		req, err := http.NewRequest(http.MethodGet, s.testServer.URL+path, nil)
+		s.Nil(err)
+		req.Header.Set(APIKeyHeader, "key")
+		res, err = http.DefaultClient.Do(req)
+		s.Nil(err)
+		defer res.Body.Close()
+		s.Equal(http.StatusOK, res.StatusCode)
+	}
+}
+
+func (s *ProverServerTestSuite) TestGetGuardianApprovalsNotEnabled() {
+	res := s.sendReq("/guardian/approvals")
+	defer res.Body.Close()
+	s.Equal(http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,329 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// APIKeyHeader is the HTTP header which carries the API key of an assignment request.
+	APIKeyHeader = "X-API-Key"
+	// proposerStateIdleTimeout is the duration after which an idle proposer's rate limit state is dropped.
+	proposerStateIdleTimeout = 1 * time.Hour
+)
+
+var (
+	errInvalidAPIKey             = errors.New("invalid API key")
+	errInvalidProposerSignature  = errors.New("invalid proposer signature")
+	errProposerNotAllowed        = errors.New("proposer not allowed")
+	errRateLimitExceeded         = errors.New("proposer rate limit exceeded")
+	errTooManyOutstandingRequest = errors.New("too many outstanding assignments for proposer")
+	errReplayedRequest           = errors.New("assignment request nonce already used")
+
+	// assignmentDomainTypeHash and assignmentRequestTypeHash are the EIP-712 style type hashes of the
+	// assignment request domain and body, which are signed by the proposer.
+	assignmentDomainTypeHash = crypto.Keccak256Hash(
+		[]byte("EIP712Domain(string name,uint256 chainId,address verifyingContract)"),
+	)
+	assignmentRequestTypeHash = crypto.Keccak256Hash([]byte(
+		"AssignmentRequest(address prover,address proposer,address feeToken,bytes32 blobHash,uint64 expiry," +
+			"uint64 nonce,bytes32 tierFeesHash)",
+	))
+	assignmentDomainNameHash = crypto.Keccak256Hash([]byte("TaikoProverAssignment"))
+)
+
+// AssignmentDomain binds a signed assignment request to one prover on one chain, so that the request
+// can't be replayed to other provers, or on other chains.
+type AssignmentDomain struct {
+	ChainID uint64
+	TaikoL1 common.Address
+	Prover  common.Address
+}
+
+// separator returns the EIP-712 style domain separator.
+func (d *AssignmentDomain) separator() common.Hash {
+	return crypto.Keccak256Hash(
+		assignmentDomainTypeHash.Bytes(),
+		assignmentDomainNameHash.Bytes(),
+		common.BigToHash(new(big.Int).SetUint64(d.ChainID)).Bytes(),
+		common.BytesToHash(d.TaikoL1.Bytes()).Bytes(),
+	)
+}
+
+// AuthConfig contains the configurations for authenticating and rate limiting the proposers
+// which request proof assignments.
+type AuthConfig struct {
+	// Proposers which are allowed to request assignments, empty means any proposer is allowed.
+	AllowedProposers []common.Address
+	// Whether a proposer signature over the request is required, it is always required
+	// when the allowlist is not empty.
+	RequireSignature bool
+	// API keys which are accepted in the `X-API-Key` header, empty means no API key is needed.
+	APIKeys []string
+	// Maximum number of assignment requests per minute for each proposer, zero means no limit.
+	RequestsPerMinute uint64
+	// Maximum number of signed and not yet expired assignments for each proposer, zero means no limit.
+	MaxOutstandingAssignments uint64
+}
+
+// assignmentDomain returns the domain of the assignment requests signed for the prover server.
+func (s *ProverServer) assignmentDomain() *AssignmentDomain {
+	protocolConfigs, _, _ := s.protocolParams()
+	return &AssignmentDomain{ChainID: protocolConfigs.ChainId, TaikoL1: s.taikoL1Address, Prover: s.proverAddress}
+}
+
+// requireAPIKey is a middleware which checks the API key of the requests, if API keys are configured.
+func (s *ProverServer) requireAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := s.proposerGuard.authenticateAPIKey(c); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// proposerState contains the rate limit, outstanding assignments and used request nonces state of a proposer.
+type proposerState struct {
+	limiter  *rate.Limiter
+	expiries []uint64
+	// Expiry of each used request nonce, a nonce can be used again once its request expired.
+	nonces   map[uint64]uint64
+	lastSeen time.Time
+}
+
+// proposerGuard authenticates proposers, and enforces the per-proposer rate limits
+// and outstanding assignments caps.
+type proposerGuard struct {
+	cfg       *AuthConfig
+	states    map[common.Address]*proposerState
+	lastPrune time.Time
+	mutex     sync.Mutex
+}
+
+// newProposerGuard creates a new proposerGuard instance.
+func newProposerGuard(cfg *AuthConfig) *proposerGuard {
+	if cfg == nil {
+		cfg = &AuthConfig{}
+	}
+	return &proposerGuard{cfg: cfg, states: make(map[common.Address]*proposerState), lastPrune: time.Now()}
+}
+
+// authenticate checks the API key, the proposer signature and the allowlist of the given request.
+func (g *proposerGuard) authenticate(
+	c echo.Context,
+	req *CreateAssignmentRequestBody,
+	domain *AssignmentDomain,
+) *echo.HTTPError {
+	if err := g.authenticateAPIKey(c); err != nil {
+		return err
+	}
+
+	if g.requireSignature() {
+		signer, err := req.Signer(domain)
+		if err != nil || signer != req.Proposer {
+			log.Warn("Invalid proposer signature", "proposer", req.Proposer, "signer", signer, "error", err)
+			return echo.NewHTTPError(http.StatusUnauthorized, errInvalidProposerSignature.Error())
+		}
+	}
+
+	if len(g.cfg.AllowedProposers) != 0 && !slices.Contains(g.cfg.AllowedProposers, req.Proposer) {
+		return echo.NewHTTPError(http.StatusForbidden, errProposerNotAllowed.Error())
+	}
+
+	return nil
+}
+
+// authenticateAPIKey checks the API key of the given request, if API keys are configured.
+func (g *proposerGuard) authenticateAPIKey(c echo.Context) *echo.HTTPError {
+	if len(g.cfg.APIKeys) != 0 && !g.isValidAPIKey(c.Request().Header.Get(APIKeyHeader)) {
+		return echo.NewHTTPError(http.StatusUnauthorized, errInvalidAPIKey.Error())
+	}
+	return nil
+}
+
+// requireSignature returns whether the assignment requests must be signed by their proposers.
+func (g *proposerGuard) requireSignature() bool {
+	return g.cfg.RequireSignature || len(g.cfg.AllowedProposers) != 0
+}
+
+// isValidAPIKey checks whether the given key is one of the configured API keys.
+func (g *proposerGuard) isValidAPIKey(key string) bool {
+	valid := false
+	for _, k := range g.cfg.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// reserve checks whether the given proposer is within its rate limit and outstanding assignments cap, and
+// whether the nonce of the signed request is unused, then reserves an outstanding assignment and the nonce
+// for the request. The check and the reservation are done with the mutex held, so that concurrent requests
+// can't exceed the cap together. The reservation should be released if no assignment is signed.
+func (g *proposerGuard) reserve(req *CreateAssignmentRequestBody) *echo.HTTPError {
+	checkNonce := g.requireSignature()
+	if g.cfg.RequestsPerMinute == 0 && g.cfg.MaxOutstandingAssignments == 0 && !checkNonce {
+		return nil
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	state := g.getState(req.Proposer)
+
+	if checkNonce {
+		if _, ok := state.nonces[req.Nonce]; ok {
+			return echo.NewHTTPError(http.StatusUnauthorized, errReplayedRequest.Error())
+		}
+	}
+
+	if state.limiter != nil && !state.limiter.Allow() {
+		return echo.NewHTTPError(http.StatusTooManyRequests, errRateLimitExceeded.Error())
+	}
+
+	if g.cfg.MaxOutstandingAssignments != 0 {
+		if uint64(len(state.expiries)) >= g.cfg.MaxOutstandingAssignments {
+			return echo.NewHTTPError(http.StatusTooManyRequests, errTooManyOutstandingRequest.Error())
+		}
+		state.expiries = append(state.expiries, req.Expiry)
+	}
+
+	// A used nonce is never released, even if no assignment is signed for the request, so that
+	// a captured request can't be replayed once the prover is able to accept it.
+	if checkNonce {
+		state.nonces[req.Nonce] = req.Expiry
+	}
+
+	return nil
+}
+
+// release releases the outstanding assignment reserved for the given request, which has not been signed.
+func (g *proposerGuard) release(req *CreateAssignmentRequestBody) {
+	if g.cfg.MaxOutstandingAssignments == 0 {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	state := g.getState(req.Proposer)
+	if i := slices.Index(state.expiries, req.Expiry); i >= 0 {
+		state.expiries = slices.Delete(state.expiries, i, i+1)
+	}
+}
+
+// outstandingAssignments returns the number of signed and not yet expired assignments of the given proposer.
+func (g *proposerGuard) outstandingAssignments(proposer common.Address) int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return len(g.getState(proposer).expiries)
+}
+
+// getState returns the state of the given proposer, with the expired assignments removed,
+// the caller must hold the mutex.
+func (g *proposerGuard) getState(proposer common.Address) *proposerState {
+	now := time.Now()
+
+	// Drop the idle proposers' states from time to time, so that the map will not grow unbounded.
+	if now.Sub(g.lastPrune) > proposerStateIdleTimeout {
+		for address, state := range g.states {
+			if now.Sub(state.lastSeen) > proposerStateIdleTimeout && len(state.expiries) == 0 && len(state.nonces) == 0 {
+				delete(g.states, address)
+			}
+		}
+		g.lastPrune = now
+	}
+
+	state, ok := g.states[proposer]
+	if !ok {
+		state = &proposerState{nonces: make(map[uint64]uint64)}
+		if g.cfg.RequestsPerMinute != 0 {
+			state.limiter = rate.NewLimiter(
+				rate.Limit(float64(g.cfg.RequestsPerMinute)/time.Minute.Seconds()),
+				int(g.cfg.RequestsPerMinute),
+			)
+		}
+		g.states[proposer] = state
+	}
+	state.lastSeen = now
+
+	state.expiries = slices.DeleteFunc(state.expiries, func(expiry uint64) bool {
+		return expiry <= uint64(now.Unix())
+	})
+	for nonce, expiry := range state.nonces {
+		if expiry <= uint64(now.Unix()) {
+			delete(state.nonces, nonce)
+		}
+	}
+
+	return state
+}
+
+// Hash returns the EIP-712 style hash of the assignment request in the given domain, which is signed by
+// the proposer.
+func (r *CreateAssignmentRequestBody) Hash(domain *AssignmentDomain) common.Hash {
+	var tierFees [][]byte
+	for _, tierFee := range r.TierFees {
+		tier := make([]byte, 2)
+		binary.BigEndian.PutUint16(tier, tierFee.Tier)
+
+		fee := tierFee.Fee
+		if fee == nil {
+			fee = common.Big0
+		}
+		tierFees = append(tierFees, tier, common.BigToHash(new(big.Int).Set(fee)).Bytes())
+	}
+
+	structHash := crypto.Keccak256Hash(
+		assignmentRequestTypeHash.Bytes(),
+		common.BytesToHash(domain.Prover.Bytes()).Bytes(),
+		common.BytesToHash(r.Proposer.Bytes()).Bytes(),
+		common.BytesToHash(r.FeeToken.Bytes()).Bytes(),
+		r.BlobHash.Bytes(),
+		common.BigToHash(new(big.Int).SetUint64(r.Expiry)).Bytes(),
+		common.BigToHash(new(big.Int).SetUint64(r.Nonce)).Bytes(),
+		crypto.Keccak256(tierFees...),
+	)
+
+	return crypto.Keccak256Hash([]byte("\x19\x01"), domain.separator().Bytes(), structHash.Bytes())
+}
+
+// Sign signs the assignment request in the given domain with the given proposer private key.
+func (r *CreateAssignmentRequestBody) Sign(key *ecdsa.PrivateKey, domain *AssignmentDomain) error {
+	sig, err := crypto.Sign(r.Hash(domain).Bytes(), key)
+	if err != nil {
+		return err
+	}
+	r.Signature = sig
+
+	return nil
+}
+
+// Signer recovers the address which signed the assignment request in the given domain.
+func (r *CreateAssignmentRequestBody) Signer(domain *AssignmentDomain) (common.Address, error) {
+	if len(r.Signature) != crypto.SignatureLength {
+		return common.Address{}, errInvalidProposerSignature
+	}
+
+	pubKey, err := crypto.SigToPub(r.Hash(domain).Bytes(), r.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/labstack/echo/v4"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+func (s *ProverServerTestSuite) TestSignAssignmentRequest() {
+	key, err := crypto.GenerateKey()
+	s.Nil(err)
+
+	domain := &AssignmentDomain{ChainID: 167001, TaikoL1: common.HexToAddress("0x01"), Prover: common.HexToAddress("0x02")}
+	req := &CreateAssignmentRequestBody{
+		Proposer: crypto.PubkeyToAddress(key.PublicKey),
+		TierFees: []encoding.TierFee{{Tier: encoding.TierOptimisticID, Fee: common.Big256}},
+		Expiry:   uint64(time.Now().Add(time.Minute).Unix()),
+		BlobHash: common.BigToHash(common.Big1),
+		Nonce:    1,
+	}
+	_, err = req.Signer(domain)
+	s.ErrorIs(err, errInvalidProposerSignature)
+
+	s.Nil(req.Sign(key, domain))
+	signer, err := req.Signer(domain)
+	s.Nil(err)
+	s.Equal(req.Proposer, signer)
+
+	// The signature is only valid for the signed prover and chain.
+	for _, other := range []*AssignmentDomain{
+		{ChainID: domain.ChainID, TaikoL1: domain.TaikoL1, Prover: common.HexToAddress("0x03")},
+		{ChainID: domain.ChainID + 1, TaikoL1: domain.TaikoL1, Prover: domain.Prover},
+		{ChainID: domain.ChainID, TaikoL1: common.HexToAddress("0x03"), Prover: domain.Prover},
+	} {
+		signer, err = req.Signer(other)
+		s.Nil(err)
+		s.NotEqual(req.Proposer, signer)
+	}
+
+	// Changing any signed field should change the signer.
+	req.Nonce = 2
+	signer, err = req.Signer(domain)
+	s.Nil(err)
+	s.NotEqual(req.Proposer, signer)
+	req.Nonce = 1
+	req.TierFees[0].Fee = common.Big1
+	signer, err = req.Signer(domain)
+	s.Nil(err)
+	s.NotEqual(req.Proposer, signer)
+}
+
+func (s *ProverServerTestSuite) TestProposerGuardAuthenticate() {
+	key, err := crypto.GenerateKey()
+	s.Nil(err)
+	proposer := crypto.PubkeyToAddress(key.PublicKey)
+
+	domain := &AssignmentDomain{ChainID: 167001}
+	guard := newProposerGuard(&AuthConfig{AllowedProposers: []common.Address{proposer}, APIKeys: []string{"key"}})
+	req := &CreateAssignmentRequestBody{Proposer: proposer, BlobHash: common.BigToHash(common.Big1)}
+
+	newContext := func(apiKey string) echo.Context {
+		httpReq, err := http.NewRequest(http.MethodPost, "/assignment", nil)
+		s.Nil(err)
+		httpReq.Header.Set(APIKeyHeader, apiKey)
+		return echo.New().NewContext(httpReq, nil)
+	}
+
+	// Invalid API key.
+	s.Equal(http.StatusUnauthorized, guard.authenticate(newContext("wrong"), req, domain).Code)
+	// Missing proposer signature.
+	s.Equal(http.StatusUnauthorized, guard.authenticate(newContext("key"), req, domain).Code)
+	// Valid request.
+	s.Nil(req.Sign(key, domain))
+	s.Nil(guard.authenticate(newContext("key"), req, domain))
+	// Signed for another prover.
+	s.Equal(
+		http.StatusUnauthorized,
+		guard.authenticate(newContext("key"), req, &AssignmentDomain{ChainID: 167001, Prover: proposer}).Code,
+	)
+
+	// Proposer not in the allowlist.
+	otherKey, err := crypto.GenerateKey()
+	s.Nil(err)
+	otherReq := &CreateAssignmentRequestBody{Proposer: crypto.PubkeyToAddress(otherKey.PublicKey)}
+	s.Nil(otherReq.Sign(otherKey, domain))
+	s.Equal(http.StatusForbidden, guard.authenticate(newContext("key"), otherReq, domain).Code)
+}
+
+func (s *ProverServerTestSuite) TestProposerGuardLimits() {
+	proposer := common.BytesToAddress(crypto.Keccak256([]byte("proposer")))
+	newReq := func(proposer common.Address, nonce uint64, expiry time.Duration) *CreateAssignmentRequestBody {
+		return &CreateAssignmentRequestBody{Proposer: proposer, Nonce: nonce, Expiry: uint64(time.Now().Add(expiry).Unix())}
+	}
+
+	guard := newProposerGuard(&AuthConfig{RequestsPerMinute: 2})
+	s.Nil(guard.reserve(newReq(proposer, 0, time.Hour)))
+	s.Nil(guard.reserve(newReq(proposer, 1, time.Hour)))
+	s.Equal(http.StatusTooManyRequests, guard.reserve(newReq(proposer, 2, time.Hour)).Code)
+	// Other proposers are not affected.
+	s.Nil(guard.reserve(newReq(common.Address{}, 0, time.Hour)))
+
+	guard = newProposerGuard(&AuthConfig{MaxOutstandingAssignments: 1})
+	req := newReq(proposer, 0, time.Hour)
+	s.Nil(guard.reserve(req))
+	s.Equal(1, guard.outstandingAssignments(proposer))
+	s.Equal(http.StatusTooManyRequests, guard.reserve(newReq(proposer, 1, time.Hour)).Code)
+	// A released reservation is no longer outstanding.
+	guard.release(req)
+	s.Zero(guard.outstandingAssignments(proposer))
+
+	// Expired assignments are no longer outstanding.
+	s.Nil(guard.reserve(newReq(common.Address{}, 0, -time.Second)))
+	s.Zero(guard.outstandingAssignments(common.Address{}))
+}
+
+func (s *ProverServerTestSuite) TestProposerGuardConcurrentReservations() {
+	var (
+		proposer = common.BytesToAddress(crypto.Keccak256([]byte("proposer")))
+		guard    = newProposerGuard(&AuthConfig{MaxOutstandingAssignments: 3})
+		reserved atomic.Int64
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := &CreateAssignmentRequestBody{Proposer: proposer, Expiry: uint64(time.Now().Add(time.Hour).Unix())}
+			if guard.reserve(req) == nil {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(int64(3), reserved.Load())
+	s.Equal(3, guard.outstandingAssignments(proposer))
+}
+
+func (s *ProverServerTestSuite) TestProposerGuardReplayedRequest() {
+	proposer := common.BytesToAddress(crypto.Keccak256([]byte("proposer")))
+	guard := newProposerGuard(&AuthConfig{RequireSignature: true})
+
+	req := &CreateAssignmentRequestBody{Proposer: proposer, Nonce: 1, Expiry: uint64(time.Now().Add(time.Hour).Unix())}
+	s.Nil(guard.reserve(req))
+	// The nonce stays used even if no assignment is signed for the request.
+	guard.release(req)
+	s.Equal(http.StatusUnauthorized, guard.reserve(req).Code)
+
+	// Other nonces and other proposers are not affected.
+	s.Nil(guard.reserve(&CreateAssignmentRequestBody{Proposer: proposer, Nonce: 2, Expiry: req.Expiry}))
+	s.Nil(guard.reserve(&CreateAssignmentRequestBody{Nonce: 1, Expiry: req.Expiry}))
+
+	// The nonce of an expired request can be used again.
+	expired := &CreateAssignmentRequestBody{Proposer: proposer, Nonce: 3, Expiry: uint64(time.Now().Unix())}
+	s.Nil(guard.reserve(expired))
+	s.Nil(guard.reserve(expired))
+}
+
+func (s *ProverServerTestSuite) TestProposeBlockProposerNotAllowed() {
+	s.s.proposerGuard = newProposerGuard(&AuthConfig{AllowedProposers: []common.Address{{}}})
+	defer func() { s.s.proposerGuard = newProposerGuard(nil) }()
+
+	data, err := json.Marshal(CreateAssignmentRequestBody{
+		TierFees: []encoding.TierFee{{Tier: encoding.TierOptimisticID, Fee: common.Big256}},
+		Expiry:   uint64(time.Now().Add(time.Minute).Unix()),
+		BlobHash: common.BigToHash(common.Big1),
+	})
+	s.Nil(err)
+	res, err := http.Post(s.testServer.URL+"/assignment", "application/json", strings.NewReader(string(data)))
+	s.Nil(err)
+	defer res.Body.Close()
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+}
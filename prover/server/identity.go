@@ -0,0 +1,218 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/labstack/echo/v4"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// Identity is a prover identity which signs the proof assignments with its own key, capacity and fee settings.
+type Identity struct {
+	PrivateKey *ecdsa.PrivateKey
+	// Maximum number of the outstanding assignments and unproven blocks of the identity, zero means
+	// the identity is only limited by the prover capacity.
+	Capacity             uint64
+	MinOptimisticTierFee *big.Int
+	MinSgxTierFee        *big.Int
+	MinSgxAndZkVMTierFee *big.Int
+}
+
+// Address returns the L1 address of the identity.
+func (i *Identity) Address() common.Address {
+	return crypto.PubkeyToAddress(i.PrivateKey.PublicKey)
+}
+
+// minTierFee returns the static minimum fee the identity accepts for the given tier.
+func (i *Identity) minTierFee(tier uint16) *big.Int {
+	switch tier {
+	case encoding.TierOptimisticID:
+		return i.MinOptimisticTierFee
+	case encoding.TierSgxID:
+		return i.MinSgxTierFee
+	case encoding.TierSgxAndZkVMID:
+		return i.MinSgxAndZkVMTierFee
+	default:
+		return common.Big0
+	}
+}
+
+// identityCandidate is a prover identity which can accept the requested assignment.
+type identityCandidate struct {
+	identity *Identity
+	// Used ratio of the identity capacity.
+	utilization float64
+	// Taiko token balance of the identity.
+	balance *big.Int
+}
+
+// selectIdentity selects the identity to sign the requested assignment, among the identities passing all
+// the balance, bond exposure, proof fee and capacity checks, the one with the lowest utilization and then
+// the highest Taiko token balance is selected. If no identity passes, the rejection of the first identity
+// is returned.
+func (s *ProverServer) selectIdentity(c echo.Context, req *CreateAssignmentRequestBody) (*Identity, error) {
+	var (
+		quote     *Quote
+		selected  *identityCandidate
+		rejection error
+		err       error
+	)
+	if s.pricing != nil && hasPricedTier(req.TierFees) {
+		if quote, err = s.quote(c.Request().Context()); err != nil {
+			log.Error("Failed to get current proof fees quote", "error", err)
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "failed to get proof fees quote")
+		}
+	}
+
+	for _, identity := range s.identities {
+		candidate, err := s.checkIdentity(c, req, identity, quote)
+		if err != nil {
+			var httpErr *echo.HTTPError
+			if !errors.As(err, &httpErr) || httpErr.Code != http.StatusUnprocessableEntity {
+				return nil, err
+			}
+			if rejection == nil {
+				rejection = err
+			}
+			continue
+		}
+
+		if selected == nil ||
+			candidate.utilization < selected.utilization ||
+			(candidate.utilization == selected.utilization && candidate.balance.Cmp(selected.balance) > 0) {
+			selected = candidate
+		}
+	}
+
+	if selected == nil {
+		return nil, rejection
+	}
+
+	if len(s.identities) > 1 {
+		log.Info(
+			"Prover identity selected",
+			"prover", selected.identity.Address(),
+			"utilization", selected.utilization,
+			"balance", selected.balance,
+		)
+	}
+
+	return selected.identity, nil
+}
+
+// checkIdentity checks whether the given identity can accept the requested assignment.
+func (s *ProverServer) checkIdentity(
+	c echo.Context,
+	req *CreateAssignmentRequestBody,
+	identity *Identity,
+	quote *Quote,
+) (*identityCandidate, error) {
+	var (
+		ctx     = c.Request().Context()
+		address = identity.Address()
+	)
+
+	// 1. Check if the identity has the required minimum on-chain ETH and Taiko token balance.
+	ok, err := s.checkMinEthAndToken(ctx, address)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "insufficient prover balance")
+	}
+
+	// 2. Check if the identity's token balance is enough to cover the bonds.
+	_, livenessBond, _ := s.protocolParams()
+	if ok, err = rpc.CheckProverBalance(ctx, s.rpc, address, s.assignmentHookAddress, livenessBond); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	if !ok {
+		log.Warn(
+			"Insufficient prover token balance, please get more tokens or wait for verification of the blocks you proved",
+			"prover", address,
+		)
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "insufficient prover balance")
+	}
+
+	// 3. Check if the liveness bond exposure would exceed the limit after accepting this assignment.
+	balance, err := s.rpc.TaikoToken.BalanceOf(&bind.CallOpts{Context: ctx}, address)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	if s.bondExposure != nil && !s.bondExposure.CanAccept(address, balance) {
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "bond exposure too high")
+	}
+
+	// 4. Check if the proof fee meets the identity's minimum requirement for each tier.
+	for _, tier := range req.TierFees {
+		if tier.Tier == encoding.TierGuardianMajorityID {
+			continue
+		}
+
+		if tier.Tier == encoding.TierGuardianMinorityID {
+			continue
+		}
+
+		if !isPricedTier(tier.Tier) {
+			log.Warn("Unknown tier", "tier", tier.Tier, "fee", tier.Fee, "proposerIP", c.RealIP())
+			return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "unknown tier")
+		}
+
+		minTierFee := identity.minTierFee(tier.Tier)
+		if quote != nil && quote.tierFee(tier.Tier).Cmp(minTierFee) > 0 {
+			minTierFee = quote.tierFee(tier.Tier)
+		}
+
+		if tier.Fee.Cmp(minTierFee) < 0 {
+			log.Warn(
+				"Proof fee too low",
+				"prover", address,
+				"tier", tier.Tier,
+				"fee", tier.Fee,
+				"minTierFee", minTierFee,
+				"proposerIP", c.RealIP(),
+			)
+			// Counter-quote with the current proof fees if dynamic pricing is enabled.
+			if quote != nil {
+				return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, &CounterQuoteResponse{
+					Message:  "proof fee too low",
+					TierFees: quote.TierFees,
+				})
+			}
+			return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "proof fee too low")
+		}
+	}
+
+	// 5. Check if the identity has any capacity now.
+	var load uint64
+	if s.assignmentLedger != nil {
+		load += uint64(s.assignmentLedger.Pending(address))
+	}
+	if s.bondExposure != nil {
+		load += uint64(s.bondExposure.LockedBlocks(address))
+	}
+	if identity.Capacity != 0 && load >= identity.Capacity {
+		log.Warn("Prover identity does not have capacity", "prover", address, "capacity", identity.Capacity)
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "prover does not have capacity")
+	}
+
+	capacity := identity.Capacity
+	if capacity == 0 {
+		capacity = s.capacity
+	}
+	candidate := &identityCandidate{identity: identity, balance: balance}
+	if capacity != 0 {
+		candidate.utilization = float64(load) / float64(capacity)
+	}
+
+	return candidate, nil
+}
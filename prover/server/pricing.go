@@ -0,0 +1,229 @@
+package server
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+var (
+	secondsPerYear = new(big.Float).SetUint64(365 * 24 * 60 * 60)
+	// pricedTiers are the tiers whose fees are priced by the prover server, guardian tiers are always free.
+	pricedTiers = []uint16{encoding.TierOptimisticID, encoding.TierSgxID, encoding.TierSgxAndZkVMID}
+	// gasPriceTTL is the duration to reuse the suggested L1 gas price, so that the quote requests don't
+	// hit the L1 node each time.
+	gasPriceTTL = 12 * time.Second
+)
+
+// PricingConfig contains the parameters of the dynamic proof fee formula, for each tier,
+// the price of proving a block is:
+//
+//	(l1GasPrice * proveBlockGas + raikoCostPerGas[tier] * expectedL2Gas + bondOpportunityCost) *
+//	(1 + utilizationPremium * utilization) * (1 + profitMargin)
+//
+// where bondOpportunityCost = livenessBond * bondTokenPrice * bondCostRate * provingWindow / 1 year,
+// and the price will never be lower than the static minimum tier fee.
+type PricingConfig struct {
+	// Expected gas used by a TaikoL1.proveBlock transaction.
+	ProveBlockGas uint64
+	// Expected gas used by a L2 block, if zero, the protocol block max gas limit will be used.
+	ExpectedL2Gas uint64
+	// Raiko proof generation cost in wei per unit of L2 gas, for each tier.
+	RaikoCostPerGas map[uint16]*big.Int
+	// Fee multiplier added when the prover is fully utilized, 1.0 doubles the price at full capacity.
+	UtilizationPremium float64
+	// Annual opportunity cost rate of the locked liveness bond.
+	BondCostRate float64
+	// Price of one liveness bond token (with decimals) in wei.
+	BondTokenPrice *big.Int
+	// Profit margin added on top of the total cost.
+	ProfitMargin float64
+}
+
+// Quote represents the current proof fees offered by the prover.
+type Quote struct {
+	TierFees    []encoding.TierFee `json:"tierFees"`
+	L1GasPrice  *big.Int           `json:"l1GasPrice"`
+	Utilization float64            `json:"utilization"`
+	Prover      common.Address     `json:"prover"`
+}
+
+// quote calculates the current proof fees for all priced tiers.
+func (s *ProverServer) quote(ctx context.Context) (*Quote, error) {
+	var (
+		utilization = s.utilization()
+		gasPrice    = common.Big0
+		err         error
+	)
+	if s.pricing != nil {
+		if gasPrice, err = s.l1GasPrice(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	q := &Quote{L1GasPrice: gasPrice, Utilization: utilization, Prover: s.proverAddress}
+	for _, tier := range pricedTiers {
+		q.TierFees = append(q.TierFees, encoding.TierFee{
+			Tier: tier,
+			Fee:  s.tierFee(tier, gasPrice, utilization),
+		})
+	}
+
+	return q, nil
+}
+
+// l1GasPrice returns the suggested L1 gas price, which is cached for a short while.
+func (s *ProverServer) l1GasPrice(ctx context.Context) (*big.Int, error) {
+	s.gasPriceMutex.RLock()
+	gasPrice, updatedAt := s.gasPrice, s.gasPriceUpdatedAt
+	s.gasPriceMutex.RUnlock()
+
+	if gasPrice != nil && time.Since(updatedAt) < gasPriceTTL {
+		return gasPrice, nil
+	}
+
+	gasPrice, err := s.rpc.L1.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.gasPriceMutex.Lock()
+	s.gasPrice, s.gasPriceUpdatedAt = gasPrice, time.Now()
+	s.gasPriceMutex.Unlock()
+
+	return gasPrice, nil
+}
+
+// tierFee returns the quoted fee of the given tier.
+func (q *Quote) tierFee(tier uint16) *big.Int {
+	for _, tierFee := range q.TierFees {
+		if tierFee.Tier == tier {
+			return tierFee.Fee
+		}
+	}
+
+	return common.Big0
+}
+
+// tierFee returns the minimum fee this prover accepts for the given tier.
+func (s *ProverServer) tierFee(tier uint16, gasPrice *big.Int, utilization float64) *big.Int {
+	minTierFee := s.minTierFee(tier)
+	if s.pricing == nil {
+		return minTierFee
+	}
+
+	protocolConfigs, livenessBond, tiers := s.protocolParams()
+
+	var provingWindow uint16
+	for _, t := range tiers {
+		if t.ID == tier {
+			provingWindow = t.ProvingWindow
+		}
+	}
+
+	expectedL2Gas := s.pricing.ExpectedL2Gas
+	if expectedL2Gas == 0 && protocolConfigs != nil {
+		expectedL2Gas = uint64(protocolConfigs.BlockMaxGasLimit)
+	}
+
+	fee := calculateTierFee(s.pricing, tier, gasPrice, expectedL2Gas, utilization, livenessBond, provingWindow)
+	if fee.Cmp(minTierFee) < 0 {
+		return minTierFee
+	}
+
+	return fee
+}
+
+// minTierFee returns the static minimum fee of the given tier.
+func (s *ProverServer) minTierFee(tier uint16) *big.Int {
+	switch tier {
+	case encoding.TierOptimisticID:
+		return s.minOptimisticTierFee
+	case encoding.TierSgxID:
+		return s.minSgxTierFee
+	case encoding.TierSgxAndZkVMID:
+		return s.minSgxAndZkVMTierFee
+	default:
+		return common.Big0
+	}
+}
+
+// utilization returns the current used capacity ratio of the prover, which is measured by the number
+// of in-flight proof requests, i.e. the requests which have been accepted but not submitted yet.
+func (s *ProverServer) utilization() float64 {
+	if s.inflightProofs == nil || s.capacity == 0 {
+		return 0
+	}
+
+	return math.Min(float64(s.inflightProofs())/float64(s.capacity), 1)
+}
+
+// isPricedTier checks whether the given tier is priced by the prover server.
+func isPricedTier(tier uint16) bool {
+	return slices.Contains(pricedTiers, tier)
+}
+
+// hasPricedTier checks whether any of the given tier fees needs to be priced by the prover server.
+func hasPricedTier(tierFees []encoding.TierFee) bool {
+	for _, tierFee := range tierFees {
+		if isPricedTier(tierFee.Tier) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// calculateTierFee calculates the fee of the given tier with the dynamic pricing formula.
+func calculateTierFee(
+	cfg *PricingConfig,
+	tier uint16,
+	gasPrice *big.Int,
+	expectedL2Gas uint64,
+	utilization float64,
+	livenessBond *big.Int,
+	provingWindowMinutes uint16,
+) *big.Int {
+	// L1 proof submission cost.
+	cost := new(big.Float).SetInt(new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(cfg.ProveBlockGas)))
+
+	// Proof generation cost.
+	if costPerGas, ok := cfg.RaikoCostPerGas[tier]; ok && costPerGas != nil {
+		cost.Add(cost, new(big.Float).SetInt(new(big.Int).Mul(costPerGas, new(big.Int).SetUint64(expectedL2Gas))))
+	}
+
+	// Liveness bond opportunity cost.
+	if livenessBond != nil && cfg.BondTokenPrice != nil && cfg.BondCostRate > 0 {
+		bondValue := new(big.Float).Quo(
+			new(big.Float).SetInt(new(big.Int).Mul(livenessBond, cfg.BondTokenPrice)),
+			new(big.Float).SetInt(big.NewInt(params.Ether)),
+		)
+		bondCost := new(big.Float).Mul(bondValue, big.NewFloat(cfg.BondCostRate))
+		bondCost.Mul(bondCost, new(big.Float).SetUint64(uint64(provingWindowMinutes)*60))
+		cost.Add(cost, bondCost.Quo(bondCost, secondsPerYear))
+	}
+
+	cost.Mul(cost, big.NewFloat(1+cfg.UtilizationPremium*utilization))
+	cost.Mul(cost, big.NewFloat(1+cfg.ProfitMargin))
+
+	fee, _ := cost.Int(nil)
+
+	log.Debug(
+		"Dynamic tier fee calculated",
+		"tier", tier,
+		"fee", utils.WeiToGWei(fee),
+		"gasPrice", utils.WeiToGWei(gasPrice),
+		"utilization", utilization,
+	)
+
+	return fee
+}
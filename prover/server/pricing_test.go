@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+func (s *ProverServerTestSuite) TestCalculateTierFee() {
+	cfg := &PricingConfig{
+		ProveBlockGas: 100,
+		RaikoCostPerGas: map[uint16]*big.Int{
+			encoding.TierSgxID: common.Big2,
+		},
+	}
+
+	// Only the L1 proof submission cost.
+	s.Equal(big.NewInt(1000), calculateTierFee(cfg, encoding.TierOptimisticID, big.NewInt(10), 50, 0, nil, 0))
+	// L1 proof submission cost + proof generation cost.
+	s.Equal(big.NewInt(1100), calculateTierFee(cfg, encoding.TierSgxID, big.NewInt(10), 50, 0, nil, 0))
+
+	// Utilization premium and profit margin.
+	cfg.UtilizationPremium = 1
+	cfg.ProfitMargin = 0.5
+	s.Equal(big.NewInt(2250), calculateTierFee(cfg, encoding.TierOptimisticID, big.NewInt(10), 50, 0.5, nil, 0))
+
+	// Liveness bond opportunity cost, a bond worth 8760 Ether locked for one hour with a 100% annual rate.
+	cfg = &PricingConfig{BondCostRate: 1, BondTokenPrice: big.NewInt(params.Ether)}
+	fee := calculateTierFee(
+		cfg, encoding.TierSgxID, common.Big0, 0, 0, new(big.Int).Mul(big.NewInt(365*24), big.NewInt(params.Ether)), 60,
+	)
+	s.InDelta(float64(params.Ether), float64(fee.Uint64()), 1e6)
+}
+
+func (s *ProverServerTestSuite) TestTierFeeFloor() {
+	s.s.pricing = &PricingConfig{}
+	defer func() { s.s.pricing = nil }()
+
+	s.Equal(s.s.minSgxTierFee, s.s.tierFee(encoding.TierSgxID, common.Big0, 0))
+	s.Equal(common.Big0, s.s.tierFee(encoding.TierGuardianMajorityID, common.Big0, 0))
+}
+
+func (s *ProverServerTestSuite) TestUtilization() {
+	defer func() { s.s.capacity, s.s.inflightProofs = 0, nil }()
+	s.Zero(s.s.utilization())
+
+	inflight := uint64(5)
+	s.s.capacity = 10
+	s.s.inflightProofs = func() uint64 { return inflight }
+	s.Equal(0.5, s.s.utilization())
+
+	inflight = 20
+	s.Equal(1.0, s.s.utilization())
+}
+
+func (s *ProverServerTestSuite) TestTierFeeWithUtilization() {
+	s.s.pricing = &PricingConfig{ProveBlockGas: 1_000_000, UtilizationPremium: 1}
+	s.s.capacity = 10
+	defer func() { s.s.pricing, s.s.capacity, s.s.inflightProofs = nil, 0, nil }()
+
+	gasPrice := big.NewInt(params.GWei)
+
+	s.s.inflightProofs = func() uint64 { return 0 }
+	idleFee := s.s.tierFee(encoding.TierSgxID, gasPrice, s.s.utilization())
+
+	s.s.inflightProofs = func() uint64 { return 10 }
+	busyFee := s.s.tierFee(encoding.TierSgxID, gasPrice, s.s.utilization())
+
+	s.Equal(new(big.Int).Mul(idleFee, common.Big2), busyFee)
+}
+
+func (s *ProverServerTestSuite) TestL1GasPriceCached() {
+	srv := &ProverServer{
+		minOptimisticTierFee: common.Big0,
+		minSgxTierFee:        common.Big0,
+		minSgxAndZkVMTierFee: common.Big0,
+		pricing:              &PricingConfig{},
+		gasPrice:             big.NewInt(10),
+		gasPriceUpdatedAt:    time.Now(),
+	}
+
+	// The cached gas price is used without querying the L1 node.
+	q, err := srv.quote(context.Background())
+	s.Nil(err)
+	s.Equal(big.NewInt(10), q.L1GasPrice)
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -15,7 +16,11 @@ import (
 
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	ledger "github.com/taikoxyz/taiko-client/prover/assignment_ledger"
+	exposure "github.com/taikoxyz/taiko-client/prover/bond_exposure"
+	monitor "github.com/taikoxyz/taiko-client/prover/guardian_monitor"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+	scheduler "github.com/taikoxyz/taiko-client/prover/proof_scheduler"
 )
 
 // @title Taiko Prover Server API
@@ -48,6 +53,25 @@ type ProverServer struct {
 	rpc                   *rpc.Client
 	protocolConfigs       *bindings.TaikoDataConfig
 	livenessBond          *big.Int
+	tiers                 []*rpc.TierProviderTierWithID
+	protocolMutex         sync.RWMutex
+	pricing               *PricingConfig
+	gasPrice              *big.Int
+	gasPriceUpdatedAt     time.Time
+	gasPriceMutex         sync.RWMutex
+	capacity              uint64
+	inflightProofs        func() uint64
+	proposerGuard         *proposerGuard
+	assignmentLedger      *ledger.Ledger
+	bondExposure          *exposure.Tracker
+	proofScheduler        *scheduler.Scheduler
+	guardianMonitor       *monitor.Monitor
+	pauseWatcher          *rpc.PauseWatcher
+	identities            []*Identity
+	// ID of the L2 chain proven by the prover server, used in metrics.
+	chain string
+	// Prover servers of the L2 chains routed by this prover server, keyed by their chain IDs.
+	chains map[string]*ProverServer
 }
 
 // NewProverServerOpts contains all configurations for creating a prover server instance.
@@ -67,6 +91,18 @@ type NewProverServerOpts struct {
 	RPC                   *rpc.Client
 	ProtocolConfigs       *bindings.TaikoDataConfig
 	LivenessBond          *big.Int
+	Tiers                 []*rpc.TierProviderTierWithID
+	Pricing               *PricingConfig
+	Capacity              uint64
+	InflightProofs        func() uint64
+	Auth                  *AuthConfig
+	AssignmentLedger      *ledger.Ledger
+	BondExposure          *exposure.Tracker
+	ProofScheduler        *scheduler.Scheduler
+	GuardianMonitor       *monitor.Monitor
+	PauseWatcher          *rpc.PauseWatcher
+	// Additional prover identities, which can sign the assignments besides the prover private key.
+	Identities []*Identity
 }
 
 // New creates a new prover server instance.
@@ -89,7 +125,28 @@ func New(opts *NewProverServerOpts) (*ProverServer, error) {
 		rpc:                   opts.RPC,
 		protocolConfigs:       opts.ProtocolConfigs,
 		livenessBond:          opts.LivenessBond,
+		tiers:                 opts.Tiers,
+		pricing:               opts.Pricing,
+		capacity:              opts.Capacity,
+		inflightProofs:        opts.InflightProofs,
+		proposerGuard:         newProposerGuard(opts.Auth),
+		assignmentLedger:      opts.AssignmentLedger,
+		bondExposure:          opts.BondExposure,
+		proofScheduler:        opts.ProofScheduler,
+		guardianMonitor:       opts.GuardianMonitor,
+		pauseWatcher:          opts.PauseWatcher,
+		chains:                make(map[string]*ProverServer),
 	}
+	if opts.RPC != nil && opts.RPC.L2 != nil && opts.RPC.L2.ChainID != nil {
+		srv.chain = opts.RPC.L2.ChainID.String()
+	}
+
+	srv.identities = append([]*Identity{{
+		PrivateKey:           opts.ProverPrivateKey,
+		MinOptimisticTierFee: opts.MinOptimisticTierFee,
+		MinSgxTierFee:        opts.MinSgxTierFee,
+		MinSgxAndZkVMTierFee: opts.MinSgxAndZkVMTierFee,
+	}}, opts.Identities...)
 
 	srv.echo.HideBanner = true
 	srv.configureMiddleware()
@@ -103,6 +160,33 @@ func (s *ProverServer) Start(address string) error {
 	return s.echo.Start(address)
 }
 
+// MountChain routes the requests under `/chains/{chainID}` to the prover server of the given L2 chain, so that
+// the proposers of several L2 chains can use one prover server endpoint.
+func (s *ProverServer) MountChain(chainID *big.Int, srv *ProverServer) {
+	s.chains[chainID.String()] = srv
+}
+
+// SetProtocolParams swaps in the given protocol configurations, liveness bond and proof tiers, once they are
+// changed by a protocol upgrade.
+func (s *ProverServer) SetProtocolParams(
+	protocolConfigs *bindings.TaikoDataConfig,
+	livenessBond *big.Int,
+	tiers []*rpc.TierProviderTierWithID,
+) {
+	s.protocolMutex.Lock()
+	defer s.protocolMutex.Unlock()
+
+	s.protocolConfigs, s.livenessBond, s.tiers = protocolConfigs, livenessBond, tiers
+}
+
+// protocolParams returns the current protocol configurations, liveness bond and proof tiers.
+func (s *ProverServer) protocolParams() (*bindings.TaikoDataConfig, *big.Int, []*rpc.TierProviderTierWithID) {
+	s.protocolMutex.RLock()
+	defer s.protocolMutex.RUnlock()
+
+	return s.protocolConfigs, s.livenessBond, s.tiers
+}
+
 // Shutdown shuts down the HTTP server.
 func (s *ProverServer) Shutdown(ctx context.Context) error {
 	return s.echo.Shutdown(ctx)
@@ -142,5 +226,10 @@ func (s *ProverServer) configureRoutes() {
 	s.echo.GET("/", s.Health)
 	s.echo.GET("/healthz", s.Health)
 	s.echo.GET("/status", s.GetStatus)
+	s.echo.GET("/quote", s.GetQuote)
 	s.echo.POST("/assignment", s.CreateAssignment)
+	s.echo.GET("/assignments", s.GetAssignments, s.requireAPIKey)
+	s.echo.GET("/assignments/stats", s.GetAssignmentStats, s.requireAPIKey)
+	s.echo.GET("/guardian/approvals", s.GetGuardianApprovals)
+	s.echo.Any("/chains/:chainID/*", s.RouteChain)
 }
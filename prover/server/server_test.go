@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	ledger "github.com/taikoxyz/taiko-client/prover/assignment_ledger"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
 
@@ -47,6 +48,9 @@ func (s *ProverServerTestSuite) SetupTest() {
 	configs, err := rpcClient.TaikoL1.GetConfig(nil)
 	s.Nil(err)
 
+	assignmentLedger, err := ledger.New(ledger.DefaultRetention, "", "")
+	s.Nil(err)
+
 	p, err := New(&NewProverServerOpts{
 		ProverPrivateKey:      l1ProverPrivKey,
 		MinOptimisticTierFee:  common.Big1,
@@ -61,6 +65,7 @@ func (s *ProverServerTestSuite) SetupTest() {
 		RPC:                   rpcClient,
 		ProtocolConfigs:       &configs,
 		LivenessBond:          common.Big0,
+		AssignmentLedger:      assignmentLedger,
 	})
 	s.Nil(err)
 
@@ -77,6 +82,18 @@ func (s *ProverServerTestSuite) TestHealth() {
 	s.Equal(http.StatusOK, resp.StatusCode)
 }
 
+func (s *ProverServerTestSuite) TestChainRoutes() {
+	s.s.MountChain(common.Big1, s.s)
+
+	resp := s.sendReq("/chains/1/healthz")
+	defer resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	resp = s.sendReq("/chains/2/healthz")
+	defer resp.Body.Close()
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
 func (s *ProverServerTestSuite) TestRoot() {
 	resp := s.sendReq("/")
 	defer resp.Body.Close()
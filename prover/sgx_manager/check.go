@@ -0,0 +1,98 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+)
+
+// CheckInstanceResult is the result of checking an SGX instance.
+type CheckInstanceResult struct {
+	Address    common.Address `json:"address"`
+	Registered bool           `json:"registered"`
+	Instance   *InstanceInfo  `json:"instance,omitempty"`
+	ValidUntil time.Time      `json:"validUntil"`
+}
+
+// InstanceChecker checks whether an SGX instance is registered in the SgxVerifier contract, and will stay
+// valid for the given duration.
+type InstanceChecker struct {
+	manager
+	instance common.Address
+	validFor time.Duration
+}
+
+// InitFromCli initializes the given instance checker based on the command line flags.
+func (ch *InstanceChecker) InitFromCli(ctx context.Context, c *cli.Context) (err error) {
+	switch {
+	case c.IsSet(flags.SgxInstance.Name):
+		ch.instance = common.HexToAddress(c.String(flags.SgxInstance.Name))
+	case c.IsSet(flags.SgxAttestation.Name):
+		if _, ch.instance, err = readAttestation(c.String(flags.SgxAttestation.Name)); err != nil {
+			return err
+		}
+	default:
+		return errors.New("empty SGX instance and attestation")
+	}
+	ch.validFor = c.Duration(flags.SgxValidFor.Name)
+
+	return ch.initFromCli(ctx, c)
+}
+
+// Name returns the application name.
+func (ch *InstanceChecker) Name() string {
+	return "sgx check"
+}
+
+// Run checks the SGX instance, an error is returned if it is not registered, or will not stay valid for the
+// given duration.
+func (ch *InstanceChecker) Run(ctx context.Context) (interface{}, error) {
+	instances, err := ch.instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CheckInstanceResult{Address: ch.instance, ValidUntil: time.Now().Add(ch.validFor).UTC()}
+	if result.Instance = findInstance(instances, ch.instance); result.Instance == nil {
+		return result, fmt.Errorf("SGX instance %s is not registered", ch.instance)
+	}
+	result.Registered = true
+
+	return result, checkValidity(result.Instance, result.ValidUntil)
+}
+
+// findInstance returns the latest registered instance with the given address.
+func findInstance(instances []*InstanceInfo, address common.Address) *InstanceInfo {
+	var found *InstanceInfo
+	for _, instance := range instances {
+		if instance.Address == address {
+			found = instance
+		}
+	}
+
+	return found
+}
+
+// checkValidity checks whether the given instance is valid now, and stays valid until the given time.
+func checkValidity(instance *InstanceInfo, validUntil time.Time) error {
+	if !instance.Valid {
+		return fmt.Errorf(
+			"SGX instance %d (%s) is not valid, valid since %s, expires at %s",
+			instance.ID, instance.Address, instance.ValidSince, instance.ExpiresAt,
+		)
+	}
+	if instance.ExpiresAt.Before(validUntil) {
+		return fmt.Errorf(
+			"SGX instance %d (%s) expires at %s, before %s",
+			instance.ID, instance.Address, instance.ExpiresAt, validUntil,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	verifier "github.com/taikoxyz/taiko-client/prover/sgx_verifier"
+)
+
+// sgxVerifierName is the name of the SgxVerifier contract in the AddressManager contract.
+var sgxVerifierName = rpc.StringToBytes32("tier_sgx")
+
+// InstanceInfo is an SGX instance registered in the SgxVerifier contract.
+type InstanceInfo struct {
+	ID         uint64         `json:"id"`
+	Address    common.Address `json:"address"`
+	ValidSince time.Time      `json:"validSince"`
+	ExpiresAt  time.Time      `json:"expiresAt"`
+	Valid      bool           `json:"valid"`
+}
+
+// manager contains the clients shared by all the sgx commands.
+type manager struct {
+	l1       *rpc.EthClient
+	address  common.Address
+	contract *bindings.SgxVerifier
+	expiry   uint64
+}
+
+// initFromCli initializes the L1 client and the SgxVerifier contract binding based on the command line flags.
+func (m *manager) initFromCli(ctx context.Context, c *cli.Context) (err error) {
+	if m.l1, err = rpc.NewEthClient(
+		ctx,
+		c.String(flags.L1WSEndpoint.Name),
+		c.Duration(flags.RPCTimeout.Name),
+	); err != nil {
+		return err
+	}
+
+	if c.IsSet(flags.SgxVerifierAddress.Name) {
+		m.address = common.HexToAddress(c.String(flags.SgxVerifierAddress.Name))
+	} else {
+		taikoL1, err := bindings.NewTaikoL1Client(common.HexToAddress(c.String(flags.TaikoL1Address.Name)), m.l1)
+		if err != nil {
+			return err
+		}
+		if m.address, err = taikoL1.Resolve0(&bind.CallOpts{Context: ctx}, sgxVerifierName, false); err != nil {
+			return fmt.Errorf("failed to resolve SgxVerifier address: %w", err)
+		}
+	}
+
+	if m.contract, err = bindings.NewSgxVerifier(m.address, m.l1); err != nil {
+		return err
+	}
+	if m.expiry, err = m.contract.INSTANCEEXPIRY(&bind.CallOpts{Context: ctx}); err != nil {
+		return fmt.Errorf("failed to get SGX instance expiry: %w", err)
+	}
+
+	return nil
+}
+
+// instances returns all the SGX instances registered in the SgxVerifier contract, the validity of each instance
+// is checked at the current L1 timestamp.
+func (m *manager) instances(ctx context.Context) ([]*InstanceInfo, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	nextID, err := m.contract.NextInstanceId(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next SGX instance ID: %w", err)
+	}
+
+	header, err := m.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances []*InstanceInfo
+	for id := uint64(0); id < nextID.Uint64(); id++ {
+		instance, err := m.contract.Instances(opts, new(big.Int).SetUint64(id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SGX instance %d: %w", id, err)
+		}
+		// Deleted instances are reset to the zero address.
+		if instance.Addr == (common.Address{}) {
+			continue
+		}
+
+		instances = append(instances, &InstanceInfo{
+			ID:         id,
+			Address:    instance.Addr,
+			ValidSince: time.Unix(int64(instance.ValidSince), 0).UTC(),
+			ExpiresAt:  time.Unix(int64(instance.ValidSince+m.expiry), 0).UTC(),
+			Valid:      header.Time >= instance.ValidSince && header.Time <= instance.ValidSince+m.expiry,
+		})
+	}
+
+	return instances, nil
+}
+
+// attestation is the output of the raiko SGX bootstrap.
+type attestation struct {
+	Quote       string          `json:"quote"`
+	NewInstance *common.Address `json:"new_instance"`
+}
+
+// readAttestation reads the SGX quote from the given raiko bootstrap output, or hex encoded quote file, and
+// returns the parsed quote, and the instance address attested by it.
+func readAttestation(path string) (*bindings.V3StructParsedV3QuoteStruct, common.Address, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to read SGX attestation: %w", err)
+	}
+
+	var a attestation
+	if err := json.Unmarshal(content, &a); err != nil {
+		a = attestation{Quote: strings.TrimSpace(string(content))}
+	}
+
+	quote, err := verifier.ParseQuote(common.FromHex(a.Quote))
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	instance := verifier.InstanceAddress(quote)
+	if a.NewInstance != nil && *a.NewInstance != instance {
+		return nil, common.Address{}, fmt.Errorf(
+			"%w: quote attests instance %s, but the new instance is %s",
+			verifier.ErrInvalidQuote, instance, a.NewInstance,
+		)
+	}
+
+	return quote, instance, nil
+}
+
+// InstanceLister lists the SGX instances registered in the SgxVerifier contract.
+type InstanceLister struct {
+	manager
+}
+
+// InitFromCli initializes the given instance lister based on the command line flags.
+func (l *InstanceLister) InitFromCli(ctx context.Context, c *cli.Context) error {
+	return l.initFromCli(ctx, c)
+}
+
+// Name returns the application name.
+func (l *InstanceLister) Name() string {
+	return "sgx list"
+}
+
+// Run returns all the registered SGX instances.
+func (l *InstanceLister) Run(ctx context.Context) (interface{}, error) {
+	return l.instances(ctx)
+}
@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+
+	verifier "github.com/taikoxyz/taiko-client/prover/sgx_verifier"
+)
+
+type SgxManagerTestSuite struct {
+	suite.Suite
+}
+
+func (s *SgxManagerTestSuite) TestReadAttestation() {
+	path := filepath.Join(s.T().TempDir(), "bootstrap.json")
+
+	s.Nil(os.WriteFile(path, []byte(`{"quote":"0x1234"}`), 0600))
+	_, _, err := readAttestation(path)
+	s.ErrorIs(err, verifier.ErrInvalidQuote)
+
+	s.Nil(os.WriteFile(path, []byte("1234\n"), 0600))
+	_, _, err = readAttestation(path)
+	s.ErrorIs(err, verifier.ErrInvalidQuote)
+
+	_, _, err = readAttestation(filepath.Join(s.T().TempDir(), "missing.json"))
+	s.ErrorContains(err, "failed to read SGX attestation")
+}
+
+func (s *SgxManagerTestSuite) TestFindInstance() {
+	address := common.BytesToAddress([]byte{1})
+	instances := []*InstanceInfo{
+		{ID: 0, Address: address},
+		{ID: 1, Address: common.BytesToAddress([]byte{2})},
+		{ID: 2, Address: address},
+	}
+
+	s.Equal(uint64(2), findInstance(instances, address).ID)
+	s.Nil(findInstance(instances, common.BytesToAddress([]byte{3})))
+}
+
+func (s *SgxManagerTestSuite) TestCheckValidity() {
+	now := time.Now()
+	instance := &InstanceInfo{Valid: true, ValidSince: now.Add(-time.Hour), ExpiresAt: now.Add(48 * time.Hour)}
+
+	s.Nil(checkValidity(instance, now.Add(24*time.Hour)))
+	s.ErrorContains(checkValidity(instance, now.Add(72*time.Hour)), "expires at")
+
+	instance.Valid = false
+	s.ErrorContains(checkValidity(instance, now), "is not valid")
+}
+
+func TestSgxManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(SgxManagerTestSuite))
+}
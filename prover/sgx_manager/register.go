@@ -0,0 +1,104 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	pkgFlags "github.com/taikoxyz/taiko-client/pkg/flags"
+)
+
+// RegisterInstanceResult is the result of registering an SGX instance.
+type RegisterInstanceResult struct {
+	TxHash   common.Hash   `json:"txHash"`
+	Instance *InstanceInfo `json:"instance"`
+}
+
+// InstanceRegisterer registers a new SGX instance in the SgxVerifier contract, from the attestation output by
+// the raiko SGX bootstrap.
+type InstanceRegisterer struct {
+	manager
+	txmgr    *txmgr.SimpleTxManager
+	quote    *bindings.V3StructParsedV3QuoteStruct
+	instance common.Address
+}
+
+// InitFromCli initializes the given instance registerer based on the command line flags.
+func (r *InstanceRegisterer) InitFromCli(ctx context.Context, c *cli.Context) (err error) {
+	if !c.IsSet(flags.SgxAttestation.Name) {
+		return errors.New("empty SGX attestation")
+	}
+	if r.quote, r.instance, err = readAttestation(c.String(flags.SgxAttestation.Name)); err != nil {
+		return err
+	}
+
+	privKey, err := crypto.ToECDSA(common.FromHex(c.String(flags.L1ProverPrivKey.Name)))
+	if err != nil {
+		return fmt.Errorf("invalid L1 prover private key: %w", err)
+	}
+	if r.txmgr, err = txmgr.NewSimpleTxManager(
+		"sgx",
+		log.Root(),
+		&metrics.TxMgrMetrics,
+		*pkgFlags.InitTxmgrConfigsFromCli(c.String(flags.L1HTTPEndpoint.Name), privKey, c),
+	); err != nil {
+		return err
+	}
+
+	return r.initFromCli(ctx, c)
+}
+
+// Name returns the application name.
+func (r *InstanceRegisterer) Name() string {
+	return "sgx register"
+}
+
+// Run registers the attested SGX instance, and returns the registered instance.
+func (r *InstanceRegisterer) Run(ctx context.Context) (interface{}, error) {
+	data, err := encoding.SGXVerifierABI.Pack("registerInstance", *r.quote)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Register SGX instance", "instance", r.instance, "verifier", r.address)
+
+	receipt, err := r.txmgr.Send(ctx, txmgr.TxCandidate{TxData: data, To: &r.address})
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("failed to register SGX instance %s: %s", r.instance, receipt.TxHash.Hex())
+	}
+
+	for _, l := range receipt.Logs {
+		event, err := r.contract.ParseInstanceAdded(*l)
+		if err != nil || event.Instance != r.instance {
+			continue
+		}
+
+		validSince := event.ValidSince.Uint64()
+		return &RegisterInstanceResult{
+			TxHash: receipt.TxHash,
+			Instance: &InstanceInfo{
+				ID:         event.Id.Uint64(),
+				Address:    event.Instance,
+				ValidSince: time.Unix(int64(validSince), 0).UTC(),
+				ExpiresAt:  time.Unix(int64(validSince+r.expiry), 0).UTC(),
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no InstanceAdded event found in transaction %s", receipt.TxHash.Hex())
+}
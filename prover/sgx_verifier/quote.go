@@ -0,0 +1,129 @@
+package verifier
+
+import (
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// Lengths of the parts of an SGX DCAP v3 quote.
+const (
+	quoteHeaderLength   = 48
+	enclaveReportLength = 384
+	ecdsaSignatureBytes = 64
+	certificatesInChain = 3
+)
+
+var ErrInvalidQuote = errors.New("invalid SGX quote")
+
+// ParseQuote parses the given SGX DCAP v3 quote into the attestation struct accepted by
+// SgxVerifier.registerInstance, in the same way as the V3Parser contract.
+func ParseQuote(quote []byte) (*bindings.V3StructParsedV3QuoteStruct, error) {
+	r := &quoteReader{data: quote}
+
+	var parsed bindings.V3StructParsedV3QuoteStruct
+
+	header := r.next(quoteHeaderLength)
+	copy(parsed.Header.Version[:], header[0:2])
+	copy(parsed.Header.AttestationKeyType[:], header[2:4])
+	copy(parsed.Header.TeeType[:], header[4:8])
+	copy(parsed.Header.QeSvn[:], header[8:10])
+	copy(parsed.Header.PceSvn[:], header[10:12])
+	copy(parsed.Header.QeVendorId[:], header[12:28])
+	copy(parsed.Header.UserData[:], header[28:48])
+
+	parsed.LocalEnclaveReport = parseEnclaveReport(r.next(enclaveReportLength))
+
+	authDataSize := binary.LittleEndian.Uint32(r.next(4))
+	if r.err == nil && uint64(authDataSize) != uint64(len(quote)-r.offset) {
+		return nil, fmt.Errorf(
+			"%w: auth data size %d, remaining %d bytes",
+			ErrInvalidQuote, authDataSize, len(quote)-r.offset,
+		)
+	}
+
+	authData := &parsed.V3AuthData
+	authData.Ecdsa256BitSignature = r.next(ecdsaSignatureBytes)
+	authData.EcdsaAttestationKey = r.next(ecdsaSignatureBytes)
+	authData.PckSignedQeReport = parseEnclaveReport(r.next(enclaveReportLength))
+	authData.QeReportSignature = r.next(ecdsaSignatureBytes)
+
+	authData.QeAuthData.ParsedDataSize = binary.LittleEndian.Uint16(r.next(2))
+	authData.QeAuthData.Data = r.next(int(authData.QeAuthData.ParsedDataSize))
+
+	authData.Certification.CertType = binary.LittleEndian.Uint16(r.next(2))
+	authData.Certification.CertDataSize = binary.LittleEndian.Uint32(r.next(4))
+	certData := r.next(int(authData.Certification.CertDataSize))
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	for i := 0; i < certificatesInChain; i++ {
+		var block *pem.Block
+		if block, certData = pem.Decode(certData); block == nil {
+			return nil, fmt.Errorf("%w: %d certificates in chain, expected %d", ErrInvalidQuote, i, certificatesInChain)
+		}
+		authData.Certification.DecodedCertDataArray[i] = block.Bytes
+	}
+
+	return &parsed, nil
+}
+
+// InstanceAddress returns the SGX instance address attested by the given quote, which is the first 20 bytes of
+// the enclave report data.
+func InstanceAddress(quote *bindings.V3StructParsedV3QuoteStruct) common.Address {
+	return common.BytesToAddress(quote.LocalEnclaveReport.ReportData[:common.AddressLength])
+}
+
+// parseEnclaveReport parses the given SGX enclave report.
+func parseEnclaveReport(raw []byte) bindings.V3StructEnclaveReport {
+	var report bindings.V3StructEnclaveReport
+	if len(raw) != enclaveReportLength {
+		return report
+	}
+
+	copy(report.CpuSvn[:], raw[0:16])
+	copy(report.MiscSelect[:], raw[16:20])
+	copy(report.Reserved1[:], raw[20:48])
+	copy(report.Attributes[:], raw[48:64])
+	copy(report.MrEnclave[:], raw[64:96])
+	copy(report.Reserved2[:], raw[96:128])
+	copy(report.MrSigner[:], raw[128:160])
+	report.Reserved3 = common.CopyBytes(raw[160:256])
+	report.IsvProdId = binary.LittleEndian.Uint16(raw[256:258])
+	report.IsvSvn = binary.LittleEndian.Uint16(raw[258:260])
+	report.Reserved4 = common.CopyBytes(raw[260:320])
+	report.ReportData = common.CopyBytes(raw[320:384])
+
+	return report
+}
+
+// quoteReader reads the SGX quote sequentially, once the quote is too short, the error is recorded, and all
+// the following reads return zeroed bytes of the fixed size parts, or nil for the variable size parts.
+type quoteReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+// next returns the next n bytes of the quote.
+func (r *quoteReader) next(n int) []byte {
+	if r.err != nil || r.offset+n > len(r.data) {
+		if r.err == nil {
+			r.err = fmt.Errorf("%w: too short, length %d", ErrInvalidQuote, len(r.data))
+		}
+		if n > enclaveReportLength {
+			return nil
+		}
+		return make([]byte, n)
+	}
+
+	b := common.CopyBytes(r.data[r.offset : r.offset+n])
+	r.offset += n
+	return b
+}
@@ -0,0 +1,63 @@
+package verifier
+
+import (
+	"encoding/binary"
+	"encoding/pem"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testQuote creates an SGX DCAP v3 quote, which attests the given instance address.
+func testQuote(instance common.Address) []byte {
+	report := make([]byte, enclaveReportLength)
+	report[0] = 1
+	binary.LittleEndian.PutUint16(report[256:258], 2)
+	copy(report[320:], instance.Bytes())
+
+	var certData []byte
+	for i := 0; i < certificatesInChain; i++ {
+		certData = append(certData, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{byte(i)}})...)
+	}
+
+	var authData []byte
+	authData = append(authData, make([]byte, 2*ecdsaSignatureBytes)...)
+	authData = append(authData, make([]byte, enclaveReportLength)...)
+	authData = append(authData, make([]byte, ecdsaSignatureBytes)...)
+	authData = binary.LittleEndian.AppendUint16(authData, 3)
+	authData = append(authData, 7, 8, 9)
+	authData = binary.LittleEndian.AppendUint16(authData, 5)
+	authData = binary.LittleEndian.AppendUint32(authData, uint32(len(certData)))
+	authData = append(authData, certData...)
+
+	quote := make([]byte, quoteHeaderLength)
+	binary.LittleEndian.PutUint16(quote[0:2], 3)
+	quote = append(quote, report...)
+	quote = binary.LittleEndian.AppendUint32(quote, uint32(len(authData)))
+	return append(quote, authData...)
+}
+
+func (s *SgxVerifierTestSuite) TestParseQuote() {
+	instance := common.BytesToAddress([]byte{10})
+	quote, err := ParseQuote(testQuote(instance))
+	s.Nil(err)
+	s.Equal([2]byte{3, 0}, quote.Header.Version)
+	s.Equal(byte(1), quote.LocalEnclaveReport.CpuSvn[0])
+	s.Equal(uint16(2), quote.LocalEnclaveReport.IsvProdId)
+	s.Equal(instance, InstanceAddress(quote))
+	s.Equal([]byte{7, 8, 9}, quote.V3AuthData.QeAuthData.Data)
+	s.Equal(uint16(5), quote.V3AuthData.Certification.CertType)
+	for i, cert := range quote.V3AuthData.Certification.DecodedCertDataArray {
+		s.Equal([]byte{byte(i)}, cert)
+	}
+}
+
+func (s *SgxVerifierTestSuite) TestParseInvalidQuote() {
+	quote := testQuote(common.BytesToAddress([]byte{10}))
+
+	_, err := ParseQuote(quote[:100])
+	s.ErrorIs(err, ErrInvalidQuote)
+	_, err = ParseQuote(quote[:len(quote)-1])
+	s.ErrorContains(err, "auth data size")
+	_, err = ParseQuote(append(quote, 0))
+	s.ErrorContains(err, "auth data size")
+}
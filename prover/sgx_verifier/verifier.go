@@ -0,0 +1,204 @@
+package verifier
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// proofLength is the length of an SGX proof: 4 bytes instance ID, 20 bytes new instance address,
+// and 65 bytes signature.
+const proofLength = 89
+
+var (
+	ErrInvalidProof    = errors.New("invalid SGX proof")
+	ErrInvalidInstance = errors.New("invalid SGX instance")
+)
+
+// Proof is a decoded SGX tier proof.
+type Proof struct {
+	InstanceID  uint32
+	NewInstance common.Address
+	Signature   []byte
+}
+
+// DecodeProof decodes the given SGX tier proof bytes.
+func DecodeProof(data []byte) (*Proof, error) {
+	if len(data) != proofLength {
+		return nil, fmt.Errorf("%w: length %d, expected %d", ErrInvalidProof, len(data), proofLength)
+	}
+
+	return &Proof{
+		InstanceID:  binary.BigEndian.Uint32(data[:4]),
+		NewInstance: common.BytesToAddress(data[4:24]),
+		Signature:   common.CopyBytes(data[24:]),
+	}, nil
+}
+
+// Signer recovers the address of the SGX instance which signed the given public inputs hash, in the same way as
+// the OpenZeppelin ECDSA.recover function.
+func (p *Proof) Signer(hash common.Hash) (common.Address, error) {
+	sig := common.CopyBytes(p.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// Instance is an SGX instance registered in the SgxVerifier contract.
+type Instance struct {
+	Addr       common.Address
+	ValidSince uint64
+}
+
+// Verifier verifies the SGX tier proofs locally before they are submitted, in the same way as the
+// SgxVerifier contract, so a bad proof is rejected without paying for a reverted transaction.
+type Verifier struct {
+	rpc      *rpc.Client
+	address  common.Address
+	contract *bindings.SgxVerifier
+	chainID  uint64
+	expiry   uint64
+	// Warn when the instance which signs the proofs expires within this duration.
+	expiryAlert time.Duration
+}
+
+// New creates a new Verifier instance, the SgxVerifier contract address is resolved by the given verifier name.
+func New(
+	ctx context.Context,
+	rpc *rpc.Client,
+	verifierName [32]byte,
+	expiryAlert time.Duration,
+) (*Verifier, error) {
+	address, err := rpc.TaikoL1.Resolve0(&bind.CallOpts{Context: ctx}, verifierName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve SgxVerifier address: %w", err)
+	}
+
+	contract, err := bindings.NewSgxVerifier(address, rpc.L1)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry, err := contract.INSTANCEEXPIRY(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SGX instance expiry: %w", err)
+	}
+
+	return &Verifier{
+		rpc:      rpc,
+		address:  address,
+		contract: contract,
+		chainID:  rpc.L2.ChainID.Uint64(),
+		expiry:   expiry,
+
+		expiryAlert: expiryAlert,
+	}, nil
+}
+
+// Verify checks the given SGX proof of the given transition, which will be submitted by the given prover.
+func (v *Verifier) Verify(
+	ctx context.Context,
+	transition *bindings.TaikoDataTransition,
+	prover common.Address,
+	metaHash common.Hash,
+	data []byte,
+) error {
+	proof, err := DecodeProof(data)
+	if err != nil {
+		return err
+	}
+
+	signer, err := v.recoverSigner(transition, prover, metaHash, proof)
+	if err != nil {
+		return err
+	}
+
+	instance, err := v.contract.Instances(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(uint64(proof.InstanceID)))
+	if err != nil {
+		return fmt.Errorf("failed to get SGX instance %d: %w", proof.InstanceID, err)
+	}
+
+	header, err := v.rpc.L1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := checkInstance(proof.InstanceID, signer, (*Instance)(&instance), v.expiry, header.Time); err != nil {
+		return err
+	}
+
+	expiresAt := instance.ValidSince + v.expiry
+	metrics.ProverSgxInstanceExpiresAtGaugeVec.WithLabelValues(strconv.FormatUint(v.chainID, 10)).Set(float64(expiresAt))
+	if expiresIn := time.Duration(expiresAt-header.Time) * time.Second; expiresIn < v.expiryAlert {
+		log.Warn(
+			"SGX instance expires soon, register a new instance",
+			"id", proof.InstanceID,
+			"instance", instance.Addr,
+			"expiresAt", time.Unix(int64(expiresAt), 0).UTC(),
+			"expiresIn", expiresIn,
+		)
+	}
+
+	return nil
+}
+
+// recoverSigner recovers the SGX instance which signed the public inputs of the given transition.
+func (v *Verifier) recoverSigner(
+	transition *bindings.TaikoDataTransition,
+	prover common.Address,
+	metaHash common.Hash,
+	proof *Proof,
+) (common.Address, error) {
+	inputs, err := encoding.EncodeSgxPublicInputs(v.chainID, v.address, transition, proof.NewInstance, prover, metaHash)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return proof.Signer(crypto.Keccak256Hash(inputs))
+}
+
+// checkInstance checks whether the given signer is the given registered SGX instance, and the instance is valid
+// at the given L1 timestamp.
+func checkInstance(id uint32, signer common.Address, instance *Instance, expiry uint64, now uint64) error {
+	if signer == (common.Address{}) || signer != instance.Addr {
+		return fmt.Errorf(
+			"%w: instance %d is %s, but the proof is signed by %s",
+			ErrInvalidInstance, id, instance.Addr, signer,
+		)
+	}
+	if now < instance.ValidSince {
+		return fmt.Errorf(
+			"%w: instance %d is not valid until %s",
+			ErrInvalidInstance, id, time.Unix(int64(instance.ValidSince), 0).UTC(),
+		)
+	}
+	if now > instance.ValidSince+expiry {
+		return fmt.Errorf(
+			"%w: instance %d expired at %s",
+			ErrInvalidInstance, id, time.Unix(int64(instance.ValidSince+expiry), 0).UTC(),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package verifier
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+type SgxVerifierTestSuite struct {
+	suite.Suite
+	verifier    *Verifier
+	instanceKey *ecdsa.PrivateKey
+	transition  *bindings.TaikoDataTransition
+	prover      common.Address
+	metaHash    common.Hash
+}
+
+func (s *SgxVerifierTestSuite) SetupTest() {
+	var err error
+	s.instanceKey, err = crypto.GenerateKey()
+	s.Nil(err)
+
+	s.verifier = &Verifier{chainID: 167001, address: common.BytesToAddress([]byte{1}), expiry: 100}
+	s.transition = &bindings.TaikoDataTransition{
+		ParentHash: common.BytesToHash([]byte{2}),
+		BlockHash:  common.BytesToHash([]byte{3}),
+		StateRoot:  common.BytesToHash([]byte{4}),
+	}
+	s.prover = common.BytesToAddress([]byte{5})
+	s.metaHash = common.BytesToHash([]byte{6})
+}
+
+// signProof creates an SGX proof of the test transition, signed by the test instance.
+func (s *SgxVerifierTestSuite) signProof(id uint32, newInstance common.Address) []byte {
+	inputs, err := encoding.EncodeSgxPublicInputs(
+		s.verifier.chainID,
+		s.verifier.address,
+		s.transition,
+		newInstance,
+		s.prover,
+		s.metaHash,
+	)
+	s.Nil(err)
+
+	sig, err := crypto.Sign(crypto.Keccak256(inputs), s.instanceKey)
+	s.Nil(err)
+	sig[crypto.RecoveryIDOffset] += 27
+
+	data := binary.BigEndian.AppendUint32(nil, id)
+	data = append(data, newInstance.Bytes()...)
+	return append(data, sig...)
+}
+
+func (s *SgxVerifierTestSuite) TestDecodeProof() {
+	newInstance := common.BytesToAddress([]byte{7})
+	proof, err := DecodeProof(s.signProof(3, newInstance))
+	s.Nil(err)
+	s.Equal(uint32(3), proof.InstanceID)
+	s.Equal(newInstance, proof.NewInstance)
+	s.Len(proof.Signature, 65)
+
+	_, err = DecodeProof(make([]byte, 100))
+	s.ErrorIs(err, ErrInvalidProof)
+}
+
+func (s *SgxVerifierTestSuite) TestRecoverSigner() {
+	proof, err := DecodeProof(s.signProof(1, common.BytesToAddress([]byte{7})))
+	s.Nil(err)
+
+	signer, err := s.verifier.recoverSigner(s.transition, s.prover, s.metaHash, proof)
+	s.Nil(err)
+	s.Equal(crypto.PubkeyToAddress(s.instanceKey.PublicKey), signer)
+
+	// The proof is bound to the prover who submits it.
+	signer, err = s.verifier.recoverSigner(s.transition, common.BytesToAddress([]byte{8}), s.metaHash, proof)
+	s.Nil(err)
+	s.NotEqual(crypto.PubkeyToAddress(s.instanceKey.PublicKey), signer)
+}
+
+func (s *SgxVerifierTestSuite) TestCheckInstance() {
+	signer := crypto.PubkeyToAddress(s.instanceKey.PublicKey)
+	instance := &Instance{Addr: signer, ValidSince: 1000}
+
+	s.Nil(checkInstance(1, signer, instance, s.verifier.expiry, 1050))
+	s.ErrorIs(checkInstance(1, common.BytesToAddress([]byte{9}), instance, s.verifier.expiry, 1050), ErrInvalidInstance)
+	s.ErrorIs(checkInstance(1, common.Address{}, &Instance{}, s.verifier.expiry, 1050), ErrInvalidInstance)
+	s.ErrorContains(checkInstance(1, signer, instance, s.verifier.expiry, 999), "not valid until")
+	s.ErrorContains(checkInstance(1, signer, instance, s.verifier.expiry, 1101), "expired at")
+}
+
+func TestSgxVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(SgxVerifierTestSuite))
+}
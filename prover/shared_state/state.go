@@ -12,7 +12,8 @@ import (
 type SharedState struct {
 	lastHandledBlockID atomic.Uint64
 	l1Current          atomic.Value
-	tiers              []*rpc.TierProviderTierWithID
+	tiers              atomic.Value
+	inflightProofs     atomic.Int64
 }
 
 // New creates a new prover shared state instance.
@@ -45,10 +46,31 @@ func (s *SharedState) SetL1Current(header *types.Header) {
 
 // GetTiers returns the current proof tiers.
 func (s *SharedState) GetTiers() []*rpc.TierProviderTierWithID {
-	return s.tiers
+	if val := s.tiers.Load(); val != nil {
+		return val.([]*rpc.TierProviderTierWithID)
+	}
+	return nil
 }
 
 // SetTiers sets the current proof tiers.
 func (s *SharedState) SetTiers(tiers []*rpc.TierProviderTierWithID) {
-	s.tiers = tiers
+	s.tiers.Store(tiers)
+}
+
+// GetInflightProofs returns the number of proofs which have been requested but not submitted yet.
+func (s *SharedState) GetInflightProofs() uint64 {
+	if n := s.inflightProofs.Load(); n > 0 {
+		return uint64(n)
+	}
+	return 0
+}
+
+// IncInflightProofs increases the number of in-flight proofs by one.
+func (s *SharedState) IncInflightProofs() {
+	s.inflightProofs.Add(1)
+}
+
+// DecInflightProofs decreases the number of in-flight proofs by n.
+func (s *SharedState) DecInflightProofs(n int) {
+	s.inflightProofs.Add(-int64(n))
 }
@@ -0,0 +1,258 @@
+package gate
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/internal/utils"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+// checkInterval is the interval of checking the L1 base fee and the held proofs deadlines.
+var checkInterval = 12 * time.Second
+
+// Config contains the configurations of the proof submission gate.
+type Config struct {
+	// Proofs are held while the L1 base fee is above this target.
+	TargetBaseFee *big.Int
+	// A held proof will be released at this long before its proving window closes, whatever the L1 base fee is.
+	SafetyMargin time.Duration
+}
+
+// heldProof is a finished proof waiting for a lower L1 base fee.
+type heldProof struct {
+	proof *proofProducer.ProofWithHeader
+	// The proof will be released at this time, whatever the L1 base fee is.
+	deadline time.Time
+}
+
+// Gate holds the finished proofs of the blocks assigned to the current prover identities while the L1 base fee is
+// above the target, and releases them once the base fee drops, or once the proving window is about to close.
+// The proofs of the other blocks are released immediately, since anyone can submit them.
+type Gate struct {
+	cfg             *Config
+	rpc             *rpc.Client
+	chain           string
+	proverAddresses []common.Address
+	// tiers returns the protocol proof tiers.
+	tiers func() []*rpc.TierProviderTierWithID
+	// Submitted proofs whose deadlines have not been looked up yet.
+	incoming []*proofProducer.ProofWithHeader
+	notifyCh chan struct{}
+	readyCh  chan<- *proofProducer.ProofWithHeader
+	held     []*heldProof
+	baseFee  *big.Int
+	mutex    sync.Mutex
+	wg       sync.WaitGroup
+}
+
+// New creates a new Gate instance, the released proofs will be sent to the given channel. The metrics are
+// labelled with the given L2 chain ID.
+func New(
+	cfg *Config,
+	rpc *rpc.Client,
+	chain string,
+	proverAddresses []common.Address,
+	tiers func() []*rpc.TierProviderTierWithID,
+	readyCh chan<- *proofProducer.ProofWithHeader,
+) *Gate {
+	return &Gate{
+		cfg:             cfg,
+		rpc:             rpc,
+		chain:           chain,
+		proverAddresses: proverAddresses,
+		tiers:           tiers,
+		notifyCh:        make(chan struct{}, 1),
+		readyCh:         readyCh,
+	}
+}
+
+// Start starts the gate loop, which will be stopped when the given context is done.
+func (g *Gate) Start(ctx context.Context) {
+	g.wg.Add(1)
+	go g.loop(ctx)
+}
+
+// Wait waits until the gate loop exits.
+func (g *Gate) Wait() {
+	g.wg.Wait()
+}
+
+// Submit passes a finished proof to the gate, it never blocks, so the gate loop can always deliver
+// the released proofs to the caller.
+func (g *Gate) Submit(proof *proofProducer.ProofWithHeader) {
+	g.mutex.Lock()
+	g.incoming = append(g.incoming, proof)
+	g.mutex.Unlock()
+
+	select {
+	case g.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Drain removes and returns all the submitted and held proofs, it should be called once the gate loop
+// exited, so that no proof is in flight.
+func (g *Gate) Drain() []*proofProducer.ProofWithHeader {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	proofs := make([]*proofProducer.ProofWithHeader, 0, len(g.incoming)+len(g.held))
+	proofs = append(proofs, g.incoming...)
+	for _, h := range g.held {
+		proofs = append(proofs, h.proof)
+	}
+	g.incoming, g.held = nil, nil
+	metrics.ProverProofsHeldGaugeVec.WithLabelValues(g.chain).Set(0)
+
+	return proofs
+}
+
+// loop is the main loop of the gate.
+func (g *Gate) loop(ctx context.Context) {
+	defer g.wg.Done()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	g.updateBaseFee(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-g.notifyCh:
+			for _, proof := range g.takeIncoming() {
+				deadline, err := g.deadline(ctx, proof)
+				if err != nil {
+					log.Warn("Failed to get proof submission deadline", "blockID", proof.BlockID, "error", err)
+				}
+				g.hold(proof, deadline)
+			}
+		case <-ticker.C:
+			g.updateBaseFee(ctx)
+		}
+
+		if !g.deliver(ctx, g.release(time.Now())) {
+			return
+		}
+	}
+}
+
+// takeIncoming removes and returns the submitted proofs.
+func (g *Gate) takeIncoming() []*proofProducer.ProofWithHeader {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	proofs := g.incoming
+	g.incoming = nil
+	return proofs
+}
+
+// deliver sends the given released proofs to the ready channel, and returns false if the given context is
+// done before all proofs are sent, the undelivered proofs are held again, so that they can be drained.
+func (g *Gate) deliver(ctx context.Context, proofs []*proofProducer.ProofWithHeader) bool {
+	for i, proof := range proofs {
+		select {
+		case <-ctx.Done():
+			for _, undelivered := range proofs[i:] {
+				g.hold(undelivered, time.Time{})
+			}
+			return false
+		case g.readyCh <- proof:
+		}
+	}
+	return true
+}
+
+// deadline returns the time to release the given proof whatever the L1 base fee is, zero means the proof
+// should be released immediately.
+func (g *Gate) deadline(ctx context.Context, proof *proofProducer.ProofWithHeader) (time.Time, error) {
+	block, err := g.rpc.TaikoL1.GetBlock(&bind.CallOpts{Context: ctx}, proof.BlockID.Uint64())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Other provers can submit the proofs of the blocks which are not assigned to us, or the blocks which
+	// have been proven already, when contesting.
+	if !slices.Contains(g.proverAddresses, block.AssignedProver) || block.NextTransitionId > 1 {
+		return time.Time{}, nil
+	}
+
+	for _, t := range g.tiers() {
+		if t.ID == proof.Meta.MinTier {
+			provingWindow := time.Duration(t.ProvingWindow) * time.Minute
+			return time.Unix(int64(proof.Meta.Timestamp), 0).Add(provingWindow - g.cfg.SafetyMargin), nil
+		}
+	}
+
+	return time.Time{}, nil
+}
+
+// hold holds the given proof until the given deadline.
+func (g *Gate) hold(proof *proofProducer.ProofWithHeader, deadline time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.held = append(g.held, &heldProof{proof: proof, deadline: deadline})
+	metrics.ProverProofsHeldGaugeVec.WithLabelValues(g.chain).Set(float64(len(g.held)))
+}
+
+// release removes and returns the held proofs which should be submitted now.
+func (g *Gate) release(now time.Time) []*proofProducer.ProofWithHeader {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var (
+		released []*proofProducer.ProofWithHeader
+		held     []*heldProof
+		cheap    = g.baseFee != nil && g.baseFee.Cmp(g.cfg.TargetBaseFee) <= 0
+	)
+	for _, h := range g.held {
+		if cheap || !now.Before(h.deadline) {
+			released = append(released, h.proof)
+			continue
+		}
+
+		log.Debug(
+			"Hold proof until L1 base fee drops",
+			"blockID", h.proof.BlockID,
+			"tier", h.proof.Tier,
+			"baseFee", g.baseFee,
+			"deadline", h.deadline,
+		)
+		held = append(held, h)
+	}
+	g.held = held
+	metrics.ProverProofsHeldGaugeVec.WithLabelValues(g.chain).Set(float64(len(g.held)))
+
+	return released
+}
+
+// updateBaseFee fetches the base fee of the latest L1 block.
+func (g *Gate) updateBaseFee(ctx context.Context) {
+	header, err := g.rpc.L1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		log.Warn("Failed to get L1 base fee", "error", err)
+		return
+	}
+	if header.BaseFee == nil {
+		return
+	}
+
+	g.mutex.Lock()
+	g.baseFee = header.BaseFee
+	g.mutex.Unlock()
+
+	baseFee, _ := utils.WeiToGWei(header.BaseFee).Float64()
+	metrics.ProverL1BaseFeeGaugeVec.WithLabelValues(g.chain).Set(baseFee)
+}
@@ -0,0 +1,100 @@
+package gate
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+type SubmissionGateTestSuite struct {
+	suite.Suite
+	gate *Gate
+}
+
+func (s *SubmissionGateTestSuite) SetupTest() {
+	s.gate = New(
+		&Config{TargetBaseFee: big.NewInt(10), SafetyMargin: time.Minute},
+		nil,
+		"",
+		[]common.Address{{}},
+		nil,
+		make(chan *proofProducer.ProofWithHeader, 16),
+	)
+}
+
+func testProof(id int64) *proofProducer.ProofWithHeader {
+	return &proofProducer.ProofWithHeader{BlockID: big.NewInt(id)}
+}
+
+func (s *SubmissionGateTestSuite) TestReleaseImmediately() {
+	s.gate.baseFee = big.NewInt(100)
+	s.gate.hold(testProof(1), time.Time{})
+	s.Len(s.gate.release(time.Now()), 1)
+	s.Empty(s.gate.held)
+}
+
+func (s *SubmissionGateTestSuite) TestHoldUntilBaseFeeDrops() {
+	s.gate.baseFee = big.NewInt(100)
+	s.gate.hold(testProof(1), time.Now().Add(time.Hour))
+	s.gate.hold(testProof(2), time.Now().Add(time.Hour))
+	s.Empty(s.gate.release(time.Now()))
+	s.Len(s.gate.held, 2)
+
+	s.gate.baseFee = big.NewInt(10)
+	s.Len(s.gate.release(time.Now()), 2)
+	s.Empty(s.gate.held)
+}
+
+func (s *SubmissionGateTestSuite) TestHoldUntilDeadline() {
+	s.gate.baseFee = big.NewInt(100)
+	deadline := time.Now().Add(time.Hour)
+	s.gate.hold(testProof(1), deadline)
+	s.gate.hold(testProof(2), deadline.Add(time.Hour))
+
+	released := s.gate.release(deadline)
+	s.Len(released, 1)
+	s.Equal(common.Big1, released[0].BlockID)
+	s.Len(s.gate.held, 1)
+}
+
+func (s *SubmissionGateTestSuite) TestHoldWithoutBaseFee() {
+	s.gate.hold(testProof(1), time.Now().Add(time.Hour))
+	s.Empty(s.gate.release(time.Now()))
+}
+
+func (s *SubmissionGateTestSuite) TestDrain() {
+	s.gate.hold(testProof(1), time.Now().Add(time.Hour))
+	s.gate.hold(testProof(2), time.Now().Add(time.Hour))
+	s.gate.Submit(testProof(3))
+	s.Len(s.gate.Drain(), 3)
+	s.Empty(s.gate.held)
+	s.Empty(s.gate.incoming)
+}
+
+func (s *SubmissionGateTestSuite) TestSubmitNonBlocking() {
+	// The gate loop is not running, submitting more proofs than the channel capacity must not block.
+	for i := 0; i < 64; i++ {
+		s.gate.Submit(testProof(int64(i)))
+	}
+	s.Len(s.gate.takeIncoming(), 64)
+}
+
+func (s *SubmissionGateTestSuite) TestDeliverHoldsUndelivered() {
+	readyCh := make(chan *proofProducer.ProofWithHeader)
+	s.gate.readyCh = readyCh
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.False(s.gate.deliver(ctx, []*proofProducer.ProofWithHeader{testProof(1), testProof(2)}))
+	s.Len(s.gate.Drain(), 2)
+}
+
+func TestSubmissionGateTestSuite(t *testing.T) {
+	suite.Run(t, new(SubmissionGateTestSuite))
+}
@@ -0,0 +1,107 @@
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+)
+
+// AlertKind is the kind of a watchtower alert.
+type AlertKind string
+
+// Watchtower alert kinds.
+const (
+	AlertInvalidTransition AlertKind = "invalid_transition"
+	AlertContestStarted    AlertKind = "contest_started"
+	AlertContestEnded      AlertKind = "contest_ended"
+	AlertVerificationNear  AlertKind = "verification_near"
+	AlertInvalidVerified   AlertKind = "invalid_verified"
+)
+
+// Alert is a notable change of a transition, compared to the local L2 canonical chain.
+type Alert struct {
+	Kind       AlertKind      `json:"kind"`
+	BlockID    uint64         `json:"blockID"`
+	ParentHash common.Hash    `json:"parentHash"`
+	BlockHash  common.Hash    `json:"blockHash"`
+	StateRoot  common.Hash    `json:"stateRoot"`
+	Tier       uint16         `json:"tier"`
+	Valid      bool           `json:"valid"`
+	Contester  common.Address `json:"contester"`
+	Message    string         `json:"message"`
+	Time       time.Time      `json:"time"`
+}
+
+// alerter sends the alerts to the log sink, and to the webhook if configured.
+type alerter struct {
+	webhook string
+	client  *http.Client
+}
+
+// newAlerter creates a new alerter, an empty webhook means the alerts are only logged.
+func newAlerter(webhook string, timeout time.Duration) *alerter {
+	return &alerter{webhook: webhook, client: &http.Client{Timeout: timeout}}
+}
+
+// send sends the given alert, a webhook failure is logged and won't be retried.
+func (a *alerter) send(ctx context.Context, alert *Alert) {
+	metrics.WatchtowerAlertCounterVec.WithLabelValues(string(alert.Kind)).Inc()
+
+	logger := log.Info
+	if !alert.Valid {
+		logger = log.Warn
+	}
+	logger(
+		"Watchtower alert",
+		"kind", alert.Kind,
+		"blockID", alert.BlockID,
+		"parentHash", alert.ParentHash,
+		"blockHash", alert.BlockHash,
+		"stateRoot", alert.StateRoot,
+		"tier", alert.Tier,
+		"valid", alert.Valid,
+		"contester", alert.Contester,
+		"message", alert.Message,
+	)
+
+	if a.webhook == "" {
+		return
+	}
+	if err := a.post(ctx, alert); err != nil {
+		log.Error("Failed to send watchtower alert to the webhook", "kind", alert.Kind, "error", err)
+	}
+}
+
+// post sends the given alert to the webhook.
+func (a *alerter) post(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected webhook response status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AlerterTestSuite struct {
+	suite.Suite
+}
+
+func (s *AlerterTestSuite) TestPost() {
+	received := make(chan *Alert, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+
+		alert := new(Alert)
+		s.Nil(json.NewDecoder(r.Body).Decode(alert))
+		received <- alert
+	}))
+	defer srv.Close()
+
+	a := newAlerter(srv.URL, time.Second)
+	s.Nil(a.post(context.Background(), &Alert{Kind: AlertInvalidTransition, BlockID: 1}))
+
+	alert := <-received
+	s.Equal(AlertInvalidTransition, alert.Kind)
+	s.Equal(uint64(1), alert.BlockID)
+}
+
+func (s *AlerterTestSuite) TestPostFailed() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	a := newAlerter(srv.URL, time.Second)
+	s.Error(a.post(context.Background(), &Alert{Kind: AlertContestStarted}))
+
+	// A webhook failure won't stop the alert being logged.
+	s.NotPanics(func() { a.send(context.Background(), &Alert{Kind: AlertContestStarted}) })
+}
+
+func TestAlerterTestSuite(t *testing.T) {
+	suite.Run(t, new(AlerterTestSuite))
+}
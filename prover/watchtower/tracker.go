@@ -0,0 +1,163 @@
+package watchtower
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// transitionKey identifies a transition of a block.
+type transitionKey struct {
+	blockID    uint64
+	parentHash common.Hash
+}
+
+// transition is the latest known state of a transition.
+type transition struct {
+	blockHash common.Hash
+	stateRoot common.Hash
+	tier      uint16
+	// Whether the transition matches the local L2 canonical chain.
+	valid bool
+	// Zero address if the transition is not contested.
+	contester common.Address
+	// The cooldown window starts from the latest proof or contest of the transition.
+	timestamp uint64
+	// Whether the verification near alert has been sent.
+	alerted bool
+}
+
+// tracker tracks the transitions of the unverified blocks, and decides which alerts to send.
+type tracker struct {
+	transitions       map[transitionKey]*transition
+	tiers             []*rpc.TierProviderTierWithID
+	verificationAlert time.Duration
+}
+
+// newTracker creates a new tracker, an invalid transition is alerted once it will be verified
+// within the given duration.
+func newTracker(tiers []*rpc.TierProviderTierWithID, verificationAlert time.Duration) *tracker {
+	return &tracker{
+		transitions:       make(map[transitionKey]*transition),
+		tiers:             tiers,
+		verificationAlert: verificationAlert,
+	}
+}
+
+// proved records a newly proved transition.
+func (t *tracker) proved(key transitionKey, tran *transition) []*Alert {
+	var alerts []*Alert
+	if prev, ok := t.transitions[key]; ok && prev.contester != rpc.ZeroAddress {
+		alerts = append(alerts, newAlert(AlertContestEnded, key, tran, fmt.Sprintf(
+			"contest resolved by a tier %d proof", tran.tier,
+		)))
+	}
+	if !tran.valid {
+		alerts = append(alerts, newAlert(AlertInvalidTransition, key, tran, "transition mismatches local L2 chain"))
+	}
+	t.transitions[key] = tran
+
+	return alerts
+}
+
+// contested records a newly contested transition.
+func (t *tracker) contested(key transitionKey, tran *transition) []*Alert {
+	t.transitions[key] = tran
+
+	message := "contested transition mismatches local L2 chain"
+	if tran.valid {
+		message = "contested transition matches local L2 chain"
+	}
+	return []*Alert{newAlert(AlertContestStarted, key, tran, message)}
+}
+
+// verified removes the transitions of the given verified block and all blocks before it.
+func (t *tracker) verified(blockID uint64, blockHash common.Hash) []*Alert {
+	var alerts []*Alert
+	for key, tran := range t.transitions {
+		if key.blockID > blockID {
+			continue
+		}
+		delete(t.transitions, key)
+
+		if key.blockID != blockID || tran.blockHash != blockHash {
+			continue
+		}
+		if tran.contester != rpc.ZeroAddress {
+			alerts = append(alerts, newAlert(AlertContestEnded, key, tran, "contested transition verified"))
+		}
+		if !tran.valid {
+			alerts = append(alerts, newAlert(AlertInvalidVerified, key, tran, "invalid transition verified"))
+		}
+	}
+
+	return alerts
+}
+
+// due returns the alerts of the invalid transitions whose cooldown windows end soon, each transition
+// is only alerted once until it is proved or contested again.
+func (t *tracker) due(now time.Time) []*Alert {
+	var alerts []*Alert
+	for key, tran := range t.transitions {
+		if tran.valid || tran.alerted {
+			continue
+		}
+		cooldownWindow, ok := t.cooldownWindow(tran.tier)
+		if !ok {
+			continue
+		}
+
+		deadline := time.Unix(int64(tran.timestamp), 0).Add(cooldownWindow)
+		if now.Add(t.verificationAlert).Before(deadline) {
+			continue
+		}
+		tran.alerted = true
+		alerts = append(alerts, newAlert(AlertVerificationNear, key, tran, fmt.Sprintf(
+			"invalid transition can be verified in %s", max(deadline.Sub(now), 0).Truncate(time.Second),
+		)))
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].BlockID < alerts[j].BlockID })
+
+	return alerts
+}
+
+// invalid returns the number of the tracked invalid transitions.
+func (t *tracker) invalid() int {
+	var count int
+	for _, tran := range t.transitions {
+		if !tran.valid {
+			count++
+		}
+	}
+	return count
+}
+
+// cooldownWindow returns the cooldown window of the given tier.
+func (t *tracker) cooldownWindow(tier uint16) (time.Duration, bool) {
+	for _, tierWithID := range t.tiers {
+		if tierWithID.ID == tier {
+			return time.Duration(tierWithID.CooldownWindow.Uint64()) * time.Minute, true
+		}
+	}
+	return 0, false
+}
+
+// newAlert creates a new alert of the given transition.
+func newAlert(kind AlertKind, key transitionKey, tran *transition, message string) *Alert {
+	return &Alert{
+		Kind:       kind,
+		BlockID:    key.blockID,
+		ParentHash: key.parentHash,
+		BlockHash:  tran.blockHash,
+		StateRoot:  tran.stateRoot,
+		Tier:       tran.tier,
+		Valid:      tran.valid,
+		Contester:  tran.contester,
+		Message:    message,
+		Time:       time.Now(),
+	}
+}
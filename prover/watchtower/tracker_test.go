@@ -0,0 +1,112 @@
+package watchtower
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+type TrackerTestSuite struct {
+	suite.Suite
+	tracker *tracker
+	now     time.Time
+}
+
+func (s *TrackerTestSuite) SetupTest() {
+	s.tracker = newTracker([]*rpc.TierProviderTierWithID{
+		{ID: 100, ITierProviderTier: bindings.ITierProviderTier{CooldownWindow: big.NewInt(60)}},
+		{ID: 200, ITierProviderTier: bindings.ITierProviderTier{CooldownWindow: big.NewInt(240)}},
+	}, 10*time.Minute)
+	s.now = time.Unix(1_700_000_000, 0)
+}
+
+func (s *TrackerTestSuite) key(blockID uint64) transitionKey {
+	return transitionKey{blockID: blockID, parentHash: common.BigToHash(new(big.Int).SetUint64(blockID - 1))}
+}
+
+func (s *TrackerTestSuite) transition(blockID uint64, valid bool) *transition {
+	return &transition{
+		blockHash: common.BigToHash(new(big.Int).SetUint64(blockID)),
+		tier:      100,
+		valid:     valid,
+		timestamp: uint64(s.now.Unix()),
+	}
+}
+
+func (s *TrackerTestSuite) kinds(alerts []*Alert) []AlertKind {
+	kinds := make([]AlertKind, 0, len(alerts))
+	for _, alert := range alerts {
+		kinds = append(kinds, alert.Kind)
+	}
+	return kinds
+}
+
+func (s *TrackerTestSuite) TestProved() {
+	s.Empty(s.tracker.proved(s.key(1), s.transition(1, true)))
+	s.Equal([]AlertKind{AlertInvalidTransition}, s.kinds(s.tracker.proved(s.key(2), s.transition(2, false))))
+	s.Equal(1, s.tracker.invalid())
+}
+
+func (s *TrackerTestSuite) TestContest() {
+	s.Equal([]AlertKind{AlertInvalidTransition}, s.kinds(s.tracker.proved(s.key(1), s.transition(1, false))))
+
+	contested := s.transition(1, false)
+	contested.contester = common.HexToAddress("0x01")
+	alerts := s.tracker.contested(s.key(1), contested)
+	s.Equal([]AlertKind{AlertContestStarted}, s.kinds(alerts))
+	s.Equal(contested.contester, alerts[0].Contester)
+
+	// A higher tier proof resolves the contest.
+	resolved := s.transition(1, true)
+	resolved.tier = 200
+	alerts = s.tracker.proved(s.key(1), resolved)
+	s.Equal([]AlertKind{AlertContestEnded}, s.kinds(alerts))
+	s.Equal(uint16(200), alerts[0].Tier)
+	s.Zero(s.tracker.invalid())
+}
+
+func (s *TrackerTestSuite) TestVerified() {
+	s.tracker.proved(s.key(1), s.transition(1, false))
+	s.tracker.proved(s.key(2), s.transition(2, true))
+	contested := s.transition(3, true)
+	contested.contester = common.HexToAddress("0x01")
+	s.tracker.contested(s.key(3), contested)
+
+	s.Equal([]AlertKind{AlertInvalidVerified}, s.kinds(s.tracker.verified(1, s.transition(1, false).blockHash)))
+	// The transitions of the blocks before the verified one are removed without alerts.
+	s.Equal([]AlertKind{AlertContestEnded}, s.kinds(s.tracker.verified(3, contested.blockHash)))
+	s.Empty(s.tracker.transitions)
+}
+
+func (s *TrackerTestSuite) TestDue() {
+	s.tracker.proved(s.key(1), s.transition(1, false))
+	s.tracker.proved(s.key(2), s.transition(2, true))
+	unknownTier := s.transition(3, false)
+	unknownTier.tier = 300
+	s.tracker.proved(s.key(3), unknownTier)
+
+	// The cooldown window of block 1 ends in 60 minutes.
+	s.Empty(s.tracker.due(s.now.Add(49 * time.Minute)))
+	alerts := s.tracker.due(s.now.Add(51 * time.Minute))
+	s.Equal([]AlertKind{AlertVerificationNear}, s.kinds(alerts))
+	s.Equal(uint64(1), alerts[0].BlockID)
+
+	// Each transition is only alerted once, until it is contested again.
+	s.Empty(s.tracker.due(s.now.Add(55 * time.Minute)))
+	contested := s.transition(1, false)
+	contested.contester = common.HexToAddress("0x01")
+	contested.timestamp = uint64(s.now.Add(55 * time.Minute).Unix())
+	s.tracker.contested(s.key(1), contested)
+	s.Empty(s.tracker.due(s.now.Add(56 * time.Minute)))
+	s.Len(s.tracker.due(s.now.Add(110*time.Minute)), 1)
+}
+
+func TestTrackerTestSuite(t *testing.T) {
+	suite.Run(t, new(TrackerTestSuite))
+}
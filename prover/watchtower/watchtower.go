@@ -0,0 +1,192 @@
+package watchtower
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	handler "github.com/taikoxyz/taiko-client/prover/event_handler"
+)
+
+var (
+	// checkInterval is the interval of checking whether the invalid transitions will be verified soon.
+	checkInterval = 12 * time.Second
+	// webhookTimeout is the timeout of sending an alert to the webhook.
+	webhookTimeout = 10 * time.Second
+)
+
+// Watchtower checks every proved and contested transition against the local L2 canonical chain, and
+// alerts on the invalid ones, without submitting any transaction.
+type Watchtower struct {
+	rpc           *rpc.Client
+	tracker       *tracker
+	alerter       *alerter
+	retryInterval time.Duration
+	maxRetries    uint64
+
+	ctx context.Context
+	wg  sync.WaitGroup
+}
+
+// InitFromCli initializes the given watchtower instance based on the command line flags.
+func (w *Watchtower) InitFromCli(ctx context.Context, c *cli.Context) (err error) {
+	w.ctx = ctx
+	w.retryInterval = c.Duration(flags.BackOffRetryInterval.Name)
+	w.maxRetries = c.Uint64(flags.BackOffMaxRetries.Name)
+
+	if w.rpc, err = rpc.NewClient(ctx, &rpc.ClientConfig{
+		L1Endpoint:     c.String(flags.L1WSEndpoint.Name),
+		L2Endpoint:     c.String(flags.L2WSEndpoint.Name),
+		TaikoL1Address: common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+		TaikoL2Address: common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+		Timeout:        c.Duration(flags.RPCTimeout.Name),
+	}); err != nil {
+		return err
+	}
+
+	tiers, err := w.rpc.GetTiers(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.tracker = newTracker(tiers, c.Duration(flags.WatchtowerVerificationAlert.Name))
+	w.alerter = newAlerter(c.String(flags.WatchtowerWebhook.Name), webhookTimeout)
+
+	return nil
+}
+
+// Name returns the application name.
+func (w *Watchtower) Name() string {
+	return "watchtower"
+}
+
+// Start starts the main loop of the watchtower.
+func (w *Watchtower) Start() error {
+	w.wg.Add(1)
+	go w.eventLoop()
+
+	return nil
+}
+
+// Close waits until the main loop exits.
+func (w *Watchtower) Close(_ context.Context) {
+	w.wg.Wait()
+}
+
+// eventLoop starts the main loop of the watchtower.
+func (w *Watchtower) eventLoop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	// Channels
+	blockVerifiedCh := make(chan *bindings.TaikoL1ClientBlockVerified, 128)
+	transitionProvedCh := make(chan *bindings.TaikoL1ClientTransitionProved, 128)
+	transitionContestedCh := make(chan *bindings.TaikoL1ClientTransitionContested, 128)
+	// Subscriptions
+	blockVerifiedSub := rpc.SubscribeBlockVerified(w.rpc.TaikoL1, blockVerifiedCh)
+	transitionProvedSub := rpc.SubscribeTransitionProved(w.rpc.TaikoL1, transitionProvedCh)
+	transitionContestedSub := rpc.SubscribeTransitionContested(w.rpc.TaikoL1, transitionContestedCh)
+	defer func() {
+		blockVerifiedSub.Unsubscribe()
+		transitionProvedSub.Unsubscribe()
+		transitionContestedSub.Unsubscribe()
+	}()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case e := <-transitionProvedCh:
+			w.withRetry(func() error { return w.onTransitionProved(e) })
+		case e := <-transitionContestedCh:
+			w.withRetry(func() error { return w.onTransitionContested(e) })
+		case e := <-blockVerifiedCh:
+			w.send(w.tracker.verified(e.BlockId.Uint64(), e.BlockHash))
+		case <-ticker.C:
+			w.send(w.tracker.due(time.Now()))
+		}
+	}
+}
+
+// onTransitionProved checks the given proved transition against the local L2 canonical chain.
+func (w *Watchtower) onTransitionProved(e *bindings.TaikoL1ClientTransitionProved) error {
+	valid, err := handler.IsValidProof(w.ctx, w.rpc, e.BlockId, e.Tran.ParentHash, e.Tran.BlockHash, e.Tran.StateRoot)
+	if err != nil {
+		return err
+	}
+
+	state, err := w.rpc.TaikoL1.GetTransition0(&bind.CallOpts{Context: w.ctx}, e.BlockId.Uint64(), e.Tran.ParentHash)
+	if err != nil {
+		return err
+	}
+
+	w.send(w.tracker.proved(
+		transitionKey{blockID: e.BlockId.Uint64(), parentHash: e.Tran.ParentHash},
+		&transition{
+			blockHash: e.Tran.BlockHash,
+			stateRoot: e.Tran.StateRoot,
+			tier:      e.Tier,
+			valid:     valid,
+			timestamp: state.Timestamp,
+		},
+	))
+
+	return nil
+}
+
+// onTransitionContested checks the given contested transition against the local L2 canonical chain.
+func (w *Watchtower) onTransitionContested(e *bindings.TaikoL1ClientTransitionContested) error {
+	state, err := w.rpc.TaikoL1.GetTransition0(&bind.CallOpts{Context: w.ctx}, e.BlockId.Uint64(), e.Tran.ParentHash)
+	if err != nil {
+		return err
+	}
+
+	valid, err := handler.IsValidProof(w.ctx, w.rpc, e.BlockId, e.Tran.ParentHash, state.BlockHash, state.StateRoot)
+	if err != nil {
+		return err
+	}
+
+	w.send(w.tracker.contested(
+		transitionKey{blockID: e.BlockId.Uint64(), parentHash: e.Tran.ParentHash},
+		&transition{
+			blockHash: state.BlockHash,
+			stateRoot: state.StateRoot,
+			tier:      e.Tier,
+			valid:     valid,
+			contester: e.Contester,
+			timestamp: state.Timestamp,
+		},
+	))
+
+	return nil
+}
+
+// send sends the given alerts, and updates the metrics.
+func (w *Watchtower) send(alerts []*Alert) {
+	for _, alert := range alerts {
+		w.alerter.send(w.ctx, alert)
+	}
+	metrics.WatchtowerInvalidTransitionsGauge.Set(float64(w.tracker.invalid()))
+}
+
+// withRetry retries the given function with the watchtower backoff policy.
+func (w *Watchtower) withRetry(f func() error) {
+	if err := backoff.Retry(f, backoff.WithContext(
+		backoff.WithMaxRetries(backoff.NewConstantBackOff(w.retryInterval), w.maxRetries),
+		w.ctx,
+	)); err != nil {
+		log.Error("Failed to check transition", "error", err)
+	}
+}